@@ -0,0 +1,146 @@
+package allora
+
+import (
+	"fmt"
+
+	"cosmossdk.io/core/address"
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AddressPrefixes holds the bech32 human-readable prefixes of a chain's account,
+// validator operator and consensus addresses.
+//
+// The SDK never reads or modifies the global sdk.Config, so sdk.AccAddress.String()
+// (which uses it) does not produce Allora addresses unless the program configures it.
+// Use these helpers to encode and decode addresses instead. This lets the SDK be used
+// alongside clients of other Cosmos chains in the same program.
+type AddressPrefixes struct {
+	Account   string
+	Validator string
+	Consensus string
+}
+
+// AlloraAddressPrefixes are the prefixes of Allora Network addresses (allo, allovaloper, allovalcons)
+var AlloraAddressPrefixes = NewAddressPrefixes(AlloraBech32Prefix)
+
+// NewAddressPrefixes derives the standard Cosmos prefixes from a base prefix,
+// e.g. "osmo" gives osmo, osmovaloper and osmovalcons
+func NewAddressPrefixes(base string) AddressPrefixes {
+	return AddressPrefixes{
+		Account:   base,
+		Validator: base + "valoper",
+		Consensus: base + "valcons",
+	}
+}
+
+// AccountCodec returns an address codec for account addresses
+func (p AddressPrefixes) AccountCodec() address.Codec {
+	return addresscodec.NewBech32Codec(p.Account)
+}
+
+// ValidatorCodec returns an address codec for validator operator addresses
+func (p AddressPrefixes) ValidatorCodec() address.Codec {
+	return addresscodec.NewBech32Codec(p.Validator)
+}
+
+// ConsensusCodec returns an address codec for consensus addresses
+func (p AddressPrefixes) ConsensusCodec() address.Codec {
+	return addresscodec.NewBech32Codec(p.Consensus)
+}
+
+// FormatAccAddress encodes an account address
+func (p AddressPrefixes) FormatAccAddress(addr sdk.AccAddress) string {
+	return mustBech32(p.AccountCodec(), addr)
+}
+
+// FormatValAddress encodes a validator operator address
+func (p AddressPrefixes) FormatValAddress(addr sdk.ValAddress) string {
+	return mustBech32(p.ValidatorCodec(), addr)
+}
+
+// FormatConsAddress encodes a consensus address
+func (p AddressPrefixes) FormatConsAddress(addr sdk.ConsAddress) string {
+	return mustBech32(p.ConsensusCodec(), addr)
+}
+
+// ParseAccAddress decodes an account address, rejecting addresses with another prefix
+func (p AddressPrefixes) ParseAccAddress(addr string) (sdk.AccAddress, error) {
+	bz, err := p.AccountCodec().StringToBytes(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address %q: %w", addr, err)
+	}
+	return sdk.AccAddress(bz), nil
+}
+
+// ParseValAddress decodes a validator operator address, rejecting addresses with another prefix
+func (p AddressPrefixes) ParseValAddress(addr string) (sdk.ValAddress, error) {
+	bz, err := p.ValidatorCodec().StringToBytes(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator address %q: %w", addr, err)
+	}
+	return sdk.ValAddress(bz), nil
+}
+
+// ParseConsAddress decodes a consensus address, rejecting addresses with another prefix
+func (p AddressPrefixes) ParseConsAddress(addr string) (sdk.ConsAddress, error) {
+	bz, err := p.ConsensusCodec().StringToBytes(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consensus address %q: %w", addr, err)
+	}
+	return sdk.ConsAddress(bz), nil
+}
+
+// FormatAccAddress encodes an Allora account address (allo1...)
+func FormatAccAddress(addr sdk.AccAddress) string {
+	return AlloraAddressPrefixes.FormatAccAddress(addr)
+}
+
+// FormatValAddress encodes an Allora validator operator address (allovaloper1...)
+func FormatValAddress(addr sdk.ValAddress) string {
+	return AlloraAddressPrefixes.FormatValAddress(addr)
+}
+
+// FormatConsAddress encodes an Allora consensus address (allovalcons1...)
+func FormatConsAddress(addr sdk.ConsAddress) string {
+	return AlloraAddressPrefixes.FormatConsAddress(addr)
+}
+
+// ParseAccAddress decodes an Allora account address (allo1...)
+func ParseAccAddress(addr string) (sdk.AccAddress, error) {
+	return AlloraAddressPrefixes.ParseAccAddress(addr)
+}
+
+// ParseValAddress decodes an Allora validator operator address (allovaloper1...)
+func ParseValAddress(addr string) (sdk.ValAddress, error) {
+	return AlloraAddressPrefixes.ParseValAddress(addr)
+}
+
+// ParseConsAddress decodes an Allora consensus address (allovalcons1...)
+func ParseConsAddress(addr string) (sdk.ConsAddress, error) {
+	return AlloraAddressPrefixes.ParseConsAddress(addr)
+}
+
+// ValidateBech32Prefix checks that addresses encoded with the prefix can be decoded
+// again, e.g. that it has no uppercase or invalid characters
+func ValidateBech32Prefix(prefix string) error {
+	codec := addresscodec.NewBech32Codec(prefix)
+	s, err := codec.BytesToString(make([]byte, 32))
+	if err == nil {
+		_, err = codec.StringToBytes(s)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid bech32 prefix %q: %w", prefix, err)
+	}
+	return nil
+}
+
+// mustBech32 encodes addr with the codec. Encoding only fails for an invalid prefix,
+// which is a programming error.
+func mustBech32(codec address.Codec, addr []byte) string {
+	s, err := codec.BytesToString(addr)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode bech32 address: %v", err))
+	}
+	return s
+}
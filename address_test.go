@@ -0,0 +1,107 @@
+package allora
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAddressFormatAndParse(t *testing.T) {
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+
+	acc := FormatAccAddress(wallet.Address)
+	if !strings.HasPrefix(acc, "allo1") || acc != wallet.GetAddress() {
+		t.Fatalf("unexpected account address %s", acc)
+	}
+	parsed, err := ParseAccAddress(acc)
+	if err != nil || !parsed.Equals(wallet.Address) {
+		t.Fatalf("failed to round-trip account address: %v", err)
+	}
+
+	val := FormatValAddress(sdk.ValAddress(wallet.Address))
+	if !strings.HasPrefix(val, "allovaloper1") {
+		t.Fatalf("unexpected validator address %s", val)
+	}
+	if _, err := ParseValAddress(val); err != nil {
+		t.Fatalf("failed to parse validator address: %v", err)
+	}
+	if _, err := ParseAccAddress(val); err == nil {
+		t.Fatal("expected validator address to be rejected as account address")
+	}
+
+	cons := FormatConsAddress(sdk.ConsAddress(wallet.Address))
+	if !strings.HasPrefix(cons, "allovalcons1") {
+		t.Fatalf("unexpected consensus address %s", cons)
+	}
+	if _, err := ParseConsAddress(cons); err != nil {
+		t.Fatalf("failed to parse consensus address: %v", err)
+	}
+
+	osmo := NewAddressPrefixes("osmo").FormatAccAddress(wallet.Address)
+	if !strings.HasPrefix(osmo, "osmo1") {
+		t.Fatalf("unexpected osmo address %s", osmo)
+	}
+	if _, err := ParseAccAddress(osmo); err == nil {
+		t.Fatal("expected address of another chain to be rejected")
+	}
+}
+
+func TestWalletBech32Prefix(t *testing.T) {
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	allo := wallet.GetAddress()
+
+	if err := wallet.SetBech32Prefix("osmo"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	if wallet.GetBech32Prefix() != "osmo" || !strings.HasPrefix(wallet.GetAddress(), "osmo1") {
+		t.Fatalf("unexpected address %s", wallet.GetAddress())
+	}
+	addr, err := NewAddressPrefixes("osmo").ParseAccAddress(wallet.GetAddress())
+	if err != nil || FormatAccAddress(addr) != allo {
+		t.Fatalf("expected the same key on both chains: %v", err)
+	}
+}
+
+func TestWalletRejectsInvalidBech32Prefix(t *testing.T) {
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	for _, prefix := range []string{"Osmo", "os mo", "osmo\x7f"} {
+		if err := wallet.SetBech32Prefix(prefix); err == nil {
+			t.Errorf("expected prefix %q to be rejected", prefix)
+		}
+	}
+	if prefix := wallet.GetBech32Prefix(); prefix != AlloraBech32Prefix {
+		t.Errorf("expected the prefix to stay %s, got %s", AlloraBech32Prefix, prefix)
+	}
+	if !strings.HasPrefix(wallet.GetAddress(), AlloraBech32Prefix+"1") {
+		t.Errorf("unexpected address %s", wallet.GetAddress())
+	}
+}
+
+func TestGlobalConfigUntouched(t *testing.T) {
+	if _, err := GenerateWallet(); err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+
+	config := sdk.GetConfig()
+	if prefix := config.GetBech32AccountAddrPrefix(); prefix != sdk.Bech32MainPrefix {
+		t.Fatalf("expected global account prefix %q, got %q", sdk.Bech32MainPrefix, prefix)
+	}
+
+	// Another chain's client must still be able to configure the global prefixes
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("global sdk.Config must not be sealed: %v", r)
+		}
+	}()
+	config.SetBech32PrefixForAccount(sdk.Bech32MainPrefix, sdk.Bech32PrefixAccPub)
+}
@@ -0,0 +1,476 @@
+package allora
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/pool"
+	"github.com/allora-network/allora-sdk-go/tmrpc"
+)
+
+// BroadcastMode selects how a signed transaction is submitted to the network
+type BroadcastMode int
+
+const (
+	// BroadcastModeSync returns once the transaction has passed CheckTx
+	BroadcastModeSync BroadcastMode = iota
+	// BroadcastModeAsync returns as soon as the node has received the transaction
+	BroadcastModeAsync
+)
+
+// ErrTxNotIncluded is returned when a broadcast transaction was not observed in
+// a block before the inclusion timeout elapsed
+var ErrTxNotIncluded = errors.New("transaction not included in a block before timeout")
+
+// BroadcastOpt is a functional option for configuring a broadcast
+type BroadcastOpt func(*BroadcastOpts)
+
+// BroadcastOpts holds configuration options for broadcasting a transaction
+type BroadcastOpts struct {
+	Mode             BroadcastMode
+	WaitForInclusion bool
+	Timeout          time.Duration
+	PollInterval     time.Duration
+}
+
+// Apply applies the provided options to BroadcastOpts
+func (o *BroadcastOpts) Apply(opts ...BroadcastOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultBroadcastOpts returns default broadcast options: sync mode, waiting up
+// to one minute for the transaction to be included in a block
+func DefaultBroadcastOpts() *BroadcastOpts {
+	return &BroadcastOpts{
+		Mode:             BroadcastModeSync,
+		WaitForInclusion: true,
+		Timeout:          60 * time.Second,
+		PollInterval:     time.Second,
+	}
+}
+
+// WithBroadcastMode sets the broadcast mode
+func WithBroadcastMode(mode BroadcastMode) BroadcastOpt {
+	return func(opts *BroadcastOpts) {
+		opts.Mode = mode
+	}
+}
+
+// WithWaitForInclusion sets whether BroadcastTx waits for the transaction to be included in a block
+func WithWaitForInclusion(wait bool) BroadcastOpt {
+	return func(opts *BroadcastOpts) {
+		opts.WaitForInclusion = wait
+	}
+}
+
+// WithInclusionTimeout sets how long to wait for the transaction to be included in a block
+func WithInclusionTimeout(timeout time.Duration) BroadcastOpt {
+	return func(opts *BroadcastOpts) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval at which GetTx is polled while waiting for inclusion
+func WithPollInterval(interval time.Duration) BroadcastOpt {
+	return func(opts *BroadcastOpts) {
+		opts.PollInterval = interval
+	}
+}
+
+// BroadcastResult describes the outcome of a broadcast transaction
+type BroadcastResult struct {
+	TxHash    string
+	Height    int64 // zero until the transaction is included in a block
+	Codespace string
+	Code      uint32
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+	Events    []abcitypes.Event
+}
+
+// Included reports whether the transaction has been included in a block
+func (r *BroadcastResult) Included() bool {
+	return r.Height > 0
+}
+
+// TxError is returned when the chain rejects a transaction, either during
+// CheckTx (Height is zero) or during block execution
+type TxError struct {
+	TxHash    string
+	Height    int64
+	Codespace string
+	Code      uint32
+	Log       string
+}
+
+func (e *TxError) Error() string {
+	if e.Height > 0 {
+		return fmt.Sprintf("tx %s failed at height %d (codespace=%s, code=%d): %s", e.TxHash, e.Height, e.Codespace, e.Code, e.Log)
+	}
+	return fmt.Sprintf("tx %s rejected (codespace=%s, code=%d): %s", e.TxHash, e.Codespace, e.Code, e.Log)
+}
+
+// Is reports whether the error matches a registered Cosmos SDK error, e.g.
+// errors.Is(err, sdkerrors.ErrOutOfGas)
+func (e *TxError) Is(target error) bool {
+	type abciCoder interface {
+		Codespace() string
+		ABCICode() uint32
+	}
+	t, ok := target.(abciCoder)
+	if !ok {
+		return false
+	}
+	return t.Codespace() == e.Codespace && t.ABCICode() == e.Code
+}
+
+// TxHash returns the hex-encoded (uppercase) hash of the given transaction bytes,
+// as reported by CometBFT and accepted by GetTx
+func TxHash(txBytes []byte) string {
+	return strings.ToUpper(hex.EncodeToString(ctypes.Tx(txBytes).Hash()))
+}
+
+// BroadcastTx submits a signed transaction through the Cosmos client pool
+//
+// In sync mode the call fails with a *TxError if the transaction is rejected by
+// CheckTx. Unless disabled with WithWaitForInclusion(false), it then waits for the
+// transaction to be included in a block, listening for websocket Tx events and
+// falling back to polling GetTx, and returns a *TxError if execution failed.
+//
+// Example:
+//
+//	signedTx, _ := allora.SignTransaction(unsignedTx, wallet, params)
+//	res, err := client.BroadcastTx(ctx, signedTx)
+//	if err != nil {
+//	    var txErr *allora.TxError
+//	    if errors.As(err, &txErr) {
+//	        fmt.Printf("rejected: %s\n", txErr.Log)
+//	    }
+//	    return err
+//	}
+//	fmt.Printf("included at height %d\n", res.Height)
+func (c *client) BroadcastTx(ctx context.Context, txBytes []byte, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	if len(txBytes) == 0 {
+		return nil, fmt.Errorf("transaction is empty")
+	}
+
+	o := DefaultBroadcastOpts()
+	o.Apply(opts...)
+
+	hash := TxHash(txBytes)
+
+	// Register interest before broadcasting so the inclusion event cannot be missed
+	var included <-chan *abcitypes.TxResult
+	if o.WaitForInclusion {
+		var cancel func()
+		included, cancel = c.txWatcher.watch(hash)
+		defer cancel()
+	}
+
+	mode := txtypes.BroadcastMode_BROADCAST_MODE_SYNC
+	if o.Mode == BroadcastModeAsync {
+		mode = txtypes.BroadcastMode_BROADCAST_MODE_ASYNC
+	}
+
+	resp, err := c.cosmosPool.Tx().BroadcastTx(ctx, &txtypes.BroadcastTxRequest{
+		TxBytes: txBytes,
+		Mode:    mode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	if resp.TxResponse == nil {
+		return nil, fmt.Errorf("broadcast returned an empty response")
+	}
+
+	txResp := resp.TxResponse
+
+	// A retried broadcast may land on a node that already has the tx in its mempool
+	alreadyInMempool := txResp.Codespace == sdkerrors.RootCodespace && txResp.Code == sdkerrors.ErrTxInMempoolCache.ABCICode()
+	if txResp.Code != 0 && !alreadyInMempool {
+		return nil, &TxError{
+			TxHash:    hash,
+			Codespace: txResp.Codespace,
+			Code:      txResp.Code,
+			Log:       txResp.RawLog,
+		}
+	}
+
+	if !o.WaitForInclusion {
+		return &BroadcastResult{
+			TxHash:    hash,
+			Codespace: txResp.Codespace,
+			Code:      txResp.Code,
+			Log:       txResp.RawLog,
+			GasWanted: txResp.GasWanted,
+			GasUsed:   txResp.GasUsed,
+			Events:    txResp.Events,
+		}, nil
+	}
+
+	return c.waitForTx(ctx, hash, included, o, false)
+}
+
+// WaitForTx waits for a previously broadcast transaction to be included in a block
+//
+// This is useful after broadcasting with WithWaitForInclusion(false), e.g. when
+// the transaction hash was persisted and confirmation happens elsewhere.
+func (c *client) WaitForTx(ctx context.Context, txHash string, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	o := DefaultBroadcastOpts()
+	o.Apply(opts...)
+
+	hash := strings.ToUpper(txHash)
+	included, cancel := c.txWatcher.watch(hash)
+	defer cancel()
+
+	return c.waitForTx(ctx, hash, included, o, true)
+}
+
+// waitForTx waits for the Tx event of the transaction, polling GetTx while no Tx
+// event subscription is live. With pollFirst, GetTx is also queried right away.
+func (c *client) waitForTx(ctx context.Context, hash string, included <-chan *abcitypes.TxResult, o *BroadcastOpts, pollFirst bool) (*BroadcastResult, error) {
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	pollInterval := o.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultBroadcastOpts().PollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// A transaction waited on after its broadcast may already be in a block
+	if pollFirst {
+		if res, found, err := c.pollTx(ctx, hash); found {
+			return res, err
+		}
+	}
+
+	for {
+		select {
+		case txResult := <-included:
+			return resultFromExecTx(hash, txResult.Height, &txResult.Result)
+
+		case <-ticker.C:
+			// Polling is the fallback for when Tx events are not delivered
+			if c.txWatcher.live() {
+				continue
+			}
+			if res, found, err := c.pollTx(ctx, hash); found {
+				return res, err
+			}
+
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: %s", ErrTxNotIncluded, hash)
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pollTx queries the transaction with GetTx, reporting whether it was found in a block
+func (c *client) pollTx(ctx context.Context, hash string) (*BroadcastResult, bool, error) {
+	resp, err := c.cosmosPool.Tx().GetTx(ctx, &txtypes.GetTxRequest{Hash: hash})
+	if err != nil && !isNotFound(err) {
+		c.logger.Warn().Err(err).Str("tx_hash", hash).Msg("failed to poll transaction")
+		return nil, false, nil
+	} else if err != nil || resp.TxResponse == nil {
+		c.logger.Debug().Str("tx_hash", hash).Msg("transaction not found yet")
+		return nil, false, nil
+	}
+	res, err := resultFromTxResponse(hash, resp.TxResponse)
+	return res, true, err
+}
+
+// isNotFound reports whether a query failed because the node does not have the
+// requested item, e.g. a transaction that is not yet included in a block
+func isNotFound(err error) bool {
+	return pool.IsNotFound(err)
+}
+
+func resultFromExecTx(hash string, height int64, res *abcitypes.ExecTxResult) (*BroadcastResult, error) {
+	result := &BroadcastResult{
+		TxHash:    hash,
+		Height:    height,
+		Codespace: res.Codespace,
+		Code:      res.Code,
+		Log:       res.Log,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
+		Events:    res.Events,
+	}
+	if res.Code != 0 {
+		return result, &TxError{TxHash: hash, Height: height, Codespace: res.Codespace, Code: res.Code, Log: res.Log}
+	}
+	return result, nil
+}
+
+func resultFromTxResponse(hash string, res *sdk.TxResponse) (*BroadcastResult, error) {
+	result := &BroadcastResult{
+		TxHash:    hash,
+		Height:    res.Height,
+		Codespace: res.Codespace,
+		Code:      res.Code,
+		Log:       res.RawLog,
+		GasWanted: res.GasWanted,
+		GasUsed:   res.GasUsed,
+		Events:    res.Events,
+	}
+	if res.Code != 0 {
+		return result, &TxError{TxHash: hash, Height: res.Height, Codespace: res.Codespace, Code: res.Code, Log: res.RawLog}
+	}
+	return result, nil
+}
+
+// txWatcher maintains a single websocket subscription to Tx events and
+// dispatches them to goroutines waiting on specific transaction hashes
+type txWatcher struct {
+	websocketPool tmrpc.WebsocketPool
+	logger        zerolog.Logger
+
+	mu      sync.Mutex
+	started bool
+	failed  bool // the subscription reported an error
+	waiters map[string][]chan *abcitypes.TxResult
+
+	mb     *tmrpc.Mailbox
+	sub    tmrpc.Subscription
+	chStop chan struct{}
+	wgDone sync.WaitGroup
+}
+
+func newTxWatcher(websocketPool tmrpc.WebsocketPool, logger zerolog.Logger) *txWatcher {
+	return &txWatcher{
+		websocketPool: websocketPool,
+		logger:        logger.With().Str("component", "tx_watcher").Logger(),
+		waiters:       make(map[string][]chan *abcitypes.TxResult),
+		chStop:        make(chan struct{}),
+	}
+}
+
+// watch registers interest in a transaction hash. The returned channel receives
+// the execution result once a matching Tx event arrives; cancel must be called
+// to release the registration.
+func (w *txWatcher) watch(hash string) (<-chan *abcitypes.TxResult, func()) {
+	ch := make(chan *abcitypes.TxResult, 1)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.startLocked()
+	w.waiters[hash] = append(w.waiters[hash], ch)
+
+	cancel := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		chans := w.waiters[hash]
+		for i := range chans {
+			if chans[i] == ch {
+				chans = append(chans[:i], chans[i+1:]...)
+				break
+			}
+		}
+		if len(chans) == 0 {
+			delete(w.waiters, hash)
+		} else {
+			w.waiters[hash] = chans
+		}
+	}
+	return ch, cancel
+}
+
+func (w *txWatcher) startLocked() {
+	if w.started || w.websocketPool == nil {
+		return
+	}
+	w.started = true
+
+	w.mb = tmrpc.NewMailbox(1000)
+	sub, err := w.websocketPool.Subscribe(w.mb, ctypes.EventQueryTx.String())
+	if err != nil {
+		// Waiting falls back to polling GetTx
+		w.logger.Debug().Err(err).Msg("not watching Tx events")
+		return
+	}
+	w.sub = sub
+
+	w.wgDone.Add(1)
+	go w.run()
+}
+
+func (w *txWatcher) run() {
+	defer w.wgDone.Done()
+
+	for {
+		select {
+		case <-w.chStop:
+			return
+		case err := <-w.sub.Err():
+			w.logger.Error().Err(err).Msg("Tx event subscription failed, falling back to polling")
+			w.mu.Lock()
+			w.failed = true
+			w.mu.Unlock()
+		case <-w.mb.Notify():
+			for _, evt := range w.mb.RetrieveAll() {
+				txEvt, ok := evt.(ctypes.EventDataTx)
+				if !ok {
+					continue
+				}
+				w.dispatch(TxHash(txEvt.Tx), &txEvt.TxResult)
+			}
+		}
+	}
+}
+
+// live reports whether Tx events are being delivered
+func (w *txWatcher) live() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sub != nil && !w.failed
+}
+
+func (w *txWatcher) dispatch(hash string, result *abcitypes.TxResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ch := range w.waiters[hash] {
+		select {
+		case ch <- result:
+		default: // already notified (e.g. duplicate delivery from another websocket)
+		}
+	}
+}
+
+func (w *txWatcher) close() {
+	w.mu.Lock()
+	sub := w.sub
+	w.mu.Unlock()
+
+	if sub != nil {
+		close(w.chStop)
+		w.wgDone.Wait()
+		if err := sub.Unsubscribe(); err != nil {
+			w.logger.Debug().Err(err).Msg("failed to unsubscribe from Tx events")
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package allora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/cosmosrpc"
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+)
+
+func TestTxErrorMatchesRegisteredErrors(t *testing.T) {
+	var err error = &TxError{
+		TxHash:    "ABCD",
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrOutOfGas.ABCICode(),
+		Log:       "out of gas in location: WriteFlat",
+	}
+
+	if !errors.Is(err, sdkerrors.ErrOutOfGas) {
+		t.Error("expected TxError to match ErrOutOfGas")
+	}
+	if errors.Is(err, sdkerrors.ErrInsufficientFee) {
+		t.Error("expected TxError not to match ErrInsufficientFee")
+	}
+
+	var txErr *TxError
+	if !errors.As(err, &txErr) || txErr.TxHash != "ABCD" {
+		t.Error("expected errors.As to extract the TxError")
+	}
+}
+
+func TestResultFromTxResponse(t *testing.T) {
+	res, err := resultFromTxResponse("ABCD", &sdk.TxResponse{Height: 100, GasUsed: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Included() || res.Height != 100 || res.GasUsed != 42 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	_, err = resultFromTxResponse("ABCD", &sdk.TxResponse{Height: 100, Codespace: "sdk", Code: 5, RawLog: "insufficient funds"})
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected TxError, got %v", err)
+	}
+	if txErr.Height != 100 || txErr.Code != 5 {
+		t.Errorf("unexpected tx error: %+v", txErr)
+	}
+}
+
+func TestTxHash(t *testing.T) {
+	// sha256("") in uppercase hex
+	const expected = "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+	if got := TxHash([]byte{}); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+// fakeTxPool serves the Tx service from canned responses; GetTx answers NotFound
+// until the transaction has been polled notFoundPolls times
+type fakeTxPool struct {
+	cosmosrpc.ClientPool
+	interfaces.TxClient
+
+	checkTx       *sdk.TxResponse
+	deliverTx     *sdk.TxResponse
+	notFoundPolls int
+	gasUsed       uint64
+	simulateErr   error
+
+	mu    sync.Mutex
+	polls int
+}
+
+func (p *fakeTxPool) Tx() interfaces.TxClient { return p }
+
+func (p *fakeTxPool) BroadcastTx(ctx context.Context, req *txtypes.BroadcastTxRequest, opts ...config.CallOpt) (*txtypes.BroadcastTxResponse, error) {
+	return &txtypes.BroadcastTxResponse{TxResponse: p.checkTx}, nil
+}
+
+func (p *fakeTxPool) GetTx(ctx context.Context, req *txtypes.GetTxRequest, opts ...config.CallOpt) (*txtypes.GetTxResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.polls++
+	if p.deliverTx == nil || p.polls <= p.notFoundPolls {
+		return nil, status.Errorf(codes.NotFound, "tx not found: %s", req.Hash)
+	}
+	return &txtypes.GetTxResponse{TxResponse: p.deliverTx}, nil
+}
+
+func (p *fakeTxPool) Simulate(ctx context.Context, req *txtypes.SimulateRequest, opts ...config.CallOpt) (*txtypes.SimulateResponse, error) {
+	if p.simulateErr != nil {
+		return nil, p.simulateErr
+	}
+	return &txtypes.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: p.gasUsed}}, nil
+}
+
+func (p *fakeTxPool) pollCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.polls
+}
+
+func newBroadcastTestClient(txPool *fakeTxPool, websocketPool *fakeWebsocketPool) *client {
+	c := &client{cosmosPool: txPool, logger: zerolog.Nop()}
+	if websocketPool != nil {
+		c.websocketPool = websocketPool
+		c.txWatcher = newTxWatcher(websocketPool, c.logger)
+	} else {
+		c.txWatcher = newTxWatcher(nil, c.logger)
+	}
+	return c
+}
+
+func TestBroadcastTxPollsUntilIncluded(t *testing.T) {
+	txPool := &fakeTxPool{
+		checkTx:       &sdk.TxResponse{},
+		deliverTx:     &sdk.TxResponse{Height: 42, GasUsed: 1000},
+		notFoundPolls: 2,
+	}
+	c := newBroadcastTestClient(txPool, nil)
+
+	res, err := c.BroadcastTx(context.Background(), []byte("tx"), WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Included() || res.Height != 42 || res.GasUsed != 1000 || res.TxHash != TxHash([]byte("tx")) {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if polls := txPool.pollCount(); polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+}
+
+func (p *fakeWebsocketPool) subscribed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.mbs) > 0
+}
+
+func TestBroadcastTxIncludedViaWebsocket(t *testing.T) {
+	txPool := &fakeTxPool{checkTx: &sdk.TxResponse{}}
+	websocketPool := &fakeWebsocketPool{}
+	c := newBroadcastTestClient(txPool, websocketPool)
+	defer c.txWatcher.close()
+
+	go func() {
+		// The watcher subscribes before broadcasting, so the mailbox exists by now
+		for !websocketPool.subscribed() {
+			time.Sleep(time.Millisecond)
+		}
+		websocketPool.deliver(ctypes.EventDataTx{TxResult: abcitypes.TxResult{
+			Height: 7,
+			Tx:     []byte("tx"),
+		}})
+	}()
+
+	res, err := c.BroadcastTx(context.Background(), []byte("tx"), WithPollInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Height != 7 {
+		t.Errorf("expected inclusion at height 7, got %d", res.Height)
+	}
+}
+
+func TestBroadcastTxSkipsPollingWhileSubscribed(t *testing.T) {
+	txPool := &fakeTxPool{checkTx: &sdk.TxResponse{}}
+	websocketPool := &fakeWebsocketPool{}
+	c := newBroadcastTestClient(txPool, websocketPool)
+	defer c.txWatcher.close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		websocketPool.deliver(ctypes.EventDataTx{TxResult: abcitypes.TxResult{
+			Height: 7,
+			Tx:     []byte("tx"),
+		}})
+	}()
+
+	if _, err := c.BroadcastTx(context.Background(), []byte("tx"), WithPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls := txPool.pollCount(); polls != 0 {
+		t.Errorf("expected no polling while Tx events are delivered, got %d polls", polls)
+	}
+}
+
+func TestWaitForTxFindsIncludedTx(t *testing.T) {
+	txPool := &fakeTxPool{deliverTx: &sdk.TxResponse{Height: 42}}
+	c := newBroadcastTestClient(txPool, &fakeWebsocketPool{})
+	defer c.txWatcher.close()
+
+	res, err := c.WaitForTx(context.Background(), "abcd", WithPollInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Height != 42 {
+		t.Errorf("expected inclusion at height 42, got %d", res.Height)
+	}
+}
+
+func TestBroadcastTxCheckTxRejection(t *testing.T) {
+	txPool := &fakeTxPool{checkTx: &sdk.TxResponse{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrInsufficientFee.ABCICode(),
+		RawLog:    "insufficient fees",
+	}}
+	c := newBroadcastTestClient(txPool, nil)
+
+	_, err := c.BroadcastTx(context.Background(), []byte("tx"), WithPollInterval(time.Millisecond))
+	var txErr *TxError
+	if !errors.As(err, &txErr) || !errors.Is(err, sdkerrors.ErrInsufficientFee) {
+		t.Fatalf("expected insufficient fee TxError, got %v", err)
+	}
+	if txErr.Height != 0 {
+		t.Errorf("expected a CheckTx rejection without height, got %d", txErr.Height)
+	}
+	if polls := txPool.pollCount(); polls != 0 {
+		t.Errorf("expected no polling after CheckTx rejection, got %d polls", polls)
+	}
+}
+
+func TestBroadcastTxDeliverTxFailure(t *testing.T) {
+	txPool := &fakeTxPool{
+		checkTx: &sdk.TxResponse{},
+		deliverTx: &sdk.TxResponse{
+			Height:    42,
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrOutOfGas.ABCICode(),
+			RawLog:    "out of gas",
+		},
+		notFoundPolls: 1,
+	}
+	c := newBroadcastTestClient(txPool, nil)
+
+	res, err := c.BroadcastTx(context.Background(), []byte("tx"), WithPollInterval(time.Millisecond))
+	var txErr *TxError
+	if !errors.As(err, &txErr) || !errors.Is(err, sdkerrors.ErrOutOfGas) {
+		t.Fatalf("expected out of gas TxError, got %v", err)
+	}
+	if txErr.Height != 42 || res == nil || res.Height != 42 {
+		t.Errorf("expected the failure to be reported at height 42, got %+v / %+v", txErr, res)
+	}
+}
+
+func TestWaitForTxTimeout(t *testing.T) {
+	txPool := &fakeTxPool{}
+	c := newBroadcastTestClient(txPool, nil)
+
+	_, err := c.WaitForTx(context.Background(), "abcd",
+		WithPollInterval(time.Millisecond),
+		WithInclusionTimeout(20*time.Millisecond),
+	)
+	if !errors.Is(err, ErrTxNotIncluded) {
+		t.Fatalf("expected ErrTxNotIncluded, got %v", err)
+	}
+	if txPool.pollCount() == 0 {
+		t.Error("expected GetTx to be polled before timing out")
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{status.Error(codes.NotFound, "tx not found"), true},
+		{fmt.Errorf("all clients failed, last error: %w", status.Error(codes.NotFound, "tx not found")), true},
+		{errors.New("HTTP error 404: 404 Not Found"), true},
+		{status.Error(codes.Unavailable, "connection refused"), false},
+		{errors.New("HTTP error 500: 500 Internal Server Error"), false},
+		{errors.New("client not found in pools"), false},
+	} {
+		if got := isNotFound(tc.err); got != tc.want {
+			t.Errorf("isNotFound(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
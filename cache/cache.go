@@ -0,0 +1,155 @@
+// Package cache caches the responses of cosmos queries. Responses to queries pinned
+// to a height never change and are cached until evicted; responses at the latest
+// height are cached for a short per-module TTL.
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/metrics"
+)
+
+// Store holds encoded responses by key. A ttl of zero means the entry never expires.
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// tiered looks entries up in memory first and falls back to disk
+type tiered struct {
+	memory Store
+	disk   Store
+}
+
+func (t tiered) Get(key string) ([]byte, bool) {
+	if value, ok := t.memory.Get(key); ok {
+		return value, true
+	}
+	value, ok := t.disk.Get(key)
+	if ok {
+		t.memory.Set(key, value, 0)
+	}
+	return value, ok
+}
+
+func (t tiered) Set(key string, value []byte, ttl time.Duration) {
+	t.memory.Set(key, value, ttl)
+	t.disk.Set(key, value, ttl)
+}
+
+// Cache caches query responses in a Store according to a CacheConfig. A nil *Cache
+// caches nothing.
+type Cache struct {
+	store     Store
+	latestTTL time.Duration
+	moduleTTL map[string]time.Duration
+}
+
+// New creates a cache with an in-memory LRU and, if cfg.Dir is set, an on-disk
+// store for height-pinned responses. Zero values in cfg are replaced by the
+// defaults of config.DefaultCacheConfig.
+func New(cfg config.CacheConfig) (*Cache, error) {
+	defaults := config.DefaultCacheConfig()
+	if cfg.Size <= 0 {
+		cfg.Size = defaults.Size
+	}
+
+	var store Store = NewLRU(cfg.Size)
+	if cfg.Dir != "" {
+		disk, err := NewDisk(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		store = tiered{memory: store, disk: disk}
+	}
+	return NewWithStore(store, cfg), nil
+}
+
+// NewWithStore creates a cache on top of the given store, using the TTLs of cfg. A
+// zero LatestTTL takes its default.
+func NewWithStore(store Store, cfg config.CacheConfig) *Cache {
+	if cfg.LatestTTL == 0 {
+		cfg.LatestTTL = config.DefaultCacheConfig().LatestTTL
+	}
+	return &Cache{
+		store:     store,
+		latestTTL: cfg.LatestTTL,
+		moduleTTL: cfg.ModuleTTL,
+	}
+}
+
+// ttl returns how long a response at the given height may be cached, and whether
+// it may be cached at all
+func (c *Cache) ttl(module string, height int64) (time.Duration, bool) {
+	if height > 0 {
+		return 0, true
+	}
+	ttl, ok := c.moduleTTL[module]
+	if !ok {
+		ttl = c.latestTTL
+	}
+	return ttl, ttl > 0
+}
+
+// Query returns the cached response to req if there is one, and otherwise calls fetch
+// and caches its response. Only successful responses are cached. Queries at a height
+// are cached indefinitely, since the node only answers them for committed blocks;
+// queries at the latest height are cached for the module's TTL.
+func Query[T any, PT interface {
+	*T
+	proto.Message
+}](c *Cache, module, method string, req proto.Message, opts []config.CallOpt, fetch func() (PT, error)) (PT, error) {
+	if c == nil || isNil(req) {
+		return fetch()
+	}
+
+	callOpts := config.DefaultCallOpts()
+	callOpts.Apply(opts...)
+	ttl, ok := c.ttl(module, callOpts.Height)
+	if !ok {
+		return fetch()
+	}
+
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return fetch()
+	}
+	key := cacheKey(module, method, callOpts.Height, reqBytes)
+
+	if value, ok := c.store.Get(key); ok {
+		resp := PT(new(T))
+		if err := proto.Unmarshal(value, resp); err == nil {
+			metrics.ObserveCacheLookup(module, method, true)
+			return resp, nil
+		}
+	}
+	metrics.ObserveCacheLookup(module, method, false)
+
+	resp, err := fetch()
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if value, err := proto.Marshal(resp); err == nil {
+		c.store.Set(key, value, ttl)
+	}
+	return resp, nil
+}
+
+func cacheKey(module, method string, height int64, req []byte) string {
+	sum := sha256.Sum256(req)
+	return fmt.Sprintf("%s/%s/%d/%x", module, method, height, sum)
+}
+
+func isNil(msg proto.Message) bool {
+	if msg == nil {
+		return true
+	}
+	v := reflect.ValueOf(msg)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
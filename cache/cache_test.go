@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// balances counts the queries that reach the network
+type balances struct {
+	calls int
+	err   error
+}
+
+func (b *balances) query(c *Cache, address string, opts ...config.CallOpt) (*banktypes.QueryBalanceResponse, error) {
+	req := &banktypes.QueryBalanceRequest{Address: address, Denom: "uallo"}
+	return Query(c, "bank", "Balance", req, opts, func() (*banktypes.QueryBalanceResponse, error) {
+		b.calls++
+		if b.err != nil {
+			return nil, b.err
+		}
+		coin := sdk.NewInt64Coin("uallo", int64(b.calls))
+		return &banktypes.QueryBalanceResponse{Balance: &coin}, nil
+	})
+}
+
+func TestQueryCachesHeightPinnedResponses(t *testing.T) {
+	c, err := New(config.CacheConfig{LatestTTL: -1})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	b := &balances{}
+
+	first, err := b.query(c, "allo1a", config.Height(100))
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	second, err := b.query(c, "allo1a", config.Height(100))
+	if err != nil || b.calls != 1 || !second.Balance.Equal(*first.Balance) || second == first {
+		t.Fatalf("expected a cached copy of the first response, got %v after %d calls (%v)", second, b.calls, err)
+	}
+
+	// Other heights and requests miss, and latest-height queries aren't cached without a TTL
+	b.query(c, "allo1a", config.Height(101))
+	b.query(c, "allo1b", config.Height(100))
+	b.query(c, "allo1a")
+	b.query(c, "allo1a")
+	if b.calls != 5 {
+		t.Fatalf("expected 5 calls, got %d", b.calls)
+	}
+
+	// Failed queries aren't cached
+	b.err = errors.New("unavailable")
+	if _, err := b.query(c, "allo1c", config.Height(100)); err == nil {
+		t.Fatal("expected the error to be returned")
+	}
+	b.err = nil
+	if _, err := b.query(c, "allo1c", config.Height(100)); err != nil || b.calls != 7 {
+		t.Fatalf("expected the failed query to be retried, got %d calls (%v)", b.calls, err)
+	}
+
+	// A nil cache passes every query through
+	b.query(nil, "allo1a", config.Height(100))
+	if b.calls != 8 {
+		t.Fatalf("expected a nil cache to query, got %d calls", b.calls)
+	}
+}
+
+func TestNewDefaultsLatestTTL(t *testing.T) {
+	c, err := New(config.CacheConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	if ttl, ok := c.ttl("bank", 0); !ok || ttl != config.DefaultCacheConfig().LatestTTL {
+		t.Fatalf("expected the default latest-height TTL, got %v", ttl)
+	}
+
+	c, _ = New(config.CacheConfig{LatestTTL: -1})
+	if _, ok := c.ttl("bank", 0); ok {
+		t.Fatal("expected a negative TTL to disable latest-height caching")
+	}
+}
+
+func TestQueryExpiresLatestHeightResponses(t *testing.T) {
+	c, err := New(config.CacheConfig{
+		LatestTTL: 50 * time.Millisecond,
+		ModuleTTL: map[string]time.Duration{"mint": time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	b := &balances{}
+
+	b.query(c, "allo1a")
+	resp, _ := b.query(c, "allo1a")
+	if b.calls != 1 || resp.Balance.Amount.Int64() != 1 {
+		t.Fatalf("expected a cached response, got %d calls", b.calls)
+	}
+	time.Sleep(60 * time.Millisecond)
+	resp, _ = b.query(c, "allo1a")
+	if b.calls != 2 || resp.Balance.Amount.Int64() != 2 {
+		t.Fatalf("expected the response to expire, got %d calls", b.calls)
+	}
+
+	if ttl, ok := c.ttl("mint", 0); !ok || ttl != time.Hour {
+		t.Fatalf("expected the module TTL, got %v", ttl)
+	}
+	c.moduleTTL["bank"] = 0
+	b.query(c, "allo1b")
+	b.query(c, "allo1b")
+	if b.calls != 4 {
+		t.Fatalf("expected a zero module TTL to disable caching, got %d calls", b.calls)
+	}
+}
+
+func TestQueryPersistsHeightPinnedResponsesOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.CacheConfig{Dir: dir, LatestTTL: time.Hour}
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	b := &balances{}
+	b.query(c, "allo1a", config.Height(100))
+	b.query(c, "allo1a")
+
+	// A new cache in the same directory starts with the height-pinned responses only
+	c, err = New(cfg)
+	if err != nil {
+		t.Fatalf("failed to reopen cache: %v", err)
+	}
+	resp, err := b.query(c, "allo1a", config.Height(100))
+	if err != nil || b.calls != 2 || resp.Balance.Amount.Int64() != 1 {
+		t.Fatalf("expected the response from disk, got %v after %d calls (%v)", resp, b.calls, err)
+	}
+	b.query(c, "allo1a")
+	if b.calls != 3 {
+		t.Fatalf("expected latest-height responses not to be persisted, got %d calls", b.calls)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := NewLRU(2)
+	lru.Set("a", []byte("a"), 0)
+	lru.Set("b", []byte("b"), 0)
+	lru.Get("a")
+	lru.Set("c", []byte("c"), 0)
+
+	if _, ok := lru.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := lru.Get("a"); !ok {
+		t.Fatal("expected a to be kept")
+	}
+	if lru.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", lru.Len())
+	}
+}
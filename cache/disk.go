@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Disk is a store that keeps one file per entry in a directory. It only holds
+// entries that never expire; entries with a TTL are ignored.
+type Disk struct {
+	dir string
+}
+
+var _ Store = (*Disk)(nil)
+
+// NewDisk creates a store in dir, creating the directory if needed
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Disk{dir: dir}, nil
+}
+
+func (d *Disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(d.dir, name[:2], name)
+}
+
+func (d *Disk) Get(key string) ([]byte, bool) {
+	value, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (d *Disk) Set(key string, value []byte, ttl time.Duration) {
+	if ttl > 0 {
+		return
+	}
+
+	// Write to a temporary file first so readers never see a partial entry
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/allora-network/allora-sdk-go/codec"
@@ -33,7 +35,7 @@ type AccountInfo struct {
 // Example:
 //
 //	client, _ := allora.NewClient(config, logger)
-//	addr, _ := sdk.AccAddressFromBech32("allo1...")
+//	addr, _ := allora.ParseAccAddress("allo1...")
 //
 //	info, err := allora.QueryAccountInfo(ctx, client, addr)
 //	if err != nil {
@@ -48,7 +50,7 @@ func QueryAccountInfo(ctx context.Context, client Client, address sdk.AccAddress
 
 	// Query account from the auth module
 	req := &authtypes.QueryAccountRequest{
-		Address: address.String(),
+		Address: FormatAccAddress(address),
 	}
 
 	resp, err := client.Cosmos().Auth().Account(ctx, req)
@@ -110,7 +112,7 @@ func GetChainID(ctx context.Context, client Client) (string, error) {
 // Example:
 //
 //	client, _ := allora.NewClient(config, logger)
-//	addr, _ := sdk.AccAddressFromBech32("allo1...")
+//	addr, _ := allora.ParseAccAddress("allo1...")
 //
 //	params, err := allora.NewTxParamsBuilder(ctx, client).
 //	    WithAddress(addr).
@@ -118,6 +120,13 @@ func GetChainID(ctx context.Context, client Client) (string, error) {
 //	    WithFee(sdk.NewCoins(sdk.NewInt64Coin("uallo", 6000))).
 //	    WithMemo("my-transaction").
 //	    QueryAndBuild()
+//
+//	// or estimate gas by simulation and derive the fee from the gas price
+//	params, err := allora.NewTxParamsBuilder(ctx, client).
+//	    WithAddress(addr).
+//	    WithMsgs(msgs...).
+//	    WithAutoGas(1.3).
+//	    QueryAndBuild()
 type TxParamsBuilder struct {
 	ctx    context.Context
 	client Client
@@ -130,9 +139,20 @@ type TxParamsBuilder struct {
 	feeAmount     sdk.Coins
 	memo          string
 	timeoutHeight uint64
+	signMode      signingtypes.SignMode
 
 	// Address to query if account info not manually set
 	address sdk.AccAddress
+
+	// Optional sequence manager used instead of querying account info on every build
+	sequenceManager *SequenceManager
+
+	// Gas estimation
+	autoGas       bool
+	gasAdjustment float64
+	gasPrice      *sdk.DecCoin
+	msgs          []sdk.Msg
+	pubKey        cryptotypes.PubKey
 }
 
 // NewTxParamsBuilder creates a new TxParamsBuilder
@@ -193,11 +213,58 @@ func (b *TxParamsBuilder) WithTimeoutHeight(height uint64) *TxParamsBuilder {
 	return b
 }
 
+// WithSignMode sets the sign mode used to sign the transaction
+func (b *TxParamsBuilder) WithSignMode(signMode signingtypes.SignMode) *TxParamsBuilder {
+	b.signMode = signMode
+	return b
+}
+
+// WithSequenceManager makes QueryAndBuild reserve the account number and sequence
+// from the given SequenceManager instead of querying them on every call
+func (b *TxParamsBuilder) WithSequenceManager(m *SequenceManager) *TxParamsBuilder {
+	b.sequenceManager = m
+	return b
+}
+
+// WithAutoGas enables gas estimation: QueryAndBuild simulates the transaction
+// (see WithMsgs) and sets the gas limit to the simulated usage multiplied by
+// adjustment (DefaultGasAdjustment if adjustment is not positive). The fee is
+// then derived from the gas price.
+func (b *TxParamsBuilder) WithAutoGas(adjustment float64) *TxParamsBuilder {
+	b.autoGas = true
+	b.gasAdjustment = adjustment
+	return b
+}
+
+// WithGasPrice sets the gas price used to derive the fee from the gas limit.
+// Without it, auto gas reads the node's minimum gas price and falls back to
+// DefaultGasPrice.
+func (b *TxParamsBuilder) WithGasPrice(gasPrice sdk.DecCoin) *TxParamsBuilder {
+	b.gasPrice = &gasPrice
+	return b
+}
+
+// WithMsgs sets the messages of the transaction being built (required for auto gas)
+func (b *TxParamsBuilder) WithMsgs(msgs ...sdk.Msg) *TxParamsBuilder {
+	b.msgs = msgs
+	return b
+}
+
+// WithPubKey sets the signer's public key used when simulating the transaction
+func (b *TxParamsBuilder) WithPubKey(pubKey cryptotypes.PubKey) *TxParamsBuilder {
+	b.pubKey = pubKey
+	return b
+}
+
 // QueryAndBuild queries any missing parameters from the blockchain and builds TxParams
 //
 // This method will query:
 // - Chain ID (if not manually set)
-// - Account number and sequence (if not manually set and address is provided)
+// - Account number and sequence (if not manually set and address is provided),
+//   taking them from the SequenceManager when one is configured; the sequence is
+//   reserved only once every other query has succeeded
+// - Gas limit via simulation and the node's minimum gas price (if auto gas is enabled)
+// - Denom metadata for SIGN_MODE_TEXTUAL, lazily when the transaction is signed
 //
 // Returns:
 //   - *TxParams: The constructed transaction parameters
@@ -208,6 +275,10 @@ func (b *TxParamsBuilder) QueryAndBuild() (*TxParams, error) {
 		FeeAmount:     b.feeAmount,
 		Memo:          b.memo,
 		TimeoutHeight: b.timeoutHeight,
+		SignMode:      b.signMode,
+	}
+	if b.client != nil {
+		params.CoinMetadataQuery = QueryCoinMetadataFn(b.client)
 	}
 
 	// Query chain ID if not manually set
@@ -221,13 +292,24 @@ func (b *TxParamsBuilder) QueryAndBuild() (*TxParams, error) {
 		params.ChainID = *b.chainID
 	}
 
+	// With a SequenceManager the sequence is only reserved once everything else has
+	// succeeded: a reserved but unused sequence would make every later transaction
+	// from the key fail with a sequence mismatch
+	reserveSequence := b.sequenceManager != nil && b.sequence == nil
+
 	// Query account info if not manually set
 	if b.accountNumber == nil || b.sequence == nil {
 		if b.address.Empty() {
 			return nil, fmt.Errorf("address is required to query account info")
 		}
 
-		accountInfo, err := QueryAccountInfo(b.ctx, b.client, b.address)
+		var accountInfo *AccountInfo
+		var err error
+		if b.sequenceManager != nil {
+			accountInfo, err = b.sequenceManager.current(b.ctx, b.address)
+		} else {
+			accountInfo, err = QueryAccountInfo(b.ctx, b.client, b.address)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to query account info: %w", err)
 		}
@@ -248,9 +330,57 @@ func (b *TxParamsBuilder) QueryAndBuild() (*TxParams, error) {
 		params.Sequence = *b.sequence
 	}
 
+	if b.autoGas {
+		if len(b.msgs) == 0 {
+			return nil, fmt.Errorf("messages are required for gas estimation")
+		}
+
+		gasLimit, err := EstimateGas(b.ctx, b.client, b.msgs, b.pubKey, params, b.gasAdjustment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		params.GasLimit = gasLimit
+
+		gasPrice, err := b.resolveGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		params.FeeAmount = FeeForGas(params.GasLimit, gasPrice)
+	} else if b.gasPrice != nil {
+		params.FeeAmount = FeeForGas(params.GasLimit, *b.gasPrice)
+	}
+
+	if reserveSequence {
+		accountInfo, err := b.sequenceManager.Next(b.ctx, b.address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve sequence: %w", err)
+		}
+		params.Sequence = accountInfo.Sequence
+		if b.accountNumber == nil {
+			params.AccountNumber = accountInfo.AccountNumber
+		}
+	}
+
 	return params, nil
 }
 
+// resolveGasPrice returns the configured gas price, the node's minimum gas price,
+// or DefaultGasPrice, in that order of preference
+func (b *TxParamsBuilder) resolveGasPrice() (sdk.DecCoin, error) {
+	if b.gasPrice != nil {
+		return *b.gasPrice, nil
+	}
+
+	gasPrice, ok, err := QueryMinGasPrice(b.ctx, b.client, DefaultFeeDenom)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to query gas price: %w", err)
+	}
+	if !ok {
+		return DefaultGasPrice, nil
+	}
+	return gasPrice, nil
+}
+
 // Build builds TxParams without querying the blockchain
 //
 // This method is useful for offline signing where all parameters are manually provided.
@@ -269,6 +399,9 @@ func (b *TxParamsBuilder) Build() (*TxParams, error) {
 	if b.sequence == nil {
 		return nil, fmt.Errorf("sequence is required")
 	}
+	if b.autoGas {
+		return nil, fmt.Errorf("gas estimation requires QueryAndBuild")
+	}
 
 	params := &TxParams{
 		ChainID:       *b.chainID,
@@ -278,6 +411,10 @@ func (b *TxParamsBuilder) Build() (*TxParams, error) {
 		FeeAmount:     b.feeAmount,
 		Memo:          b.memo,
 		TimeoutHeight: b.timeoutHeight,
+		SignMode:      b.signMode,
+	}
+	if b.gasPrice != nil {
+		params.FeeAmount = FeeForGas(params.GasLimit, *b.gasPrice)
 	}
 
 	return params, nil
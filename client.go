@@ -1,24 +1,34 @@
 package allora
 
 import (
+	"context"
 	"fmt"
 
 	butils "github.com/brynbellomy/go-utils"
 	ctypes "github.com/cometbft/cometbft/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/cosmosrpc"
 	"github.com/allora-network/allora-sdk-go/gen/grpc"
 	"github.com/allora-network/allora-sdk-go/gen/rest"
 	"github.com/allora-network/allora-sdk-go/metrics"
+	"github.com/allora-network/allora-sdk-go/pool"
 	"github.com/allora-network/allora-sdk-go/tmrpc"
 )
 
 type Client interface {
 	Cosmos() cosmosrpc.ClientPool
 	Tendermint() tmrpc.ClientPool
-	Subscribe(mb *butils.Mailbox[ctypes.TMEventData], query string)
+	AtHeight(height int64) cosmosrpc.Snapshot
+	Snapshot(ctx context.Context) (cosmosrpc.Snapshot, error)
+	Subscribe(mb *butils.Mailbox[ctypes.TMEventData], query string) (tmrpc.Subscription, error)
+	SubscribeEvents(ctx context.Context, opts ...EventOpt) (<-chan Event, error)
+	SubscribeBlocks(ctx context.Context, opts ...tmrpc.BlockStreamOpt) (<-chan tmrpc.BlockEvent, error)
+	BroadcastTx(ctx context.Context, txBytes []byte, opts ...BroadcastOpt) (*BroadcastResult, error)
+	WaitForTx(ctx context.Context, txHash string, opts ...BroadcastOpt) (*BroadcastResult, error)
 }
 
 // Client is the Allora Network client that provides access to all query services.
@@ -28,6 +38,7 @@ type client struct {
 	cosmosPool     cosmosrpc.ClientPool
 	tendermintPool tmrpc.ClientPool
 	websocketPool  tmrpc.WebsocketPool
+	txWatcher      *txWatcher
 	logger         zerolog.Logger
 }
 
@@ -75,7 +86,7 @@ func NewClient(cfg *config.ClientConfig, logger zerolog.Logger) (*client, error)
 				tmRPCClients = append(tmRPCClients, tmClient)
 			}
 			if endpoint.WebsocketURL != "" {
-				ws := tmrpc.NewTendermintWebsocket(endpoint.WebsocketURL, logger)
+				ws := tmrpc.NewTendermintWebsocket(endpoint.WebsocketURL, logger, websocketOpts(cfg.Websocket)...)
 				websockets = append(websockets, ws)
 			}
 		case "":
@@ -100,17 +111,47 @@ func NewClient(cfg *config.ClientConfig, logger zerolog.Logger) (*client, error)
 		Str("component", "allora_client").
 		Logger()
 
+	var responseCache *cache.Cache
+	if cfg.Cache.Enabled {
+		var err error
+		responseCache, err = cache.New(cfg.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create response cache: %w", err)
+		}
+	}
+
+	var coalescer *coalesce.Group
+	if cfg.CoalesceRequests {
+		coalescer = coalesce.New()
+	}
+
+	websocketPool := tmrpc.NewWebsocketPool(websockets, cfg.Websocket, logger)
+	weights := pool.WithEndpointWeights(pool.EndpointWeights(cfg.Endpoints))
+
 	return &client{
-		cosmosPool:     cosmosrpc.NewClientPool(cosmosClients, logger),
-		tendermintPool: tmrpc.NewClientPool(tmRPCClients, logger),
-		websocketPool:  tmrpc.NewWebsocketPool(websockets),
+		cosmosPool:     cosmosrpc.NewClientPool(cosmosClients, cfg.Pool, responseCache, coalescer, logger, weights),
+		tendermintPool: tmrpc.NewClientPool(tmRPCClients, cfg.TendermintPool, logger, weights),
+		websocketPool:  websocketPool,
+		txWatcher:      newTxWatcher(websocketPool, logger),
 		logger:         logger,
 		config:         cfg,
 	}, nil
 }
 
+func websocketOpts(cfg config.WebsocketPoolConfig) []tmrpc.WebsocketOpt {
+	opts := []tmrpc.WebsocketOpt{
+		tmrpc.WithPingInterval(cfg.PingInterval),
+		tmrpc.WithStateCallback(cfg.OnStateChange),
+	}
+	if cfg.StallTimeout > 0 {
+		opts = append(opts, tmrpc.WithStallTimeout(cfg.StallTimeout))
+	}
+	return opts
+}
+
 func (c *client) Close() error {
 	c.logger.Info().Msg("shutting down Allora client")
+	c.txWatcher.close()
 	c.cosmosPool.Close()
 	c.tendermintPool.Close()
 	c.websocketPool.Close()
@@ -132,8 +173,36 @@ func (c *client) Tendermint() tmrpc.ClientPool {
 	return c.tendermintPool
 }
 
-func (c *client) Subscribe(mb *butils.Mailbox[ctypes.TMEventData], query string) {
-	c.websocketPool.Subscribe(mb, query)
+// AtHeight returns a view of the Cosmos queries that all run at the given height, on
+// the nodes that have not pruned it
+func (c *client) AtHeight(height int64) cosmosrpc.Snapshot {
+	return c.cosmosPool.AtHeight(height)
+}
+
+// Snapshot returns a view of the Cosmos queries that all run at the latest height
+// every node has reached, so that the results of several queries reconcile
+//
+// Example:
+//
+//	snap, err := client.Snapshot(ctx)
+//	topic, err := snap.Emissions().GetTopic(ctx, &emissionstypes.GetTopicRequest{TopicId: 1})
+//	stake, err := snap.Emissions().GetTopicStake(ctx, &emissionstypes.GetTopicStakeRequest{TopicId: 1})
+//	fmt.Printf("topic %d at height %d\n", topic.Topic.Id, snap.Height())
+func (c *client) Snapshot(ctx context.Context) (cosmosrpc.Snapshot, error) {
+	return c.cosmosPool.Snapshot(ctx)
+}
+
+// Subscribe delivers the events matching the CometBFT query to the mailbox, according
+// to the websocket pool mode, until the returned subscription is unsubscribed
+func (c *client) Subscribe(mb *butils.Mailbox[ctypes.TMEventData], query string) (tmrpc.Subscription, error) {
+	return c.websocketPool.Subscribe(mb, query)
+}
+
+// SubscribeBlocks delivers every block in order, without gaps: blocks missed while the
+// websockets were reconnecting are fetched over Tendermint RPC before live blocks resume.
+// The channel is closed when ctx is done.
+func (c *client) SubscribeBlocks(ctx context.Context, opts ...tmrpc.BlockStreamOpt) (<-chan tmrpc.BlockEvent, error) {
+	return tmrpc.NewBlockStream(c.websocketPool, c.tendermintPool, c.logger, opts...).Start(ctx)
 }
 
 var SetMetricsPrefix = metrics.SetPrefix
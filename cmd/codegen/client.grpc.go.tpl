@@ -17,7 +17,6 @@ import (
     sdkgrpc "github.com/cosmos/cosmos-sdk/types/grpc"
     "github.com/brynbellomy/go-utils/errors"
 
-    cmtservice "github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
     cosmoscodec "github.com/cosmos/cosmos-sdk/codec"
     codectypes "github.com/cosmos/cosmos-sdk/codec/types"
     "github.com/cosmos/cosmos-sdk/std"
@@ -32,6 +31,7 @@ import (
     "github.com/allora-network/allora-sdk-go/codec"
     "github.com/allora-network/allora-sdk-go/config"
     "github.com/allora-network/allora-sdk-go/gen/interfaces"
+    "github.com/allora-network/allora-sdk-go/pool"
 )
 
 // GRPCClient implements the Client interface using gRPC
@@ -142,13 +142,12 @@ func queryWithHeight[In any, Out any](ctx context.Context, height int64, queryFn
     return resp, nil
 }
 
-// Status implements the Status method required by ClientPoolManager
-func (c *GRPCClient) Status(ctx context.Context) error {
-    _, err := c.tendermint.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
-    return err
+// Status reports whether the node is catching up and the range of blocks it serves
+func (c *GRPCClient) Status(ctx context.Context) (pool.NodeStatus, error) {
+    return pool.QueryNodeStatus(ctx, c.tendermint)
 }
 
 // HealthCheck wraps Status to satisfy pool requirements
-func (c *GRPCClient) HealthCheck(ctx context.Context) error {
+func (c *GRPCClient) HealthCheck(ctx context.Context) (pool.NodeStatus, error) {
     return c.Status(ctx)
 }
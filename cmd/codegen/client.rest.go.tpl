@@ -14,7 +14,6 @@ import (
     "time"
 
     "github.com/brynbellomy/go-utils/errors"
-    cmtservice "github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
     grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
     "github.com/rs/zerolog"
 
@@ -23,6 +22,7 @@ import (
 
     "github.com/allora-network/allora-sdk-go/config"
     "github.com/allora-network/allora-sdk-go/gen/interfaces"
+    "github.com/allora-network/allora-sdk-go/pool"
     "github.com/allora-network/allora-sdk-go/metrics"
 )
 
@@ -91,14 +91,13 @@ func (c *RESTClient) {{ .ModuleName | title }}() interfaces.{{ .ModuleName | tit
 }
 {{ end }}
 
-// Status implements a basic health check using the Tendermint service
-func (c *RESTClient) Status(ctx context.Context) error {
-    _, err := c.tendermint.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
-    return err
+// Status reports whether the node is catching up and the range of blocks it serves
+func (c *RESTClient) Status(ctx context.Context) (pool.NodeStatus, error) {
+    return pool.QueryNodeStatus(ctx, c.tendermint)
 }
 
 // HealthCheck wraps Status to satisfy pool requirements
-func (c *RESTClient) HealthCheck(ctx context.Context) error {
+func (c *RESTClient) HealthCheck(ctx context.Context) (pool.NodeStatus, error) {
     return c.Status(ctx)
 }
 
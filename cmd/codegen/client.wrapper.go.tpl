@@ -3,6 +3,8 @@ package wrapper
 import (
     "github.com/rs/zerolog"
 
+    "github.com/allora-network/allora-sdk-go/cache"
+    "github.com/allora-network/allora-sdk-go/coalesce"
     "github.com/allora-network/allora-sdk-go/gen/interfaces"
     "github.com/allora-network/allora-sdk-go/pool"
 )
@@ -13,10 +15,21 @@ type WrapperClient struct {
     {{- end }}
 }
 
-func NewWrapperClient(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *WrapperClient {
+// NewWrapperClient creates a client that runs every query on the pool. responseCache
+// and coalescer may be nil to disable caching and request coalescing.
+func NewWrapperClient(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *WrapperClient {
     return &WrapperClient{
         {{- range .Modules }}
-        {{ .ModuleName }}: New{{ .ModuleName | title }}ClientWrapper(poolManager, logger),
+        {{ .ModuleName }}: New{{ .ModuleName | title }}ClientWrapper(poolManager, responseCache, coalescer, logger),
+        {{- end}}
+    }
+}
+
+// Pinned returns a view of the client that runs every query as pinned by pin
+func (c *WrapperClient) Pinned(pin *pool.Pin) *WrapperClient {
+    return &WrapperClient{
+        {{- range .Modules }}
+        {{ .ModuleName }}: c.{{ .ModuleName }}.pinned(pin),
         {{- end}}
     }
 }
@@ -6,30 +6,56 @@ import (
     "github.com/rs/zerolog"
 {{if eq .ModuleName "emissions" "mint"}}    {{ .PackageName }} "{{ .ImportPath }}"{{else}}  {{ .PackageName }} "{{ .ImportPath }}"{{end}}
 
+    "github.com/allora-network/allora-sdk-go/cache"
+    "github.com/allora-network/allora-sdk-go/coalesce"
     "github.com/allora-network/allora-sdk-go/config"
     "github.com/allora-network/allora-sdk-go/gen/interfaces"
     "github.com/allora-network/allora-sdk-go/pool"
 )
 
-// {{ .ModuleName | title }}ClientWrapper wraps the {{ .ModuleName }} module with pool management and retry logic
+// {{ .ModuleName | title }}ClientWrapper wraps the {{ .ModuleName }} module with pool management, retry logic, response caching and request coalescing
 type {{ .ModuleName | title }}ClientWrapper struct {
     poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+    cache       *cache.Cache
+    coalescer   *coalesce.Group
+    pin         *pool.Pin
     logger      zerolog.Logger
 }
 
 // New{{ .ModuleName | title }}ClientWrapper creates a new {{ .ModuleName }} client wrapper
-func New{{ .ModuleName | title }}ClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *{{ .ModuleName | title }}ClientWrapper {
+func New{{ .ModuleName | title }}ClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *{{ .ModuleName | title }}ClientWrapper {
     return &{{ .ModuleName | title }}ClientWrapper{
         poolManager: poolManager,
+        cache:       responseCache,
+        coalescer:   coalescer,
         logger:      logger.With().Str("module", "{{ .ModuleName }}").Logger(),
     }
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *{{ .ModuleName | title }}ClientWrapper) pinned(pin *pool.Pin) *{{ .ModuleName | title }}ClientWrapper {
+    pinned := *c
+    pinned.pin = pin
+    return &pinned
+}
+
 {{range .Methods}}{{if .Comment}}// {{ .Comment }}{{end}}
 func (c *{{ $.ModuleName | title }}ClientWrapper) {{ .Name }}(ctx context.Context, req *{{ $.PackageName }}.{{ .RequestType }}, opts ...config.CallOpt) (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
-    return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
+{{- if or (eq .Name "BroadcastTx") (eq .Name "Simulate") }}
+    // Transactions are neither cached, coalesced nor hedged: a hedge would send them twice
+    return pool.ExecuteWithRetry(pool.WithoutHedging(ctx), c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
         return client.{{ $.ModuleName | title }}().{{ .Name }}(ctx, req, opts...)
     })
+{{- else }}
+    ctx, opts = c.pin.Apply(ctx, opts)
+    return cache.Query(c.cache, "{{ $.ModuleName }}", "{{ .Name }}", req, opts, func() (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
+        return coalesce.Do(ctx, c.coalescer, "{{ $.ModuleName }}", "{{ .Name }}", req, opts, func() (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
+            return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*{{ $.PackageName }}.{{ .ResponseType }}, error) {
+                return client.{{ $.ModuleName | title }}().{{ .Name }}(ctx, req, opts...)
+            })
+        })
+    })
+{{- end }}
 }
 
 {{end}}
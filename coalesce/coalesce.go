@@ -0,0 +1,102 @@
+// Package coalesce merges identical concurrent cosmos queries into a single request.
+package coalesce
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/cosmos/gogoproto/proto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/metrics"
+)
+
+// Group tracks the queries in flight. A nil *Group coalesces nothing.
+type Group struct {
+	flight singleflight.Group
+}
+
+// New creates an empty group
+func New() *Group {
+	return &Group{}
+}
+
+// Do calls fetch, unless an identical query (same method, request and height) is
+// already in flight, in which case it waits for that query's response instead. Every
+// caller gets its own copy of the response. If the query in flight fails because its
+// caller's context ended, the callers that are still waiting run the query themselves.
+func Do[T any, PT interface {
+	*T
+	proto.Message
+}](ctx context.Context, g *Group, module, method string, req proto.Message, opts []config.CallOpt, fetch func() (PT, error)) (PT, error) {
+	if g == nil || isNil(req) {
+		return fetch()
+	}
+
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return fetch()
+	}
+	callOpts := config.DefaultCallOpts()
+	callOpts.Apply(opts...)
+	sum := sha256.Sum256(reqBytes)
+	key := fmt.Sprintf("%s/%s/%d/%x", module, method, callOpts.Height, sum)
+
+	var executed bool
+	ch := g.flight.DoChan(key, func() (any, error) {
+		executed = true
+		return fetch()
+	})
+
+	var res singleflight.Result
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	metrics.ObserveCoalescedRequest(module, method, !executed)
+
+	if res.Err != nil {
+		if !executed && isContextError(res.Err) && ctx.Err() == nil {
+			return fetch()
+		}
+		return nil, res.Err
+	}
+	resp, _ := res.Val.(PT)
+	if !res.Shared || resp == nil {
+		return resp, nil
+	}
+	return clone(resp)
+}
+
+// clone copies a shared response so callers can't observe each other's changes
+func clone[T any, PT interface {
+	*T
+	proto.Message
+}](resp PT) (PT, error) {
+	bz, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy coalesced response: %w", err)
+	}
+	out := PT(new(T))
+	if err := proto.Unmarshal(bz, out); err != nil {
+		return nil, fmt.Errorf("failed to copy coalesced response: %w", err)
+	}
+	return out, nil
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func isNil(msg proto.Message) bool {
+	if msg == nil {
+		return true
+	}
+	v := reflect.ValueOf(msg)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
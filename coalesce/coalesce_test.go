@@ -0,0 +1,118 @@
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+var balanceReq = &banktypes.QueryBalanceRequest{Address: "allo1a", Denom: "uallo"}
+
+func balance(amount int64) *banktypes.QueryBalanceResponse {
+	coin := sdk.NewInt64Coin("uallo", amount)
+	return &banktypes.QueryBalanceResponse{Balance: &coin}
+}
+
+func TestDoMergesIdenticalQueries(t *testing.T) {
+	g := New()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func() (*banktypes.QueryBalanceResponse, error) {
+		calls.Add(1)
+		<-release
+		return balance(42), nil
+	}
+
+	const n = 20
+	responses := make([]*banktypes.QueryBalanceResponse, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := Do(context.Background(), g, "bank", "Balance", balanceReq, nil, fetch)
+			if err != nil {
+				t.Errorf("query failed: %v", err)
+			}
+			responses[i] = resp
+		}()
+	}
+
+	// A query at another height isn't merged with the others
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Do(context.Background(), g, "bank", "Balance", balanceReq, []config.CallOpt{config.Height(5)}, fetch)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	<-done
+
+	if c := calls.Load(); c != 2 {
+		t.Fatalf("expected 2 fetches, got %d", c)
+	}
+	for i, resp := range responses {
+		if resp == nil || resp.Balance.Amount.Int64() != 42 {
+			t.Fatalf("unexpected response %d: %v", i, resp)
+		}
+		if i > 0 && resp == responses[0] {
+			t.Fatal("expected every caller to get its own copy")
+		}
+	}
+}
+
+func TestDoRetriesWhenTheLeaderIsCanceled(t *testing.T) {
+	g := New()
+	started := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go Do(leaderCtx, g, "bank", "Balance", balanceReq, nil, func() (*banktypes.QueryBalanceResponse, error) {
+		close(started)
+		<-leaderCtx.Done()
+		return nil, leaderCtx.Err()
+	})
+	<-started
+
+	result := make(chan *banktypes.QueryBalanceResponse, 1)
+	go func() {
+		resp, err := Do(context.Background(), g, "bank", "Balance", balanceReq, nil, func() (*banktypes.QueryBalanceResponse, error) {
+			return balance(7), nil
+		})
+		if err != nil {
+			t.Errorf("query failed: %v", err)
+		}
+		result <- resp
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case resp := <-result:
+		if resp == nil || resp.Balance.Amount.Int64() != 7 {
+			t.Fatalf("expected the follower to run its own query, got %v", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the follower")
+	}
+}
+
+func TestDoWithoutGroup(t *testing.T) {
+	var calls int
+	for range 2 {
+		Do(context.Background(), nil, "bank", "Balance", balanceReq, nil, func() (*banktypes.QueryBalanceResponse, error) {
+			calls++
+			return balance(1), nil
+		})
+	}
+	if calls != 2 {
+		t.Fatalf("expected a nil group to query every time, got %d calls", calls)
+	}
+}
@@ -3,7 +3,9 @@ package codec
 import (
 	"encoding/json"
 
+	"cosmossdk.io/core/address"
 	"cosmossdk.io/x/feegrant"
+	"cosmossdk.io/x/tx/signing"
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 	"github.com/brynbellomy/go-utils/errors"
 	abcitypes "github.com/cometbft/cometbft/abci/types"
@@ -58,38 +60,39 @@ var (
 	registry    = codectypes.NewInterfaceRegistry()
 )
 
+var registerFuncs = []func(codectypes.InterfaceRegistry){
+	upgradetypes.RegisterInterfaces,
+	banktypes.RegisterInterfaces,
+	distributiontypes.RegisterInterfaces,
+	slashingtypes.RegisterInterfaces,
+	stakingtypes.RegisterInterfaces,
+	authz.RegisterInterfaces,
+	feegrant.RegisterInterfaces,
+	govv1types.RegisterInterfaces,
+	govv1beta1types.RegisterInterfaces,
+	stdtypes.RegisterInterfaces,
+	cosmossdktypes.RegisterInterfaces,
+	txtypes.RegisterInterfaces,
+	ibctransfertypes.RegisterInterfaces,
+	ibcclient.RegisterInterfaces,
+	ibcconnection.RegisterInterfaces,
+	ibcchannel.RegisterInterfaces,
+	ibclightclient.RegisterInterfaces,
+	mintv1beta1.RegisterInterfaces,
+	mintv2.RegisterInterfaces,
+	mintv5.RegisterInterfaces,
+	emissionsv2.RegisterInterfaces,
+	emissionsv3.RegisterInterfaces,
+	emissionsv4.RegisterInterfaces,
+	emissionsv5.RegisterInterfaces,
+	emissionsv6.RegisterInterfaces,
+	emissionsv7.RegisterInterfaces,
+	emissionsv8.RegisterInterfaces,
+	emissionsv9.RegisterInterfaces,
+	emissionsv10.RegisterInterfaces,
+}
+
 func init() {
-	registerFuncs := []func(codectypes.InterfaceRegistry){
-		upgradetypes.RegisterInterfaces,
-		banktypes.RegisterInterfaces,
-		distributiontypes.RegisterInterfaces,
-		slashingtypes.RegisterInterfaces,
-		stakingtypes.RegisterInterfaces,
-		authz.RegisterInterfaces,
-		feegrant.RegisterInterfaces,
-		govv1types.RegisterInterfaces,
-		govv1beta1types.RegisterInterfaces,
-		stdtypes.RegisterInterfaces,
-		cosmossdktypes.RegisterInterfaces,
-		txtypes.RegisterInterfaces,
-		ibctransfertypes.RegisterInterfaces,
-		ibcclient.RegisterInterfaces,
-		ibcconnection.RegisterInterfaces,
-		ibcchannel.RegisterInterfaces,
-		ibclightclient.RegisterInterfaces,
-		mintv1beta1.RegisterInterfaces,
-		mintv2.RegisterInterfaces,
-		mintv5.RegisterInterfaces,
-		emissionsv2.RegisterInterfaces,
-		emissionsv3.RegisterInterfaces,
-		emissionsv4.RegisterInterfaces,
-		emissionsv5.RegisterInterfaces,
-		emissionsv6.RegisterInterfaces,
-		emissionsv7.RegisterInterfaces,
-		emissionsv8.RegisterInterfaces,
-		emissionsv9.RegisterInterfaces,
-		emissionsv10.RegisterInterfaces,
-	}
 	for _, register := range registerFuncs {
 		register(registry)
 	}
@@ -98,6 +101,26 @@ func init() {
 	grpcCodec = cosmosCodec.GRPCCodec()
 }
 
+// NewSigningCodec returns a codec with the same interfaces as CosmosCodec whose
+// signing context decodes signer addresses with the given address codecs, e.g. to
+// resolve message signers without relying on the global sdk.Config prefixes
+func NewSigningCodec(accountCodec, validatorCodec address.Codec) (*cosmoscodec.ProtoCodec, error) {
+	reg, err := codectypes.NewInterfaceRegistryWithOptions(codectypes.InterfaceRegistryOptions{
+		ProtoFiles: proto.HybridResolver,
+		SigningOptions: signing.Options{
+			AddressCodec:          accountCodec,
+			ValidatorAddressCodec: validatorCodec,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, register := range registerFuncs {
+		register(reg)
+	}
+	return cosmoscodec.NewProtoCodec(reg), nil
+}
+
 func GRPCCodec() encoding.Codec {
 	return grpcCodec
 }
@@ -8,12 +8,24 @@ type ClientConfig struct {
 	Endpoints         []EndpointConfig
 	RequestTimeout    time.Duration
 	ConnectionTimeout time.Duration
+	Websocket         WebsocketPoolConfig
+	// Pool configures the pool of Cosmos query clients
+	Pool PoolConfig
+	// TendermintPool configures the pool of Tendermint RPC clients
+	TendermintPool PoolConfig
+	Cache          CacheConfig
+	// CoalesceRequests merges identical concurrent queries (same method, request and
+	// height) into a single request
+	CoalesceRequests bool
 }
 
 type EndpointConfig struct {
 	URL          string
 	WebsocketURL string
 	Protocol     Protocol
+	// Weight is the endpoint's relative share of queries under the weighted pool
+	// strategy (default: 1)
+	Weight int
 }
 
 type Protocol string
@@ -24,9 +36,210 @@ const (
 	ProtocolTendermintRPC Protocol = "tendermint_rpc"
 )
 
+// WebsocketPoolMode selects how subscriptions are spread over the websocket endpoints
+type WebsocketPoolMode string
+
+const (
+	// WebsocketPoolDedup subscribes on every websocket and delivers each event once,
+	// deduplicated by height, event type and tx hash. This is the default.
+	WebsocketPoolDedup WebsocketPoolMode = "dedup"
+	// WebsocketPoolFanout subscribes on every websocket and delivers every copy of an event
+	WebsocketPoolFanout WebsocketPoolMode = "fanout"
+	// WebsocketPoolPrimaryStandby subscribes on one websocket only and promotes the next
+	// one when the primary stops delivering new blocks
+	WebsocketPoolPrimaryStandby WebsocketPoolMode = "primary_standby"
+)
+
+// ConnectionState is the state of a websocket connection
+type ConnectionState string
+
+const (
+	ConnectionStateConnecting   ConnectionState = "connecting"
+	ConnectionStateConnected    ConnectionState = "connected"
+	ConnectionStateDisconnected ConnectionState = "disconnected"
+	ConnectionStateClosed       ConnectionState = "closed"
+)
+
+type WebsocketPoolConfig struct {
+	Mode WebsocketPoolMode
+	// StallTimeout is how long a websocket subscribed to new blocks may go without
+	// one before it reconnects and, in primary-standby mode, before a standby is
+	// promoted (default: 30s)
+	StallTimeout time.Duration
+	// DedupWindow is the number of heights for which delivered events are
+	// remembered (default: 100)
+	DedupWindow int64
+	// PingInterval is how often each websocket pings its node. A connection that
+	// answers neither pings nor with events for two intervals is reconnected
+	// (default: 15s)
+	PingInterval time.Duration
+	// OnStateChange, if set, is called whenever a websocket's connection state
+	// changes. err is the cause of a disconnect.
+	OnStateChange func(url string, state ConnectionState, err error)
+}
+
+func DefaultWebsocketPoolConfig() WebsocketPoolConfig {
+	return WebsocketPoolConfig{
+		Mode:         WebsocketPoolDedup,
+		StallTimeout: 30 * time.Second,
+		DedupWindow:  100,
+		PingInterval: 15 * time.Second,
+	}
+}
+
+// PoolStrategy selects how a client pool picks the node each query runs on
+type PoolStrategy string
+
+const (
+	// PoolStrategyRoundRobin cycles through the healthy nodes. This is the default.
+	PoolStrategyRoundRobin PoolStrategy = "round_robin"
+	// PoolStrategyLeastLatency picks the node with the lowest average latency
+	PoolStrategyLeastLatency PoolStrategy = "least_latency"
+	// PoolStrategyPowerOfTwo picks the faster of two random nodes
+	PoolStrategyPowerOfTwo PoolStrategy = "power_of_two"
+	// PoolStrategyWeighted picks nodes at random in proportion to their endpoint Weight
+	PoolStrategyWeighted PoolStrategy = "weighted"
+	// PoolStrategySticky sends every query with the same sticky key (see
+	// pool.WithStickyKey) to the same node, and the others round-robin
+	PoolStrategySticky PoolStrategy = "sticky"
+)
+
+// PoolConfig configures how a client pool picks and evicts nodes. Zero fields take
+// their default.
+type PoolConfig struct {
+	Strategy PoolStrategy
+	// MaxHeightLag is how many blocks a node may fall behind the highest node of its
+	// pool before it is cooled. Negative disables lag eviction (default: 50)
+	MaxHeightLag int64
+	Hedge        HedgeConfig
+	Breaker      BreakerConfig
+	// HealthCheckInterval is how often every node is health-checked (default: 10s)
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout bounds each health check (default: 4s)
+	HealthCheckTimeout time.Duration
+	// HealthyStreak is how many health checks in a row a cooled node must pass to
+	// be reactivated without trial queries (default: 3)
+	HealthyStreak int
+	// ReactivatedSuccessRate is the success rate a reactivated node is ranked with
+	// until its next query (default: 0.8)
+	ReactivatedSuccessRate float64
+	// MaxRetries is the retry count reported for each node (default: 2)
+	MaxRetries int
+	// RateLimitDelayIncrease is how much a node's rate limit delay grows each time
+	// it rate-limits a query, up to MaxRateLimitDelay (defaults: 100ms, 2s)
+	RateLimitDelayIncrease time.Duration
+	MaxRateLimitDelay      time.Duration
+	// BackoffBase is the first delay before a node that failed a query is retried.
+	// It doubles on every failure up to BackoffMax, with BackoffJitter of random
+	// spread (defaults: 100ms, 30s, 0.1)
+	BackoffBase   time.Duration
+	BackoffMax    time.Duration
+	BackoffJitter float64
+}
+
+// HedgeConfig configures hedged queries: a query that is still running after Delay
+// is also sent to a second node, and the first success wins
+type HedgeConfig struct {
+	Enabled bool
+	// Delay is how long a query runs before it is hedged. Zero uses the p95 latency
+	// of the pool's recent queries, once there are enough of them to tell.
+	Delay time.Duration
+}
+
+// BreakerState is the state of a node's circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed lets queries through to the node
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen keeps queries away from the node, unless no other node is left
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a few trial queries through to decide whether the node
+	// is healthy again
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig configures the circuit breaker of each node in a pool
+type BreakerConfig struct {
+	// FailureRatio is the share of failed queries within Window that opens the
+	// breaker, once the node served at least MinRequests queries in the window
+	// (defaults: 0.5, 30s, 5)
+	FailureRatio float64
+	Window       time.Duration
+	MinRequests  int
+	// OpenTimeout is how long the breaker stays open before it lets trial queries
+	// through (default: 10s)
+	OpenTimeout time.Duration
+	// HalfOpenRequests is how many trial queries may run at once while half-open,
+	// and HalfOpenSuccesses how many must succeed in a row to close the breaker.
+	// A failed trial opens it again (defaults: 1, 3)
+	HalfOpenRequests  int
+	HalfOpenSuccesses int
+	// OnStateChange, if set, is called whenever a node's breaker changes state
+	OnStateChange func(endpoint string, from, to BreakerState)
+}
+
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		Strategy:               PoolStrategyRoundRobin,
+		MaxHeightLag:           50,
+		Breaker:                DefaultBreakerConfig(),
+		HealthCheckInterval:    10 * time.Second,
+		HealthCheckTimeout:     4 * time.Second,
+		HealthyStreak:          3,
+		ReactivatedSuccessRate: 0.8,
+		MaxRetries:             2,
+		RateLimitDelayIncrease: 100 * time.Millisecond,
+		MaxRateLimitDelay:      2 * time.Second,
+		BackoffBase:            100 * time.Millisecond,
+		BackoffMax:             30 * time.Second,
+		BackoffJitter:          0.1,
+	}
+}
+
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureRatio:      0.5,
+		Window:            30 * time.Second,
+		MinRequests:       5,
+		OpenTimeout:       10 * time.Second,
+		HalfOpenRequests:  1,
+		HalfOpenSuccesses: 3,
+	}
+}
+
+// CacheConfig configures the response cache of the cosmos query clients. Responses
+// to queries pinned to a height with Height(h) never change and are kept until
+// evicted; responses at the latest height are kept for a short TTL.
+type CacheConfig struct {
+	Enabled bool
+	// Size is the number of responses kept in memory (default: 10000)
+	Size int
+	// Dir, if set, is a directory where height-pinned responses are also stored so
+	// they survive restarts
+	Dir string
+	// LatestTTL is how long responses at the latest height are cached. Negative
+	// disables caching of latest-height responses (default: 2s)
+	LatestTTL time.Duration
+	// ModuleTTL overrides LatestTTL per module, e.g. {"emissions": 5 * time.Second};
+	// a negative TTL disables caching of the module's latest-height responses
+	ModuleTTL map[string]time.Duration
+}
+
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		Size:      10000,
+		LatestTTL: 2 * time.Second,
+	}
+}
+
 func DefaultClientConfig() *ClientConfig {
 	return &ClientConfig{
 		RequestTimeout:    30 * time.Second,
 		ConnectionTimeout: 10 * time.Second,
+		Websocket:         DefaultWebsocketPoolConfig(),
+		Pool:              DefaultPoolConfig(),
+		TendermintPool:    DefaultPoolConfig(),
+		Cache:             DefaultCacheConfig(),
 	}
 }
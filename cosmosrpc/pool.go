@@ -1,6 +1,11 @@
 package cosmosrpc
 
 import (
+	"context"
+
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
+	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/gen/wrapper"
 	"github.com/allora-network/allora-sdk-go/pool"
@@ -12,6 +17,8 @@ type Client = interfaces.CosmosClient
 type ClientPool interface {
 	interfaces.CosmosClientPool
 	GetHealthStatus() map[string]any
+	AtHeight(height int64) Snapshot
+	Snapshot(ctx context.Context) (Snapshot, error)
 }
 
 type clientPool struct {
@@ -21,11 +28,13 @@ type clientPool struct {
 
 var _ ClientPool = (*clientPool)(nil)
 
-func NewClientPool(clients []Client, logger zerolog.Logger) *clientPool {
-	mgr := pool.NewClientPoolManager(clients, logger)
+// NewClientPool creates a pool over the clients. responseCache and coalescer may be nil
+// to disable response caching and request coalescing.
+func NewClientPool(clients []Client, cfg config.PoolConfig, responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger, opts ...pool.ManagerOpt) *clientPool {
+	mgr := pool.NewClientPoolManager(clients, cfg, logger, opts...)
 	return &clientPool{
-		WrapperClient: wrapper.NewWrapperClient(mgr, logger),
-		poolManager:   pool.NewClientPoolManager(clients, logger),
+		WrapperClient: wrapper.NewWrapperClient(mgr, responseCache, coalescer, logger),
+		poolManager:   mgr,
 	}
 }
 
@@ -0,0 +1,143 @@
+package cosmosrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+	"github.com/allora-network/allora-sdk-go/gen/wrapper"
+	"github.com/allora-network/allora-sdk-go/pool"
+)
+
+// Snapshot is a view of the pool whose queries all run at the same block height,
+// on the nodes that still serve it, so that their results reconcile
+type Snapshot interface {
+	interfaces.CosmosClientPool
+	Height() int64
+}
+
+type snapshot struct {
+	*wrapper.WrapperClient
+	height int64
+}
+
+var _ Snapshot = (*snapshot)(nil)
+
+func (s *snapshot) Height() int64 {
+	return s.height
+}
+
+// AtHeight returns a view of the pool whose queries run at height, overriding any
+// height passed to them. Queries skip nodes whose earliest available block is above
+// height; the earliest blocks are queried once, on the first query.
+func (p *clientPool) AtHeight(height int64) Snapshot {
+	heights := &pinnedHeights{height: height, clients: p.poolManager.Clients}
+	return p.pinned(heights)
+}
+
+// Snapshot pins the latest height every node has reached: it queries each node's
+// latest and earliest available block and returns a view of the pool whose queries
+// run at the lowest latest height, on the nodes that have not pruned it.
+func (p *clientPool) Snapshot(ctx context.Context) (Snapshot, error) {
+	ranges := queryHeightRanges(ctx, p.poolManager.Clients(), true)
+
+	var height int64
+	for _, r := range ranges {
+		if r.latest > 0 && (height == 0 || r.latest < height) {
+			height = r.latest
+		}
+	}
+	if height == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to query the latest height of any node")
+	}
+
+	heights := &pinnedHeights{height: height, ranges: ranges, loaded: true}
+	return p.pinned(heights), nil
+}
+
+func (p *clientPool) pinned(heights *pinnedHeights) *snapshot {
+	return &snapshot{
+		WrapperClient: p.WrapperClient.Pinned(&pool.Pin{Height: heights.height, Eligible: heights.eligible}),
+		height:        heights.height,
+	}
+}
+
+// heightRange is the range of blocks a node serves; zero bounds are unknown
+type heightRange struct {
+	earliest, latest int64
+}
+
+func (r heightRange) serves(height int64) bool {
+	return (r.earliest == 0 || r.earliest <= height) && (r.latest == 0 || height <= r.latest)
+}
+
+// pinnedHeights decides which nodes can serve queries at a pinned height
+type pinnedHeights struct {
+	height  int64
+	clients func() []Client
+
+	mu     sync.Mutex
+	loaded bool
+	ranges map[string]heightRange // by endpoint URL
+}
+
+// eligible returns a filter that excludes the nodes known not to serve the pinned
+// height. Nodes whose range could not be queried stay eligible: if they cannot
+// answer, the pool fails over to the next node.
+func (h *pinnedHeights) eligible(ctx context.Context) func(pool.PoolParticipant) bool {
+	h.mu.Lock()
+	if !h.loaded {
+		ranges := queryHeightRanges(ctx, h.clients(), false)
+		if ctx.Err() == nil {
+			h.ranges, h.loaded = ranges, true
+		}
+	}
+	ranges := h.ranges
+	h.mu.Unlock()
+
+	return func(client pool.PoolParticipant) bool {
+		r, ok := ranges[client.GetEndpointURL()]
+		return !ok || r.serves(h.height)
+	}
+}
+
+// queryHeightRanges queries the earliest available block, and the latest one if
+// withLatest is set, of each client concurrently. Clients whose earliest block is
+// unknown have a zero lower bound; clients that answer nothing are left out.
+func queryHeightRanges(ctx context.Context, clients []Client, withLatest bool) map[string]heightRange {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		ranges = make(map[string]heightRange, len(clients))
+	)
+	for _, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var r heightRange
+			if withLatest {
+				latest, err := pool.LatestHeight(ctx, client.Tendermint())
+				if err != nil {
+					return
+				}
+				r.latest = latest
+			}
+			if earliest, err := pool.EarliestHeight(ctx, client.Tendermint()); err == nil {
+				r.earliest = earliest
+			} else if !withLatest {
+				return
+			}
+
+			mu.Lock()
+			ranges[client.GetEndpointURL()] = r
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return ranges
+}
@@ -0,0 +1,197 @@
+package cosmosrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+	"github.com/allora-network/allora-sdk-go/pool"
+)
+
+// fakeNode serves blocks and balances between its earliest and latest height
+type fakeNode struct {
+	interfaces.CosmosClientPool
+
+	url              string
+	earliest, latest int64
+
+	mu      sync.Mutex
+	heights []int64 // heights of the balance queries served
+}
+
+func (n *fakeNode) Close() error                 { return nil }
+func (n *fakeNode) GetEndpointURL() string       { return n.url }
+func (n *fakeNode) GetProtocol() config.Protocol { return config.ProtocolGRPC }
+
+func (n *fakeNode) HealthCheck(context.Context) (pool.NodeStatus, error) {
+	return pool.NodeStatus{LatestHeight: n.latest, EarliestHeight: n.earliest}, nil
+}
+
+func (n *fakeNode) Tendermint() interfaces.TendermintClient { return &fakeTendermint{node: n} }
+func (n *fakeNode) Bank() interfaces.BankClient             { return &fakeBank{node: n} }
+
+func (n *fakeNode) queried() []int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]int64(nil), n.heights...)
+}
+
+type fakeTendermint struct {
+	interfaces.TendermintClient
+	node *fakeNode
+}
+
+func (t *fakeTendermint) GetLatestBlock(ctx context.Context, req *cmtservice.GetLatestBlockRequest, opts ...config.CallOpt) (*cmtservice.GetLatestBlockResponse, error) {
+	return &cmtservice.GetLatestBlockResponse{SdkBlock: &cmtservice.Block{Header: cmtservice.Header{Height: t.node.latest}}}, nil
+}
+
+func (t *fakeTendermint) GetBlockByHeight(ctx context.Context, req *cmtservice.GetBlockByHeightRequest, opts ...config.CallOpt) (*cmtservice.GetBlockByHeightResponse, error) {
+	if req.Height < t.node.earliest {
+		return nil, fmt.Errorf("height %d is not available, lowest height is %d", req.Height, t.node.earliest)
+	}
+	return &cmtservice.GetBlockByHeightResponse{SdkBlock: &cmtservice.Block{Header: cmtservice.Header{Height: req.Height}}}, nil
+}
+
+type fakeBank struct {
+	interfaces.BankClient
+	node *fakeNode
+}
+
+func (b *fakeBank) Balance(ctx context.Context, req *banktypes.QueryBalanceRequest, opts ...config.CallOpt) (*banktypes.QueryBalanceResponse, error) {
+	callOpts := config.DefaultCallOpts()
+	callOpts.Apply(opts...)
+
+	b.node.mu.Lock()
+	defer b.node.mu.Unlock()
+	b.node.heights = append(b.node.heights, callOpts.Height)
+	if callOpts.Height < b.node.earliest || callOpts.Height > b.node.latest {
+		return nil, fmt.Errorf("version %d does not exist", callOpts.Height)
+	}
+	coin := sdk.NewInt64Coin("uallo", callOpts.Height)
+	return &banktypes.QueryBalanceResponse{Balance: &coin}, nil
+}
+
+func newTestPool(t *testing.T, nodes ...*fakeNode) *clientPool {
+	t.Helper()
+	clients := make([]Client, len(nodes))
+	for i, node := range nodes {
+		clients[i] = node
+	}
+	p := NewClientPool(clients, config.DefaultPoolConfig(), nil, nil, zerolog.Nop())
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func queryBalances(t *testing.T, snap Snapshot, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		// The caller's height is overridden by the snapshot
+		resp, err := snap.Bank().Balance(t.Context(), &banktypes.QueryBalanceRequest{Address: "allo1test", Denom: "uallo"}, config.Height(1))
+		if err != nil {
+			t.Fatalf("query %d failed: %v", i, err)
+		}
+		if resp.Balance.Amount.Int64() != snap.Height() {
+			t.Fatalf("expected balance at height %d, got %s", snap.Height(), resp.Balance)
+		}
+	}
+}
+
+func TestSnapshotPinsLowestLatestHeight(t *testing.T) {
+	a := &fakeNode{url: "a", earliest: 1, latest: 120}
+	b := &fakeNode{url: "b", earliest: 50, latest: 110}
+	p := newTestPool(t, a, b)
+
+	snap, err := p.Snapshot(t.Context())
+	if err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	if snap.Height() != 110 {
+		t.Fatalf("expected snapshot at height 110, got %d", snap.Height())
+	}
+
+	queryBalances(t, snap, 6)
+	for _, node := range []*fakeNode{a, b} {
+		heights := node.queried()
+		if len(heights) == 0 {
+			t.Errorf("expected node %s to serve queries", node.url)
+		}
+		for _, h := range heights {
+			if h != 110 {
+				t.Errorf("node %s queried at height %d, want 110", node.url, h)
+			}
+		}
+	}
+}
+
+func TestSnapshotSkipsNodesThatPrunedTheHeight(t *testing.T) {
+	archive := &fakeNode{url: "archive", earliest: 1, latest: 120}
+	pruned := &fakeNode{url: "pruned", earliest: 130, latest: 140}
+	p := newTestPool(t, archive, pruned)
+
+	snap, err := p.Snapshot(t.Context())
+	if err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	if snap.Height() != 120 {
+		t.Fatalf("expected snapshot at height 120, got %d", snap.Height())
+	}
+
+	queryBalances(t, snap, 6)
+	if heights := pruned.queried(); len(heights) != 0 {
+		t.Errorf("expected the pruned node to be skipped, it served heights %v", heights)
+	}
+	if heights := archive.queried(); len(heights) != 6 {
+		t.Errorf("expected the archive node to serve every query, got %v", heights)
+	}
+}
+
+func TestAtHeightSkipsNodesThatPrunedTheHeight(t *testing.T) {
+	archive := &fakeNode{url: "archive", earliest: 1, latest: 200}
+	pruned := &fakeNode{url: "pruned", earliest: 100, latest: 200}
+	p := newTestPool(t, archive, pruned)
+
+	old := p.AtHeight(50)
+	queryBalances(t, old, 6)
+	if heights := pruned.queried(); len(heights) != 0 {
+		t.Errorf("expected the pruned node to be skipped, it served heights %v", heights)
+	}
+
+	recent := p.AtHeight(150)
+	queryBalances(t, recent, 6)
+	if heights := pruned.queried(); len(heights) == 0 {
+		t.Error("expected the pruned node to serve heights it still has")
+	}
+}
+
+func TestSnapshotFailsWithoutLatestHeight(t *testing.T) {
+	p := newTestPool(t)
+	if _, err := p.Snapshot(t.Context()); err == nil {
+		t.Fatal("expected snapshot to fail without nodes")
+	}
+}
+
+func TestHeightRangeServes(t *testing.T) {
+	for _, tc := range []struct {
+		r      heightRange
+		height int64
+		want   bool
+	}{
+		{heightRange{earliest: 10, latest: 20}, 15, true},
+		{heightRange{earliest: 10, latest: 20}, 5, false},
+		{heightRange{earliest: 10, latest: 20}, 25, false},
+		{heightRange{latest: 20}, 5, true},
+		{heightRange{earliest: 10}, 25, true},
+	} {
+		if got := tc.r.serves(tc.height); got != tc.want {
+			t.Errorf("%+v serves %d = %v, want %v", tc.r, tc.height, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,575 @@
+package allora
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	alloramath "github.com/allora-network/allora-chain/math"
+	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
+)
+
+// ErrNoOpenNonce is returned when a topic has no unfulfilled nonce whose
+// submission window is open at the next block
+var ErrNoOpenNonce = errors.New("no unfulfilled nonce with an open submission window")
+
+// SignWorkerDataBundle signs the inference/forecast bundle of a worker data bundle
+// with the signer's key and sets the signature and hex-encoded public key
+//
+// The chain verifies the signature against the deterministic protobuf encoding of
+// InferenceForecastsBundle, so the bundle must not be modified after signing.
+func SignWorkerDataBundle(signer Signer, bundle *emissionstypes.InputWorkerDataBundle) error {
+	pubKey, err := signerPubKey(signer)
+	if err != nil {
+		return err
+	}
+	if bundle == nil || bundle.InferenceForecastsBundle == nil {
+		return fmt.Errorf("inference/forecast bundle is required")
+	}
+
+	bz, err := bundle.InferenceForecastsBundle.XXX_Marshal(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inference/forecast bundle: %w", err)
+	}
+	sig, err := signer.Sign(bz)
+	if err != nil {
+		return fmt.Errorf("failed to sign inference/forecast bundle: %w", err)
+	}
+
+	bundle.InferencesForecastsBundleSignature = sig
+	bundle.Pubkey = hex.EncodeToString(pubKey.Bytes())
+	return nil
+}
+
+// SignReputerValueBundle signs the value bundle of a reputer value bundle with the
+// signer's key and sets the signature and hex-encoded public key
+func SignReputerValueBundle(signer Signer, bundle *emissionstypes.InputReputerValueBundle) error {
+	pubKey, err := signerPubKey(signer)
+	if err != nil {
+		return err
+	}
+	if bundle == nil || bundle.ValueBundle == nil {
+		return fmt.Errorf("value bundle is required")
+	}
+
+	bz, err := bundle.ValueBundle.XXX_Marshal(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value bundle: %w", err)
+	}
+	sig, err := signer.Sign(bz)
+	if err != nil {
+		return fmt.Errorf("failed to sign value bundle: %w", err)
+	}
+
+	bundle.Signature = sig
+	bundle.Pubkey = hex.EncodeToString(pubKey.Bytes())
+	return nil
+}
+
+// NewWorkerDataBundle builds and signs a worker data bundle for the given topic and
+// nonce. Either inference or forecast may be nil; the topic, block height and
+// worker address of the ones provided are filled in from the arguments.
+func NewWorkerDataBundle(
+	signer Signer,
+	topicID uint64,
+	nonce *emissionstypes.Nonce,
+	inference *emissionstypes.InputInference,
+	forecast *emissionstypes.InputForecast,
+) (*emissionstypes.InputWorkerDataBundle, error) {
+	if _, err := signerPubKey(signer); err != nil {
+		return nil, err
+	}
+	if nonce == nil {
+		return nil, fmt.Errorf("nonce is required")
+	}
+	if inference == nil && forecast == nil {
+		return nil, fmt.Errorf("an inference or a forecast is required")
+	}
+
+	worker := FormatAccAddress(SignerAddress(signer))
+	if inference != nil {
+		inference.TopicId = topicID
+		inference.BlockHeight = nonce.BlockHeight
+		inference.Inferer = worker
+	}
+	if forecast != nil {
+		forecast.TopicId = topicID
+		forecast.BlockHeight = nonce.BlockHeight
+		forecast.Forecaster = worker
+	}
+
+	bundle := &emissionstypes.InputWorkerDataBundle{
+		Worker:  worker,
+		Nonce:   nonce,
+		TopicId: topicID,
+		InferenceForecastsBundle: &emissionstypes.InputInferenceForecastBundle{
+			Inference: inference,
+			Forecast:  forecast,
+		},
+	}
+	if err := SignWorkerDataBundle(signer, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// NewReputerValueBundle signs a value bundle on behalf of the signer. The reputer
+// address of the value bundle is set to the signer's address.
+func NewReputerValueBundle(signer Signer, valueBundle *emissionstypes.InputValueBundle) (*emissionstypes.InputReputerValueBundle, error) {
+	if _, err := signerPubKey(signer); err != nil {
+		return nil, err
+	}
+	if valueBundle == nil {
+		return nil, fmt.Errorf("value bundle is required")
+	}
+
+	valueBundle.Reputer = FormatAccAddress(SignerAddress(signer))
+	bundle := &emissionstypes.InputReputerValueBundle{ValueBundle: valueBundle}
+	if err := SignReputerValueBundle(signer, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// NewInsertWorkerPayloadMsg creates a MsgInsertWorkerPayload for a signed worker data bundle
+func NewInsertWorkerPayloadMsg(sender string, bundle *emissionstypes.InputWorkerDataBundle) *emissionstypes.InsertWorkerPayloadRequest {
+	return &emissionstypes.InsertWorkerPayloadRequest{
+		Sender:           sender,
+		WorkerDataBundle: bundle,
+	}
+}
+
+// NewInsertReputerPayloadMsg creates a MsgInsertReputerPayload for a signed reputer value bundle
+func NewInsertReputerPayloadMsg(sender string, bundle *emissionstypes.InputReputerValueBundle) *emissionstypes.InsertReputerPayloadRequest {
+	return &emissionstypes.InsertReputerPayloadRequest{
+		Sender:             sender,
+		ReputerValueBundle: bundle,
+	}
+}
+
+// NewRegisterMsg creates a MsgRegister registering sender as a worker or reputer
+// of the topic, with owner as the account allowed to remove the registration
+func NewRegisterMsg(sender string, topicID uint64, owner string, isReputer bool) *emissionstypes.RegisterRequest {
+	if owner == "" {
+		owner = sender
+	}
+	return &emissionstypes.RegisterRequest{
+		Sender:    sender,
+		TopicId:   topicID,
+		Owner:     owner,
+		IsReputer: isReputer,
+	}
+}
+
+// NewRemoveRegistrationMsg creates a MsgRemoveRegistration
+func NewRemoveRegistrationMsg(sender string, topicID uint64, isReputer bool) *emissionstypes.RemoveRegistrationRequest {
+	return &emissionstypes.RemoveRegistrationRequest{
+		Sender:    sender,
+		TopicId:   topicID,
+		IsReputer: isReputer,
+	}
+}
+
+// NewAddStakeMsg creates a MsgAddStake staking amount (in uallo) on the sender as
+// a reputer of the topic
+func NewAddStakeMsg(sender string, topicID uint64, amount math.Int) *emissionstypes.AddStakeRequest {
+	return &emissionstypes.AddStakeRequest{
+		Sender:  sender,
+		TopicId: topicID,
+		Amount:  amount,
+	}
+}
+
+// NewRemoveStakeMsg creates a MsgRemoveStake. The stake is released after the
+// chain's removal delay.
+func NewRemoveStakeMsg(sender string, topicID uint64, amount math.Int) *emissionstypes.RemoveStakeRequest {
+	return &emissionstypes.RemoveStakeRequest{
+		Sender:  sender,
+		TopicId: topicID,
+		Amount:  amount,
+	}
+}
+
+// NewDelegateStakeMsg creates a MsgDelegateStake delegating amount to a reputer of the topic
+func NewDelegateStakeMsg(sender string, topicID uint64, reputer string, amount math.Int) *emissionstypes.DelegateStakeRequest {
+	return &emissionstypes.DelegateStakeRequest{
+		Sender:  sender,
+		TopicId: topicID,
+		Reputer: reputer,
+		Amount:  amount,
+	}
+}
+
+// NewFundTopicMsg creates a MsgFundTopic adding amount to the topic's fee revenue
+func NewFundTopicMsg(sender string, topicID uint64, amount math.Int) *emissionstypes.FundTopicRequest {
+	return &emissionstypes.FundTopicRequest{
+		Sender:  sender,
+		TopicId: topicID,
+		Amount:  amount,
+	}
+}
+
+// NewCreateNewTopicMsg creates a MsgCreateNewTopic for a single-output regression
+// topic with commonly used defaults. The ground truth lag and worker submission
+// window default to the epoch length; any field can be adjusted on the returned
+// message before it is signed.
+func NewCreateNewTopicMsg(creator, metadata, lossMethod string, epochLength int64) *emissionstypes.CreateNewTopicRequest {
+	return &emissionstypes.CreateNewTopicRequest{
+		Creator:                  creator,
+		Metadata:                 metadata,
+		LossMethod:               lossMethod,
+		EpochLength:              epochLength,
+		GroundTruthLag:           epochLength,
+		WorkerSubmissionWindow:   epochLength,
+		PNorm:                    alloramath.MustNewDecFromString("3"),
+		AlphaRegret:              alloramath.MustNewDecFromString("0.1"),
+		AllowNegative:            false,
+		Epsilon:                  alloramath.MustNewDecFromString("0.01"),
+		MeritSortitionAlpha:      alloramath.MustNewDecFromString("0.1"),
+		ActiveInfererQuantile:    alloramath.MustNewDecFromString("0.25"),
+		ActiveForecasterQuantile: alloramath.MustNewDecFromString("0.25"),
+		ActiveReputerQuantile:    alloramath.MustNewDecFromString("0.25"),
+		CNorm:                    alloramath.MustNewDecFromString("0.75"),
+		TopicType:                emissionstypes.TopicType_TOPIC_TYPE_REGRESSION,
+		OutputArity:              emissionstypes.TopicOutputArity_TOPIC_OUTPUT_ARITY_SINGLE,
+		UnityTolerance:           alloramath.ZeroDec(),
+		MaxLabelsPerSubmission:   emissionstypes.MinMaxLabelsPerSubmission,
+		LabelDefaultValue:        alloramath.ZeroDec(),
+	}
+}
+
+// SelectWorkerNonce returns the unfulfilled worker nonce of the topic that a
+// payload submitted now should target: the most recent nonce whose worker
+// submission window is still open at the next block. It returns ErrNoOpenNonce
+// if there is none.
+func SelectWorkerNonce(ctx context.Context, client Client, topicID uint64) (*emissionstypes.Nonce, error) {
+	topic, height, err := queryTopicAndNextHeight(ctx, client, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Cosmos().Emissions().GetUnfulfilledWorkerNonces(ctx, &emissionstypes.GetUnfulfilledWorkerNoncesRequest{TopicId: topicID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfulfilled worker nonces: %w", err)
+	}
+	var nonces []*emissionstypes.Nonce
+	if resp.Nonces != nil {
+		nonces = resp.Nonces.Nonces
+	}
+
+	nonce, ok := selectWorkerNonce(nonces, topic, height)
+	if !ok {
+		return nil, fmt.Errorf("topic %d at height %d: %w", topicID, height, ErrNoOpenNonce)
+	}
+	return nonce, nil
+}
+
+// SelectReputerNonce returns the unfulfilled reputer nonce of the topic that a
+// payload submitted now should target: the oldest nonce (i.e. the one closing
+// first) whose reputer submission window is open at the next block. It returns
+// ErrNoOpenNonce if there is none.
+func SelectReputerNonce(ctx context.Context, client Client, topicID uint64) (*emissionstypes.ReputerRequestNonce, error) {
+	topic, height, err := queryTopicAndNextHeight(ctx, client, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Cosmos().Emissions().GetUnfulfilledReputerNonces(ctx, &emissionstypes.GetUnfulfilledReputerNoncesRequest{TopicId: topicID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfulfilled reputer nonces: %w", err)
+	}
+	var nonces []*emissionstypes.ReputerRequestNonce
+	if resp.Nonces != nil {
+		nonces = resp.Nonces.Nonces
+	}
+
+	nonce, ok := selectReputerNonce(nonces, topic, height)
+	if !ok {
+		return nil, fmt.Errorf("topic %d at height %d: %w", topicID, height, ErrNoOpenNonce)
+	}
+	return nonce, nil
+}
+
+func queryTopicAndNextHeight(ctx context.Context, client Client, topicID uint64) (*emissionstypes.Topic, int64, error) {
+	topicResp, err := client.Cosmos().Emissions().GetTopic(ctx, &emissionstypes.GetTopicRequest{TopicId: topicID})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query topic %d: %w", topicID, err)
+	}
+	if topicResp.Topic == nil {
+		return nil, 0, fmt.Errorf("topic %d not found", topicID)
+	}
+
+	status, err := client.Tendermint().Status(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query status: %w", err)
+	}
+
+	// The transaction will be executed in the next block at the earliest
+	return topicResp.Topic, status.SyncInfo.LatestBlockHeight + 1, nil
+}
+
+// selectWorkerNonce picks the most recent nonce for which height lies within
+// [nonce, nonce + WorkerSubmissionWindow]
+func selectWorkerNonce(nonces []*emissionstypes.Nonce, topic *emissionstypes.Topic, height int64) (*emissionstypes.Nonce, bool) {
+	var best *emissionstypes.Nonce
+	for _, n := range nonces {
+		if n == nil {
+			continue
+		}
+		if height < n.BlockHeight || height > n.BlockHeight+topic.WorkerSubmissionWindow {
+			continue
+		}
+		if best == nil || n.BlockHeight > best.BlockHeight {
+			best = n
+		}
+	}
+	return best, best != nil
+}
+
+// selectReputerNonce picks the oldest nonce for which height lies within
+// [nonce + GroundTruthLag, nonce + GroundTruthLag + 2*EpochLength]
+func selectReputerNonce(nonces []*emissionstypes.ReputerRequestNonce, topic *emissionstypes.Topic, height int64) (*emissionstypes.ReputerRequestNonce, bool) {
+	var best *emissionstypes.ReputerRequestNonce
+	for _, n := range nonces {
+		if n == nil || n.ReputerNonce == nil {
+			continue
+		}
+		opens := n.ReputerNonce.BlockHeight + topic.GroundTruthLag
+		if height < opens || height > opens+topic.EpochLength*2 {
+			continue
+		}
+		if best == nil || n.ReputerNonce.BlockHeight < best.ReputerNonce.BlockHeight {
+			best = n
+		}
+	}
+	return best, best != nil
+}
+
+// EmissionsTx sends emissions module transactions signed by a single Signer
+//
+// Account sequences are managed by a SequenceManager (shared across EmissionsTx
+// instances for the same signer if one is provided), gas is estimated by
+// simulation and the fee is derived from the gas price.
+//
+// Example:
+//
+//	etx := allora.NewEmissionsTx(client, wallet)
+//
+//	res, err := etx.InsertWorkerPayload(ctx, topicID, &emissionstypes.InputInference{
+//	    Value: alloramath.MustNewBoundedExp40DecFromString("2541.17"),
+//	}, nil)
+type EmissionsTx struct {
+	client        Client
+	signer        Signer
+	address       sdk.AccAddress
+	seqMgr        *SequenceManager
+	gasAdjustment float64
+	gasPrice      *sdk.DecCoin
+	memo          string
+
+	mu      sync.Mutex
+	chainID string
+}
+
+// NewEmissionsTx creates a new EmissionsTx for the signer
+func NewEmissionsTx(client Client, signer Signer) *EmissionsTx {
+	var address sdk.AccAddress
+	if pubKey, err := signerPubKey(signer); err == nil {
+		address = sdk.AccAddress(pubKey.Address())
+	}
+	return &EmissionsTx{
+		client:        client,
+		signer:        signer,
+		address:       address,
+		seqMgr:        NewSequenceManager(client),
+		gasAdjustment: DefaultGasAdjustment,
+	}
+}
+
+// WithSequenceManager shares a SequenceManager with other senders using the same key
+func (e *EmissionsTx) WithSequenceManager(m *SequenceManager) *EmissionsTx {
+	e.seqMgr = m
+	return e
+}
+
+// WithGasAdjustment sets the factor applied to simulated gas usage
+func (e *EmissionsTx) WithGasAdjustment(adjustment float64) *EmissionsTx {
+	e.gasAdjustment = adjustment
+	return e
+}
+
+// WithGasPrice sets the gas price used to derive fees
+func (e *EmissionsTx) WithGasPrice(gasPrice sdk.DecCoin) *EmissionsTx {
+	e.gasPrice = &gasPrice
+	return e
+}
+
+// WithMemo sets the memo attached to every transaction
+func (e *EmissionsTx) WithMemo(memo string) *EmissionsTx {
+	e.memo = memo
+	return e
+}
+
+// InsertWorkerPayload selects the open worker nonce of the topic, builds and signs
+// a worker data bundle from the inference and/or forecast and submits it
+func (e *EmissionsTx) InsertWorkerPayload(
+	ctx context.Context,
+	topicID uint64,
+	inference *emissionstypes.InputInference,
+	forecast *emissionstypes.InputForecast,
+	opts ...BroadcastOpt,
+) (*BroadcastResult, error) {
+	nonce, err := SelectWorkerNonce(ctx, e.client, topicID)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := NewWorkerDataBundle(e.signer, topicID, nonce, inference, forecast)
+	if err != nil {
+		return nil, err
+	}
+	return e.Send(ctx, []sdk.Msg{NewInsertWorkerPayloadMsg(FormatAccAddress(e.address), bundle)}, opts...)
+}
+
+// InsertReputerPayload selects the open reputer nonce of the topic, signs the
+// value bundle for it and submits it. The topic and nonce of valueBundle are
+// filled in.
+func (e *EmissionsTx) InsertReputerPayload(
+	ctx context.Context,
+	topicID uint64,
+	valueBundle *emissionstypes.InputValueBundle,
+	opts ...BroadcastOpt,
+) (*BroadcastResult, error) {
+	if valueBundle == nil {
+		return nil, fmt.Errorf("value bundle is required")
+	}
+	nonce, err := SelectReputerNonce(ctx, e.client, topicID)
+	if err != nil {
+		return nil, err
+	}
+	valueBundle.TopicId = topicID
+	valueBundle.ReputerRequestNonce = nonce
+
+	bundle, err := NewReputerValueBundle(e.signer, valueBundle)
+	if err != nil {
+		return nil, err
+	}
+	return e.Send(ctx, []sdk.Msg{NewInsertReputerPayloadMsg(FormatAccAddress(e.address), bundle)}, opts...)
+}
+
+// Register registers the signer as a worker or reputer of the topic, owned by itself
+func (e *EmissionsTx) Register(ctx context.Context, topicID uint64, isReputer bool, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	addr := FormatAccAddress(e.address)
+	return e.Send(ctx, []sdk.Msg{NewRegisterMsg(addr, topicID, addr, isReputer)}, opts...)
+}
+
+// RemoveRegistration removes the signer's worker or reputer registration from the topic
+func (e *EmissionsTx) RemoveRegistration(ctx context.Context, topicID uint64, isReputer bool, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	return e.Send(ctx, []sdk.Msg{NewRemoveRegistrationMsg(FormatAccAddress(e.address), topicID, isReputer)}, opts...)
+}
+
+// AddStake stakes amount on the signer as a reputer of the topic
+func (e *EmissionsTx) AddStake(ctx context.Context, topicID uint64, amount math.Int, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	return e.Send(ctx, []sdk.Msg{NewAddStakeMsg(FormatAccAddress(e.address), topicID, amount)}, opts...)
+}
+
+// RemoveStake starts removing amount of the signer's reputer stake from the topic
+func (e *EmissionsTx) RemoveStake(ctx context.Context, topicID uint64, amount math.Int, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	return e.Send(ctx, []sdk.Msg{NewRemoveStakeMsg(FormatAccAddress(e.address), topicID, amount)}, opts...)
+}
+
+// DelegateStake delegates amount to a reputer of the topic
+func (e *EmissionsTx) DelegateStake(ctx context.Context, topicID uint64, reputer string, amount math.Int, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	return e.Send(ctx, []sdk.Msg{NewDelegateStakeMsg(FormatAccAddress(e.address), topicID, reputer, amount)}, opts...)
+}
+
+// FundTopic adds amount to the topic's fee revenue
+func (e *EmissionsTx) FundTopic(ctx context.Context, topicID uint64, amount math.Int, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	return e.Send(ctx, []sdk.Msg{NewFundTopicMsg(FormatAccAddress(e.address), topicID, amount)}, opts...)
+}
+
+// CreateNewTopic creates a topic. The creator of msg is set to the signer's address.
+// See NewCreateNewTopicMsg for a message with default parameters.
+func (e *EmissionsTx) CreateNewTopic(ctx context.Context, msg *emissionstypes.CreateNewTopicRequest, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("create topic message is required")
+	}
+	msg.Creator = FormatAccAddress(e.address)
+	return e.Send(ctx, []sdk.Msg{msg}, opts...)
+}
+
+// Send signs and broadcasts arbitrary messages from the signer, estimating gas
+// and resyncing the account sequence on mismatch
+func (e *EmissionsTx) Send(ctx context.Context, msgs []sdk.Msg, opts ...BroadcastOpt) (*BroadcastResult, error) {
+	if e.address.Empty() {
+		return nil, fmt.Errorf("signer is required")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= defaultSequenceMismatchRetries; attempt++ {
+		// The sequence is only reserved once params are built, so a failure here
+		// leaves nothing to release
+		params, err := e.txParams(ctx, msgs)
+		if err != nil {
+			// Simulation runs the ante handler, so it can report a sequence mismatch too
+			if e.seqMgr.HandleError(ctx, e.address, err) {
+				lastErr = err
+				continue
+			}
+			return nil, err
+		}
+
+		signedTx, err := CreateSignedTx(msgs, e.signer, params)
+		if err != nil {
+			e.seqMgr.release(e.address, params.Sequence)
+			return nil, err
+		}
+
+		res, err := e.client.BroadcastTx(ctx, signedTx, opts...)
+		if err == nil {
+			return res, nil
+		}
+
+		lastErr = err
+		if !e.seqMgr.HandleError(ctx, e.address, err) {
+			e.seqMgr.releaseRejected(e.address, params.Sequence, err)
+			return res, err
+		}
+	}
+
+	return nil, fmt.Errorf("giving up after %d sequence mismatches: %w", defaultSequenceMismatchRetries+1, lastErr)
+}
+
+func (e *EmissionsTx) txParams(ctx context.Context, msgs []sdk.Msg) (*TxParams, error) {
+	e.mu.Lock()
+	chainID := e.chainID
+	e.mu.Unlock()
+
+	b := NewTxParamsBuilder(ctx, e.client).
+		WithAddress(e.address).
+		WithSequenceManager(e.seqMgr).
+		WithMsgs(msgs...).
+		WithPubKey(e.signer.GetPubKey()).
+		WithAutoGas(e.gasAdjustment).
+		WithMemo(e.memo)
+	if chainID != "" {
+		b = b.WithChainID(chainID)
+	}
+	if e.gasPrice != nil {
+		b = b.WithGasPrice(*e.gasPrice)
+	}
+
+	params, err := b.QueryAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.chainID = params.ChainID
+	e.mu.Unlock()
+	return params, nil
+}
@@ -0,0 +1,174 @@
+package allora
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	alloramath "github.com/allora-network/allora-chain/math"
+	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
+)
+
+// useAlloraSDKConfig sets the Allora prefixes on the global sdk.Config for the duration of
+// the test. The chain's Validate functions decode addresses with the global config,
+// which the SDK itself no longer touches. Tests using it must not call t.Parallel.
+func useAlloraSDKConfig(t *testing.T) {
+	t.Helper()
+
+	config := sdk.GetConfig()
+	acc, accPub := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	val, valPub := config.GetBech32ValidatorAddrPrefix(), config.GetBech32ValidatorPubPrefix()
+	cons, consPub := config.GetBech32ConsensusAddrPrefix(), config.GetBech32ConsensusPubPrefix()
+	t.Cleanup(func() {
+		config.SetBech32PrefixForAccount(acc, accPub)
+		config.SetBech32PrefixForValidator(val, valPub)
+		config.SetBech32PrefixForConsensusNode(cons, consPub)
+	})
+
+	config.SetBech32PrefixForAccount(AlloraBech32Prefix, AlloraBech32Prefix+"pub")
+	config.SetBech32PrefixForValidator(AlloraBech32Prefix+"valoper", AlloraBech32Prefix+"valoperpub")
+	config.SetBech32PrefixForConsensusNode(AlloraBech32Prefix+"valcons", AlloraBech32Prefix+"valconspub")
+}
+
+func TestNewWorkerDataBundleSignature(t *testing.T) {
+	useAlloraSDKConfig(t)
+
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+
+	nonce := &emissionstypes.Nonce{BlockHeight: 1200}
+	bundle, err := NewWorkerDataBundle(wallet, 7, nonce,
+		&emissionstypes.InputInference{Value: alloramath.MustNewBoundedExp40DecFromString("2541.17")},
+		&emissionstypes.InputForecast{
+			ForecastElements: []*emissionstypes.InputForecastElement{
+				{Inferer: wallet.GetAddress(), Value: alloramath.MustNewBoundedExp40DecFromString("2540.5")},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to build worker data bundle: %v", err)
+	}
+
+	// Same checks the chain runs in InsertWorkerPayload, including signature verification
+	if err := bundle.Validate(); err != nil {
+		t.Fatalf("bundle failed chain validation: %v", err)
+	}
+
+	// Tampering with the signed payload must invalidate the signature
+	bundle.InferenceForecastsBundle.Inference.Value = alloramath.MustNewBoundedExp40DecFromString("1")
+	if err := bundle.Validate(); err == nil {
+		t.Fatal("expected tampered bundle to fail validation")
+	}
+}
+
+func TestNewReputerValueBundleSignature(t *testing.T) {
+	useAlloraSDKConfig(t)
+
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+
+	bundle, err := NewReputerValueBundle(wallet, &emissionstypes.InputValueBundle{
+		TopicId: 7,
+		ReputerRequestNonce: &emissionstypes.ReputerRequestNonce{
+			ReputerNonce: &emissionstypes.Nonce{BlockHeight: 1200},
+		},
+		CombinedValue: alloramath.MustNewBoundedExp40DecFromString("0.5"),
+		NaiveValue:    alloramath.MustNewBoundedExp40DecFromString("0.6"),
+		InfererValues: []*emissionstypes.InputWorkerAttributedValue{
+			{Worker: wallet.GetAddress(), Value: alloramath.MustNewBoundedExp40DecFromString("0.4")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build reputer value bundle: %v", err)
+	}
+	if bundle.ValueBundle.Reputer != wallet.GetAddress() {
+		t.Fatalf("expected reputer %s, got %s", wallet.GetAddress(), bundle.ValueBundle.Reputer)
+	}
+	if err := bundle.Validate(); err != nil {
+		t.Fatalf("bundle failed chain validation: %v", err)
+	}
+}
+
+func TestSelectWorkerNonce(t *testing.T) {
+	topic := &emissionstypes.Topic{EpochLength: 10, WorkerSubmissionWindow: 5}
+	nonces := []*emissionstypes.Nonce{{BlockHeight: 90}, {BlockHeight: 100}, {BlockHeight: 110}}
+
+	nonce, ok := selectWorkerNonce(nonces, topic, 104)
+	if !ok || nonce.BlockHeight != 100 {
+		t.Fatalf("expected nonce 100, got %v", nonce)
+	}
+
+	// Windows of 100 and 110 overlap at 110; the most recent one wins
+	topic.WorkerSubmissionWindow = 10
+	nonce, ok = selectWorkerNonce(nonces, topic, 110)
+	if !ok || nonce.BlockHeight != 110 {
+		t.Fatalf("expected nonce 110, got %v", nonce)
+	}
+
+	if _, ok := selectWorkerNonce(nonces, topic, 200); ok {
+		t.Fatal("expected no open nonce")
+	}
+}
+
+func TestSelectReputerNonce(t *testing.T) {
+	topic := &emissionstypes.Topic{EpochLength: 10, GroundTruthLag: 20}
+	nonces := []*emissionstypes.ReputerRequestNonce{
+		{ReputerNonce: &emissionstypes.Nonce{BlockHeight: 110}},
+		{ReputerNonce: &emissionstypes.Nonce{BlockHeight: 100}},
+		{ReputerNonce: &emissionstypes.Nonce{BlockHeight: 120}},
+	}
+
+	// 100 opens at 120, 110 at 130; the oldest open nonce wins
+	nonce, ok := selectReputerNonce(nonces, topic, 135)
+	if !ok || nonce.ReputerNonce.BlockHeight != 100 {
+		t.Fatalf("expected nonce 100, got %v", nonce)
+	}
+
+	if _, ok := selectReputerNonce(nonces, topic, 119); ok {
+		t.Fatal("expected no open nonce before the ground truth lag")
+	}
+}
+
+func TestNewCreateNewTopicMsgDefaults(t *testing.T) {
+	useAlloraSDKConfig(t)
+
+	wallet, _ := GenerateWallet()
+	msg := NewCreateNewTopicMsg(wallet.GetAddress(), "ETH 10min prediction", "mse", 120)
+	if err := msg.Validate(256, 16); err != nil {
+		t.Fatalf("default topic failed chain validation: %v", err)
+	}
+}
+
+func TestCreateUnsignedTxWithoutGlobalBech32Config(t *testing.T) {
+	if prefix := sdk.GetConfig().GetBech32AccountAddrPrefix(); prefix == AlloraBech32Prefix {
+		t.Fatalf("expected the global sdk.Config to keep its default prefix, got %s", prefix)
+	}
+
+	wallet, _ := GenerateWallet()
+	params := DefaultTxParams()
+	params.ChainID = "allora-testnet-1"
+
+	msgs := []sdk.Msg{
+		NewCreateNewTopicMsg(wallet.GetAddress(), "ETH 10min prediction", "mse", 120),
+		NewFundTopicMsg(wallet.GetAddress(), 1, sdkmath.NewInt(1000)),
+	}
+	if _, err := CreateUnsignedTx(msgs, params); err != nil {
+		t.Fatalf("failed to build transaction: %v", err)
+	}
+
+	addr := wallet.GetAddress()
+	last := "q"
+	if addr[len(addr)-1] == 'q' {
+		last = "p"
+	}
+	corrupted := addr[:len(addr)-1] + last
+	msgs = []sdk.Msg{NewFundTopicMsg(corrupted, 1, sdkmath.NewInt(1000))}
+	if _, err := CreateUnsignedTx(msgs, params); err == nil {
+		t.Fatal("expected a signer with a bad checksum to be rejected")
+	}
+}
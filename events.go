@@ -0,0 +1,257 @@
+package allora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/allora-network/allora-sdk-go/codec"
+	"github.com/allora-network/allora-sdk-go/tmrpc"
+)
+
+// Event is a typed event (e.g. emissions.v10.EventScoresSet) decoded from a
+// transaction result or from a block's finalize-block events
+type Event struct {
+	Height int64
+	TxHash string // empty for finalize-block events
+	Type   string // fully-qualified proto name, e.g. "emissions.v10.EventScoresSet"
+	Msg    proto.Message
+}
+
+// TypedEvent is an Event whose message has the concrete type T
+type TypedEvent[T proto.Message] struct {
+	Height int64
+	TxHash string
+	Event  T
+}
+
+// EventOpt is a functional option for configuring an event subscription
+type EventOpt func(*EventOpts)
+
+// EventOpts holds configuration options for an event subscription
+type EventOpts struct {
+	Types       []string // proto names of the events to deliver; all typed events when empty
+	TxEvents    bool     // decode events emitted by transactions
+	BlockEvents bool     // decode finalize-block events (e.g. rewards and scores set at epoch end)
+	BufferSize  int
+}
+
+// Apply applies the provided options to EventOpts
+func (o *EventOpts) Apply(opts ...EventOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultEventOpts returns default subscription options: all typed events from
+// both transactions and finalize-block events
+func DefaultEventOpts() *EventOpts {
+	return &EventOpts{
+		TxEvents:    true,
+		BlockEvents: true,
+		BufferSize:  256,
+	}
+}
+
+// WithEventTypes restricts the subscription to events with the given proto names
+func WithEventTypes(types ...string) EventOpt {
+	return func(opts *EventOpts) {
+		opts.Types = append(opts.Types, types...)
+	}
+}
+
+// WithTxEvents sets whether events emitted by transactions are delivered
+func WithTxEvents(enabled bool) EventOpt {
+	return func(opts *EventOpts) {
+		opts.TxEvents = enabled
+	}
+}
+
+// WithBlockEvents sets whether finalize-block events are delivered
+func WithBlockEvents(enabled bool) EventOpt {
+	return func(opts *EventOpts) {
+		opts.BlockEvents = enabled
+	}
+}
+
+// WithEventBufferSize sets the capacity of the returned channel
+func WithEventBufferSize(size int) EventOpt {
+	return func(opts *EventOpts) {
+		opts.BufferSize = size
+	}
+}
+
+// SubscribeEvents subscribes to Tx and NewBlockEvents over the websocket pool and
+// delivers the typed events they carry. Untyped (legacy key/value) events are skipped.
+// The channel is closed and the websocket subscriptions are cancelled when ctx is done.
+//
+// Example:
+//
+//	events, err := client.SubscribeEvents(ctx, allora.WithEventTypes("emissions.v10.EventScoresSet"))
+//	for evt := range events {
+//	    scores := evt.Msg.(*emissionstypes.EventScoresSet)
+//	    fmt.Println(evt.Height, scores.TopicId, scores.Scores)
+//	}
+func (c *client) SubscribeEvents(ctx context.Context, opts ...EventOpt) (<-chan Event, error) {
+	o := DefaultEventOpts()
+	o.Apply(opts...)
+	if !o.TxEvents && !o.BlockEvents {
+		return nil, fmt.Errorf("at least one of tx or block events must be enabled")
+	}
+
+	var queries []string
+	if o.TxEvents {
+		queries = append(queries, ctypes.EventQueryTx.String())
+	}
+	if o.BlockEvents {
+		queries = append(queries, ctypes.EventQueryNewBlockEvents.String())
+	}
+
+	mb := tmrpc.NewMailbox(1000)
+	var subs []tmrpc.Subscription
+	unsubscribe := func() {
+		for _, sub := range subs {
+			if err := sub.Unsubscribe(); err != nil {
+				c.logger.Warn().Err(err).Msg("failed to unsubscribe")
+			}
+		}
+	}
+	for _, query := range queries {
+		sub, err := c.websocketPool.Subscribe(mb, query)
+		if err != nil {
+			unsubscribe()
+			return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+		}
+		subs = append(subs, sub)
+	}
+
+	ch := make(chan Event, o.BufferSize)
+	for _, sub := range subs {
+		go c.logSubscriptionErrors(ctx, sub)
+	}
+	go func() {
+		defer close(ch)
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-mb.Notify():
+				for _, data := range mb.RetrieveAll() {
+					events, err := DecodeEvents(data, o.Types...)
+					if err != nil {
+						c.logger.Warn().Err(err).Msg("failed to decode some events")
+					}
+					for _, evt := range events {
+						select {
+						case ch <- evt:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *client) logSubscriptionErrors(ctx context.Context, sub tmrpc.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-sub.Err():
+			c.logger.Error().Err(err).Str("query", sub.Query()).Msg("event subscription failed")
+		}
+	}
+}
+
+// SubscribeTypedEvents subscribes to events of type T, e.g.
+//
+//	scores, err := allora.SubscribeTypedEvents[*emissionstypes.EventScoresSet](ctx, client)
+//	for evt := range scores {
+//	    fmt.Println(evt.Height, evt.Event.TopicId, evt.Event.Scores)
+//	}
+func SubscribeTypedEvents[T proto.Message](ctx context.Context, c Client, opts ...EventOpt) (<-chan TypedEvent[T], error) {
+	var zero T
+	name := proto.MessageName(zero)
+	if name == "" {
+		return nil, fmt.Errorf("%T is not a registered proto message", zero)
+	}
+
+	events, err := c.SubscribeEvents(ctx, append(opts, WithEventTypes(name))...)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan TypedEvent[T], cap(events))
+	go func() {
+		defer close(ch)
+		for evt := range events {
+			msg, ok := evt.Msg.(T)
+			if !ok {
+				continue
+			}
+			select {
+			case ch <- TypedEvent[T]{Height: evt.Height, TxHash: evt.TxHash, Event: msg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+var eventCodec = codec.NewCodec()
+
+// DecodeEvents decodes the typed events carried by websocket event data. It supports
+// EventDataTx (transaction events), EventDataNewBlockEvents and EventDataNewBlock
+// (finalize-block events). If types is non-empty, only events with those proto names
+// are returned. Events that fail to decode are reported in the error; the others are
+// still returned.
+func DecodeEvents(data ctypes.TMEventData, types ...string) ([]Event, error) {
+	var (
+		height int64
+		txHash string
+		raw    []abcitypes.Event
+	)
+	switch d := data.(type) {
+	case ctypes.EventDataTx:
+		height, txHash, raw = d.Height, TxHash(d.Tx), d.Result.Events
+	case ctypes.EventDataNewBlockEvents:
+		height, raw = d.Height, d.Events
+	case ctypes.EventDataNewBlock:
+		if d.Block != nil {
+			height = d.Block.Height
+		}
+		raw = d.ResultFinalizeBlock.Events
+	default:
+		return nil, nil
+	}
+
+	var (
+		events []Event
+		errs   []error
+	)
+	for i := range raw {
+		if len(types) > 0 && !slices.Contains(types, raw[i].Type) {
+			continue
+		}
+		if !eventCodec.IsTypedEvent(&raw[i]) {
+			continue
+		}
+		msg, err := eventCodec.ParseTypedEvent(&raw[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s at height %d: %w", raw[i].Type, height, err))
+			continue
+		}
+		events = append(events, Event{Height: height, TxHash: txHash, Type: raw[i].Type, Msg: msg})
+	}
+	return events, errors.Join(errs...)
+}
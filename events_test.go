@@ -0,0 +1,189 @@
+package allora
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/rs/zerolog"
+
+	alloramath "github.com/allora-network/allora-chain/math"
+	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
+
+	"github.com/allora-network/allora-sdk-go/tmrpc"
+)
+
+func mustTypedEvent(t *testing.T, msg proto.Message, extra ...abcitypes.EventAttribute) abcitypes.Event {
+	t.Helper()
+	evt, err := sdk.TypedEventToEvent(msg)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", msg, err)
+	}
+	abciEvt := abcitypes.Event(evt)
+	abciEvt.Attributes = append(abciEvt.Attributes, extra...)
+	return abciEvt
+}
+
+func testEventData(t *testing.T) (ctypes.EventDataTx, ctypes.EventDataNewBlockEvents) {
+	t.Helper()
+
+	txData := ctypes.EventDataTx{TxResult: abcitypes.TxResult{
+		Height: 100,
+		Tx:     []byte("tx"),
+		Result: abcitypes.ExecTxResult{Events: []abcitypes.Event{
+			{Type: "transfer", Attributes: []abcitypes.EventAttribute{{Key: "amount", Value: "1uallo"}}},
+			mustTypedEvent(t, &emissionstypes.EventInsertInfererPayload{
+				Inferer: "allo1inferer",
+				Nonce:   90,
+				TopicId: 7,
+				Value:   alloramath.MustNewBoundedExp40DecFromString("2541.17"),
+			}),
+		}},
+	}}
+
+	blockData := ctypes.EventDataNewBlockEvents{
+		Height: 101,
+		Events: []abcitypes.Event{
+			mustTypedEvent(t, &emissionstypes.EventScoresSet{
+				TopicId:     7,
+				BlockHeight: 101,
+				Addresses:   []string{"allo1a", "allo1b"},
+				Scores:      []alloramath.Dec{alloramath.MustNewDecFromString("1.5"), alloramath.MustNewDecFromString("-0.25")},
+			}, abcitypes.EventAttribute{Key: "mode", Value: "EndBlock"}),
+			mustTypedEvent(t, &emissionstypes.EventNetworkLossSet{TopicId: 7, Nonce: 90}, abcitypes.EventAttribute{Key: "mode", Value: "EndBlock"}),
+		},
+	}
+	return txData, blockData
+}
+
+func TestDecodeEvents(t *testing.T) {
+	txData, blockData := testEventData(t)
+
+	txEvents, err := DecodeEvents(txData)
+	if err != nil {
+		t.Fatalf("failed to decode tx events: %v", err)
+	}
+	if len(txEvents) != 1 {
+		t.Fatalf("expected 1 typed tx event, got %d", len(txEvents))
+	}
+	inference, ok := txEvents[0].Msg.(*emissionstypes.EventInsertInfererPayload)
+	if !ok || inference.TopicId != 7 || inference.Value.String() != "2541.17" {
+		t.Fatalf("unexpected tx event %+v", txEvents[0].Msg)
+	}
+	if txEvents[0].Height != 100 || txEvents[0].TxHash != TxHash([]byte("tx")) {
+		t.Fatalf("unexpected tx event metadata %+v", txEvents[0])
+	}
+
+	blockEvents, err := DecodeEvents(blockData)
+	if err != nil {
+		t.Fatalf("failed to decode block events: %v", err)
+	}
+	if len(blockEvents) != 2 || blockEvents[0].TxHash != "" || blockEvents[0].Height != 101 {
+		t.Fatalf("unexpected block events %+v", blockEvents)
+	}
+	scores, ok := blockEvents[0].Msg.(*emissionstypes.EventScoresSet)
+	if !ok || len(scores.Scores) != 2 || scores.Scores[1].String() != "-0.25" {
+		t.Fatalf("unexpected scores event %+v", blockEvents[0].Msg)
+	}
+	if _, ok := blockEvents[1].Msg.(*emissionstypes.EventNetworkLossSet); !ok {
+		t.Fatalf("unexpected loss event %+v", blockEvents[1].Msg)
+	}
+
+	filtered, err := DecodeEvents(blockData, proto.MessageName(&emissionstypes.EventNetworkLossSet{}))
+	if err != nil || len(filtered) != 1 || filtered[0].Type != "emissions.v10.EventNetworkLossSet" {
+		t.Fatalf("unexpected filtered events %+v (err %v)", filtered, err)
+	}
+}
+
+type fakeWebsocketPool struct {
+	mu      sync.Mutex
+	queries []string
+	unsubs  []string
+	mbs     []*tmrpc.Mailbox
+}
+
+func (p *fakeWebsocketPool) Subscribe(mb *tmrpc.Mailbox, query string) (tmrpc.Subscription, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queries = append(p.queries, query)
+	p.mbs = append(p.mbs, mb)
+	return &fakeSubscription{pool: p, query: query}, nil
+}
+
+func (p *fakeWebsocketPool) Close() {}
+
+func (p *fakeWebsocketPool) unsubscribed() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.unsubs)
+}
+
+type fakeSubscription struct {
+	pool  *fakeWebsocketPool
+	query string
+}
+
+func (s *fakeSubscription) Query() string { return s.query }
+
+func (s *fakeSubscription) Err() <-chan error { return nil }
+
+func (s *fakeSubscription) Unsubscribe() error {
+	s.pool.mu.Lock()
+	defer s.pool.mu.Unlock()
+	s.pool.unsubs = append(s.pool.unsubs, s.query)
+	return nil
+}
+
+func (p *fakeWebsocketPool) deliver(data ctypes.TMEventData) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mbs[0].Deliver(data)
+}
+
+func TestSubscribeTypedEvents(t *testing.T) {
+	pool := &fakeWebsocketPool{}
+	c := &client{websocketPool: pool, logger: zerolog.Nop()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scores, err := SubscribeTypedEvents[*emissionstypes.EventScoresSet](ctx, c)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	if len(pool.queries) != 2 || pool.queries[0] != "tm.event = 'Tx'" || pool.queries[1] != "tm.event = 'NewBlockEvents'" {
+		t.Fatalf("unexpected subscription queries %v", pool.queries)
+	}
+
+	txData, blockData := testEventData(t)
+	pool.deliver(txData)
+	pool.deliver(blockData)
+
+	select {
+	case evt := <-scores:
+		if evt.Height != 101 || evt.Event.TopicId != 7 {
+			t.Fatalf("unexpected event %+v", evt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	cancel()
+	for range scores {
+	}
+
+	// Cancelling the context releases the websocket subscriptions
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pool.unsubscribed()) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected both subscriptions to be cancelled, got %v", pool.unsubscribed())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -198,7 +198,11 @@ func main() {
 	}
 
 	mb := tmrpc.NewMailbox(100)
-	client.Subscribe(mb, "tm.event='NewBlock'")
+	sub, err := client.Subscribe(mb, "tm.event='NewBlock'")
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to subscribe to new blocks")
+	}
+	defer sub.Unsubscribe()
 
 	chBlock := make(chan struct{})
 
@@ -217,6 +221,8 @@ func main() {
 				return
 			}
 			fmt.Printf("✅ Received new block event: %v\n", blockEvt.Block.Height)
+		case err := <-sub.Err():
+			logger.Error().Err(err).Msg("new block subscription failed")
 		case <-time.After(15 * time.Second):
 			logger.Error().Msg("timeout waiting for new block event")
 		}
@@ -0,0 +1,110 @@
+package allora
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+const (
+	// DefaultGasAdjustment is the multiplier applied to simulated gas usage when
+	// no explicit adjustment is given
+	DefaultGasAdjustment = 1.5
+
+	// DefaultFeeDenom is the denom used for fees when the node does not advertise
+	// a minimum gas price
+	DefaultFeeDenom = "uallo"
+)
+
+// DefaultGasPrice is the gas price used when neither a gas price is configured nor
+// the node advertises a minimum gas price. It matches the ratio of the default
+// fee to the default gas limit in DefaultTxParams.
+var DefaultGasPrice = sdk.NewDecCoinFromDec(DefaultFeeDenom, sdkmath.LegacyNewDecWithPrec(25, 3))
+
+// SimulateGas simulates a transaction containing the given messages and returns
+// the gas it consumed
+//
+// The transaction is built exactly as it would be signed (same params, memo and
+// sequence) but with an empty signature, so pubKey is only used to let the chain
+// charge the correct signature verification gas. It may be nil for accounts whose
+// public key is not yet known on chain.
+func SimulateGas(ctx context.Context, client Client, msgs []sdk.Msg, pubKey cryptotypes.PubKey, params *TxParams) (uint64, error) {
+	if len(msgs) == 0 {
+		return 0, fmt.Errorf("at least one message is required")
+	}
+	if params == nil {
+		return 0, fmt.Errorf("transaction parameters are required")
+	}
+
+	txBytes, err := newTxBuilder().buildSimulationTx(msgs, pubKey, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build simulation transaction: %w", err)
+	}
+
+	resp, err := client.Cosmos().Tx().Simulate(ctx, &txtypes.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	if resp.GasInfo == nil {
+		return 0, fmt.Errorf("simulation returned no gas info")
+	}
+
+	return resp.GasInfo.GasUsed, nil
+}
+
+// EstimateGas simulates a transaction and returns its gas usage multiplied by the
+// given adjustment factor (DefaultGasAdjustment if adjustment is not positive)
+func EstimateGas(ctx context.Context, client Client, msgs []sdk.Msg, pubKey cryptotypes.PubKey, params *TxParams, adjustment float64) (uint64, error) {
+	gasUsed, err := SimulateGas(ctx, client, msgs, pubKey, params)
+	if err != nil {
+		return 0, err
+	}
+	return AdjustGas(gasUsed, adjustment), nil
+}
+
+// AdjustGas multiplies a gas amount by the adjustment factor, rounding up
+func AdjustGas(gas uint64, adjustment float64) uint64 {
+	if adjustment <= 0 {
+		adjustment = DefaultGasAdjustment
+	}
+	return uint64(math.Ceil(float64(gas) * adjustment))
+}
+
+// QueryMinGasPrice returns the minimum gas price advertised by a node for the
+// given denom (or the first configured denom if denom is empty)
+//
+// The second return value is false if the node does not enforce a minimum gas
+// price for the denom.
+func QueryMinGasPrice(ctx context.Context, client Client, denom string) (sdk.DecCoin, bool, error) {
+	resp, err := client.Cosmos().Node().Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		return sdk.DecCoin{}, false, fmt.Errorf("failed to query node config: %w", err)
+	}
+	if resp.MinimumGasPrice == "" {
+		return sdk.DecCoin{}, false, nil
+	}
+
+	prices, err := sdk.ParseDecCoins(resp.MinimumGasPrice)
+	if err != nil {
+		return sdk.DecCoin{}, false, fmt.Errorf("failed to parse minimum gas price %q: %w", resp.MinimumGasPrice, err)
+	}
+
+	for _, price := range prices {
+		if (denom == "" || price.Denom == denom) && price.IsPositive() {
+			return price, true, nil
+		}
+	}
+	return sdk.DecCoin{}, false, nil
+}
+
+// FeeForGas computes the fee for a gas limit at the given gas price, rounding up
+func FeeForGas(gasLimit uint64, gasPrice sdk.DecCoin) sdk.Coins {
+	amount := gasPrice.Amount.MulInt(sdkmath.NewIntFromUint64(gasLimit)).Ceil().TruncateInt()
+	return sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, amount))
+}
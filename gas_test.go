@@ -0,0 +1,98 @@
+package allora
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestAdjustGas(t *testing.T) {
+	if got := AdjustGas(100000, 1.3); got != 130000 {
+		t.Errorf("expected 130000, got %d", got)
+	}
+	if got := AdjustGas(100001, 1.5); got != 150002 {
+		t.Errorf("expected rounding up to 150002, got %d", got)
+	}
+	if got := AdjustGas(100000, 0); got != 150000 {
+		t.Errorf("expected default adjustment to give 150000, got %d", got)
+	}
+}
+
+func TestFeeForGas(t *testing.T) {
+	price := sdk.NewDecCoinFromDec("uallo", sdkmath.LegacyMustNewDecFromStr("10"))
+	fee := FeeForGas(123456, price)
+	if !fee.Equal(sdk.NewCoins(sdk.NewInt64Coin("uallo", 1234560))) {
+		t.Errorf("unexpected fee: %s", fee)
+	}
+
+	// Fractional prices round up
+	fee = FeeForGas(200001, DefaultGasPrice)
+	if !fee.Equal(sdk.NewCoins(sdk.NewInt64Coin("uallo", 5001))) {
+		t.Errorf("unexpected fee: %s", fee)
+	}
+}
+
+func TestBuildSimulationTx(t *testing.T) {
+	wallet, _ := GenerateWallet()
+	recipientWallet, _ := GenerateWallet()
+
+	params := DefaultTxParams()
+	params.ChainID = "allora-testnet-1"
+	params.Sequence = 9
+
+	msg := banktypes.NewMsgSend(wallet.Address, recipientWallet.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1)))
+
+	txBytes, err := newTxBuilder().buildSimulationTx([]sdk.Msg{msg}, wallet.PubKey, params)
+	if err != nil {
+		t.Fatalf("failed to build simulation tx: %v", err)
+	}
+
+	parsed, err := ParseTxBytes(txBytes)
+	if err != nil {
+		t.Fatalf("failed to parse simulation tx: %v", err)
+	}
+
+	sigTx, ok := (*parsed).(authsigning.SigVerifiableTx)
+	if !ok {
+		t.Fatal("simulation tx does not expose signatures")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		t.Fatalf("failed to read signatures: %v", err)
+	}
+	if len(sigs) != 1 || sigs[0].Sequence != 9 || !sigs[0].PubKey.Equals(wallet.PubKey) {
+		t.Errorf("unexpected signer info: %+v", sigs)
+	}
+}
+
+func TestTxParamsBuilderDerivesFeeFromGasPrice(t *testing.T) {
+	price := sdk.NewDecCoinFromDec("uallo", sdkmath.LegacyMustNewDecFromStr("0.5"))
+
+	params, err := NewTxParamsBuilder(context.Background(), nil).
+		WithChainID("allora-testnet-1").
+		WithAccountNumber(1).
+		WithSequence(2).
+		WithGasLimit(300000).
+		WithGasPrice(price).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !params.FeeAmount.Equal(sdk.NewCoins(sdk.NewInt64Coin("uallo", 150000))) {
+		t.Errorf("unexpected fee: %s", params.FeeAmount)
+	}
+
+	_, err = NewTxParamsBuilder(context.Background(), nil).
+		WithChainID("allora-testnet-1").
+		WithAccountNumber(1).
+		WithSequence(2).
+		WithAutoGas(1.2).
+		Build()
+	if err == nil {
+		t.Error("expected auto gas to be rejected by offline Build")
+	}
+}
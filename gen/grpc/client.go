@@ -17,11 +17,10 @@ import (
 	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/metadata"
 
-	cmtservice "github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
-
 	"github.com/allora-network/allora-sdk-go/codec"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+	"github.com/allora-network/allora-sdk-go/pool"
 )
 
 // GRPCClient implements the Client interface using gRPC
@@ -199,13 +198,12 @@ func queryWithHeight[In any, Out any](ctx context.Context, height int64, queryFn
 	return resp, nil
 }
 
-// Status implements the Status method required by ClientPoolManager
-func (c *GRPCClient) Status(ctx context.Context) error {
-	_, err := c.tendermint.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
-	return err
+// Status reports whether the node is catching up and the range of blocks it serves
+func (c *GRPCClient) Status(ctx context.Context) (pool.NodeStatus, error) {
+	return pool.QueryNodeStatus(ctx, c.tendermint)
 }
 
 // HealthCheck wraps Status to satisfy pool requirements
-func (c *GRPCClient) HealthCheck(ctx context.Context) error {
+func (c *GRPCClient) HealthCheck(ctx context.Context) (pool.NodeStatus, error) {
 	return c.Status(ctx)
 }
@@ -14,7 +14,6 @@ import (
 	"time"
 
 	"github.com/brynbellomy/go-utils/errors"
-	cmtservice "github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/rs/zerolog"
 
@@ -24,6 +23,7 @@ import (
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/metrics"
+	"github.com/allora-network/allora-sdk-go/pool"
 )
 
 // RESTClient implements the interfaces.CosmosClient interface using REST/JSON-RPC
@@ -174,14 +174,13 @@ func (c *RESTClient) Staking() interfaces.StakingClient {
 	return c.staking
 }
 
-// Status implements a basic health check using the Tendermint service
-func (c *RESTClient) Status(ctx context.Context) error {
-	_, err := c.tendermint.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
-	return err
+// Status reports whether the node is catching up and the range of blocks it serves
+func (c *RESTClient) Status(ctx context.Context) (pool.NodeStatus, error) {
+	return pool.QueryNodeStatus(ctx, c.tendermint)
 }
 
 // HealthCheck wraps Status to satisfy pool requirements
-func (c *RESTClient) HealthCheck(ctx context.Context) error {
+func (c *RESTClient) HealthCheck(ctx context.Context) (pool.NodeStatus, error) {
 	return c.Status(ctx)
 }
 
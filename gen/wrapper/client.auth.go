@@ -6,81 +6,145 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// AuthClientWrapper wraps the auth module with pool management and retry logic
+// AuthClientWrapper wraps the auth module with pool management, retry logic, response caching and request coalescing
 type AuthClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewAuthClientWrapper creates a new auth client wrapper
-func NewAuthClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *AuthClientWrapper {
+func NewAuthClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *AuthClientWrapper {
 	return &AuthClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "auth").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *AuthClientWrapper) pinned(pin *pool.Pin) *AuthClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *AuthClientWrapper) Account(ctx context.Context, req *authtypes.QueryAccountRequest, opts ...config.CallOpt) (*authtypes.QueryAccountResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryAccountResponse, error) {
-		return client.Auth().Account(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "Account", req, opts, func() (*authtypes.QueryAccountResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "Account", req, opts, func() (*authtypes.QueryAccountResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryAccountResponse, error) {
+				return client.Auth().Account(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) AccountAddressByID(ctx context.Context, req *authtypes.QueryAccountAddressByIDRequest, opts ...config.CallOpt) (*authtypes.QueryAccountAddressByIDResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryAccountAddressByIDResponse, error) {
-		return client.Auth().AccountAddressByID(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "AccountAddressByID", req, opts, func() (*authtypes.QueryAccountAddressByIDResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "AccountAddressByID", req, opts, func() (*authtypes.QueryAccountAddressByIDResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryAccountAddressByIDResponse, error) {
+				return client.Auth().AccountAddressByID(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) AccountInfo(ctx context.Context, req *authtypes.QueryAccountInfoRequest, opts ...config.CallOpt) (*authtypes.QueryAccountInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryAccountInfoResponse, error) {
-		return client.Auth().AccountInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "AccountInfo", req, opts, func() (*authtypes.QueryAccountInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "AccountInfo", req, opts, func() (*authtypes.QueryAccountInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryAccountInfoResponse, error) {
+				return client.Auth().AccountInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) Accounts(ctx context.Context, req *authtypes.QueryAccountsRequest, opts ...config.CallOpt) (*authtypes.QueryAccountsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryAccountsResponse, error) {
-		return client.Auth().Accounts(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "Accounts", req, opts, func() (*authtypes.QueryAccountsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "Accounts", req, opts, func() (*authtypes.QueryAccountsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryAccountsResponse, error) {
+				return client.Auth().Accounts(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) AddressBytesToString(ctx context.Context, req *authtypes.AddressBytesToStringRequest, opts ...config.CallOpt) (*authtypes.AddressBytesToStringResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.AddressBytesToStringResponse, error) {
-		return client.Auth().AddressBytesToString(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "AddressBytesToString", req, opts, func() (*authtypes.AddressBytesToStringResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "AddressBytesToString", req, opts, func() (*authtypes.AddressBytesToStringResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.AddressBytesToStringResponse, error) {
+				return client.Auth().AddressBytesToString(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) AddressStringToBytes(ctx context.Context, req *authtypes.AddressStringToBytesRequest, opts ...config.CallOpt) (*authtypes.AddressStringToBytesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.AddressStringToBytesResponse, error) {
-		return client.Auth().AddressStringToBytes(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "AddressStringToBytes", req, opts, func() (*authtypes.AddressStringToBytesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "AddressStringToBytes", req, opts, func() (*authtypes.AddressStringToBytesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.AddressStringToBytesResponse, error) {
+				return client.Auth().AddressStringToBytes(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) Bech32Prefix(ctx context.Context, req *authtypes.Bech32PrefixRequest, opts ...config.CallOpt) (*authtypes.Bech32PrefixResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.Bech32PrefixResponse, error) {
-		return client.Auth().Bech32Prefix(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "Bech32Prefix", req, opts, func() (*authtypes.Bech32PrefixResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "Bech32Prefix", req, opts, func() (*authtypes.Bech32PrefixResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.Bech32PrefixResponse, error) {
+				return client.Auth().Bech32Prefix(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) ModuleAccountByName(ctx context.Context, req *authtypes.QueryModuleAccountByNameRequest, opts ...config.CallOpt) (*authtypes.QueryModuleAccountByNameResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryModuleAccountByNameResponse, error) {
-		return client.Auth().ModuleAccountByName(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "ModuleAccountByName", req, opts, func() (*authtypes.QueryModuleAccountByNameResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "ModuleAccountByName", req, opts, func() (*authtypes.QueryModuleAccountByNameResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryModuleAccountByNameResponse, error) {
+				return client.Auth().ModuleAccountByName(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) ModuleAccounts(ctx context.Context, req *authtypes.QueryModuleAccountsRequest, opts ...config.CallOpt) (*authtypes.QueryModuleAccountsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryModuleAccountsResponse, error) {
-		return client.Auth().ModuleAccounts(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "ModuleAccounts", req, opts, func() (*authtypes.QueryModuleAccountsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "ModuleAccounts", req, opts, func() (*authtypes.QueryModuleAccountsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryModuleAccountsResponse, error) {
+				return client.Auth().ModuleAccounts(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthClientWrapper) Params(ctx context.Context, req *authtypes.QueryParamsRequest, opts ...config.CallOpt) (*authtypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authtypes.QueryParamsResponse, error) {
-		return client.Auth().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "auth", "Params", req, opts, func() (*authtypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "auth", "Params", req, opts, func() (*authtypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authtypes.QueryParamsResponse, error) {
+				return client.Auth().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
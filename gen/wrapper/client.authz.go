@@ -6,39 +6,68 @@ import (
 	authz "github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// AuthzClientWrapper wraps the authz module with pool management and retry logic
+// AuthzClientWrapper wraps the authz module with pool management, retry logic, response caching and request coalescing
 type AuthzClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewAuthzClientWrapper creates a new authz client wrapper
-func NewAuthzClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *AuthzClientWrapper {
+func NewAuthzClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *AuthzClientWrapper {
 	return &AuthzClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "authz").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *AuthzClientWrapper) pinned(pin *pool.Pin) *AuthzClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *AuthzClientWrapper) GranteeGrants(ctx context.Context, req *authz.QueryGranteeGrantsRequest, opts ...config.CallOpt) (*authz.QueryGranteeGrantsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authz.QueryGranteeGrantsResponse, error) {
-		return client.Authz().GranteeGrants(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "authz", "GranteeGrants", req, opts, func() (*authz.QueryGranteeGrantsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "authz", "GranteeGrants", req, opts, func() (*authz.QueryGranteeGrantsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authz.QueryGranteeGrantsResponse, error) {
+				return client.Authz().GranteeGrants(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthzClientWrapper) GranterGrants(ctx context.Context, req *authz.QueryGranterGrantsRequest, opts ...config.CallOpt) (*authz.QueryGranterGrantsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authz.QueryGranterGrantsResponse, error) {
-		return client.Authz().GranterGrants(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "authz", "GranterGrants", req, opts, func() (*authz.QueryGranterGrantsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "authz", "GranterGrants", req, opts, func() (*authz.QueryGranterGrantsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authz.QueryGranterGrantsResponse, error) {
+				return client.Authz().GranterGrants(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *AuthzClientWrapper) Grants(ctx context.Context, req *authz.QueryGrantsRequest, opts ...config.CallOpt) (*authz.QueryGrantsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*authz.QueryGrantsResponse, error) {
-		return client.Authz().Grants(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "authz", "Grants", req, opts, func() (*authz.QueryGrantsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "authz", "Grants", req, opts, func() (*authz.QueryGrantsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*authz.QueryGrantsResponse, error) {
+				return client.Authz().Grants(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,99 +6,178 @@ import (
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// BankClientWrapper wraps the bank module with pool management and retry logic
+// BankClientWrapper wraps the bank module with pool management, retry logic, response caching and request coalescing
 type BankClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewBankClientWrapper creates a new bank client wrapper
-func NewBankClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *BankClientWrapper {
+func NewBankClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *BankClientWrapper {
 	return &BankClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "bank").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *BankClientWrapper) pinned(pin *pool.Pin) *BankClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *BankClientWrapper) AllBalances(ctx context.Context, req *banktypes.QueryAllBalancesRequest, opts ...config.CallOpt) (*banktypes.QueryAllBalancesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryAllBalancesResponse, error) {
-		return client.Bank().AllBalances(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "AllBalances", req, opts, func() (*banktypes.QueryAllBalancesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "AllBalances", req, opts, func() (*banktypes.QueryAllBalancesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryAllBalancesResponse, error) {
+				return client.Bank().AllBalances(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) Balance(ctx context.Context, req *banktypes.QueryBalanceRequest, opts ...config.CallOpt) (*banktypes.QueryBalanceResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryBalanceResponse, error) {
-		return client.Bank().Balance(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "Balance", req, opts, func() (*banktypes.QueryBalanceResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "Balance", req, opts, func() (*banktypes.QueryBalanceResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryBalanceResponse, error) {
+				return client.Bank().Balance(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) DenomMetadata(ctx context.Context, req *banktypes.QueryDenomMetadataRequest, opts ...config.CallOpt) (*banktypes.QueryDenomMetadataResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryDenomMetadataResponse, error) {
-		return client.Bank().DenomMetadata(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "DenomMetadata", req, opts, func() (*banktypes.QueryDenomMetadataResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "DenomMetadata", req, opts, func() (*banktypes.QueryDenomMetadataResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryDenomMetadataResponse, error) {
+				return client.Bank().DenomMetadata(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) DenomMetadataByQueryString(ctx context.Context, req *banktypes.QueryDenomMetadataByQueryStringRequest, opts ...config.CallOpt) (*banktypes.QueryDenomMetadataByQueryStringResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryDenomMetadataByQueryStringResponse, error) {
-		return client.Bank().DenomMetadataByQueryString(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "DenomMetadataByQueryString", req, opts, func() (*banktypes.QueryDenomMetadataByQueryStringResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "DenomMetadataByQueryString", req, opts, func() (*banktypes.QueryDenomMetadataByQueryStringResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryDenomMetadataByQueryStringResponse, error) {
+				return client.Bank().DenomMetadataByQueryString(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) DenomOwners(ctx context.Context, req *banktypes.QueryDenomOwnersRequest, opts ...config.CallOpt) (*banktypes.QueryDenomOwnersResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryDenomOwnersResponse, error) {
-		return client.Bank().DenomOwners(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "DenomOwners", req, opts, func() (*banktypes.QueryDenomOwnersResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "DenomOwners", req, opts, func() (*banktypes.QueryDenomOwnersResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryDenomOwnersResponse, error) {
+				return client.Bank().DenomOwners(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) DenomOwnersByQuery(ctx context.Context, req *banktypes.QueryDenomOwnersByQueryRequest, opts ...config.CallOpt) (*banktypes.QueryDenomOwnersByQueryResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryDenomOwnersByQueryResponse, error) {
-		return client.Bank().DenomOwnersByQuery(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "DenomOwnersByQuery", req, opts, func() (*banktypes.QueryDenomOwnersByQueryResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "DenomOwnersByQuery", req, opts, func() (*banktypes.QueryDenomOwnersByQueryResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryDenomOwnersByQueryResponse, error) {
+				return client.Bank().DenomOwnersByQuery(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) DenomsMetadata(ctx context.Context, req *banktypes.QueryDenomsMetadataRequest, opts ...config.CallOpt) (*banktypes.QueryDenomsMetadataResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryDenomsMetadataResponse, error) {
-		return client.Bank().DenomsMetadata(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "DenomsMetadata", req, opts, func() (*banktypes.QueryDenomsMetadataResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "DenomsMetadata", req, opts, func() (*banktypes.QueryDenomsMetadataResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryDenomsMetadataResponse, error) {
+				return client.Bank().DenomsMetadata(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) Params(ctx context.Context, req *banktypes.QueryParamsRequest, opts ...config.CallOpt) (*banktypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryParamsResponse, error) {
-		return client.Bank().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "Params", req, opts, func() (*banktypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "Params", req, opts, func() (*banktypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryParamsResponse, error) {
+				return client.Bank().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) SendEnabled(ctx context.Context, req *banktypes.QuerySendEnabledRequest, opts ...config.CallOpt) (*banktypes.QuerySendEnabledResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QuerySendEnabledResponse, error) {
-		return client.Bank().SendEnabled(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "SendEnabled", req, opts, func() (*banktypes.QuerySendEnabledResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "SendEnabled", req, opts, func() (*banktypes.QuerySendEnabledResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QuerySendEnabledResponse, error) {
+				return client.Bank().SendEnabled(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) SpendableBalanceByDenom(ctx context.Context, req *banktypes.QuerySpendableBalanceByDenomRequest, opts ...config.CallOpt) (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
-		return client.Bank().SpendableBalanceByDenom(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "SpendableBalanceByDenom", req, opts, func() (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "SpendableBalanceByDenom", req, opts, func() (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QuerySpendableBalanceByDenomResponse, error) {
+				return client.Bank().SpendableBalanceByDenom(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) SpendableBalances(ctx context.Context, req *banktypes.QuerySpendableBalancesRequest, opts ...config.CallOpt) (*banktypes.QuerySpendableBalancesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QuerySpendableBalancesResponse, error) {
-		return client.Bank().SpendableBalances(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "SpendableBalances", req, opts, func() (*banktypes.QuerySpendableBalancesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "SpendableBalances", req, opts, func() (*banktypes.QuerySpendableBalancesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QuerySpendableBalancesResponse, error) {
+				return client.Bank().SpendableBalances(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) SupplyOf(ctx context.Context, req *banktypes.QuerySupplyOfRequest, opts ...config.CallOpt) (*banktypes.QuerySupplyOfResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QuerySupplyOfResponse, error) {
-		return client.Bank().SupplyOf(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "SupplyOf", req, opts, func() (*banktypes.QuerySupplyOfResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "SupplyOf", req, opts, func() (*banktypes.QuerySupplyOfResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QuerySupplyOfResponse, error) {
+				return client.Bank().SupplyOf(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *BankClientWrapper) TotalSupply(ctx context.Context, req *banktypes.QueryTotalSupplyRequest, opts ...config.CallOpt) (*banktypes.QueryTotalSupplyResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*banktypes.QueryTotalSupplyResponse, error) {
-		return client.Bank().TotalSupply(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "bank", "TotalSupply", req, opts, func() (*banktypes.QueryTotalSupplyResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "bank", "TotalSupply", req, opts, func() (*banktypes.QueryTotalSupplyResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*banktypes.QueryTotalSupplyResponse, error) {
+				return client.Bank().TotalSupply(ctx, req, opts...)
+			})
+		})
 	})
 }
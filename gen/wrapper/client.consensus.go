@@ -6,27 +6,46 @@ import (
 	consensustypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// ConsensusClientWrapper wraps the consensus module with pool management and retry logic
+// ConsensusClientWrapper wraps the consensus module with pool management, retry logic, response caching and request coalescing
 type ConsensusClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewConsensusClientWrapper creates a new consensus client wrapper
-func NewConsensusClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *ConsensusClientWrapper {
+func NewConsensusClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *ConsensusClientWrapper {
 	return &ConsensusClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "consensus").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *ConsensusClientWrapper) pinned(pin *pool.Pin) *ConsensusClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *ConsensusClientWrapper) Params(ctx context.Context, req *consensustypes.QueryParamsRequest, opts ...config.CallOpt) (*consensustypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*consensustypes.QueryParamsResponse, error) {
-		return client.Consensus().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "consensus", "Params", req, opts, func() (*consensustypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "consensus", "Params", req, opts, func() (*consensustypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*consensustypes.QueryParamsResponse, error) {
+				return client.Consensus().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
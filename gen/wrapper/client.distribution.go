@@ -6,81 +6,145 @@ import (
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// DistributionClientWrapper wraps the distribution module with pool management and retry logic
+// DistributionClientWrapper wraps the distribution module with pool management, retry logic, response caching and request coalescing
 type DistributionClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewDistributionClientWrapper creates a new distribution client wrapper
-func NewDistributionClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *DistributionClientWrapper {
+func NewDistributionClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *DistributionClientWrapper {
 	return &DistributionClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "distribution").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *DistributionClientWrapper) pinned(pin *pool.Pin) *DistributionClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *DistributionClientWrapper) CommunityPool(ctx context.Context, req *distributiontypes.QueryCommunityPoolRequest, opts ...config.CallOpt) (*distributiontypes.QueryCommunityPoolResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryCommunityPoolResponse, error) {
-		return client.Distribution().CommunityPool(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "CommunityPool", req, opts, func() (*distributiontypes.QueryCommunityPoolResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "CommunityPool", req, opts, func() (*distributiontypes.QueryCommunityPoolResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryCommunityPoolResponse, error) {
+				return client.Distribution().CommunityPool(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) DelegationRewards(ctx context.Context, req *distributiontypes.QueryDelegationRewardsRequest, opts ...config.CallOpt) (*distributiontypes.QueryDelegationRewardsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryDelegationRewardsResponse, error) {
-		return client.Distribution().DelegationRewards(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "DelegationRewards", req, opts, func() (*distributiontypes.QueryDelegationRewardsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "DelegationRewards", req, opts, func() (*distributiontypes.QueryDelegationRewardsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryDelegationRewardsResponse, error) {
+				return client.Distribution().DelegationRewards(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) DelegationTotalRewards(ctx context.Context, req *distributiontypes.QueryDelegationTotalRewardsRequest, opts ...config.CallOpt) (*distributiontypes.QueryDelegationTotalRewardsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryDelegationTotalRewardsResponse, error) {
-		return client.Distribution().DelegationTotalRewards(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "DelegationTotalRewards", req, opts, func() (*distributiontypes.QueryDelegationTotalRewardsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "DelegationTotalRewards", req, opts, func() (*distributiontypes.QueryDelegationTotalRewardsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryDelegationTotalRewardsResponse, error) {
+				return client.Distribution().DelegationTotalRewards(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) DelegatorValidators(ctx context.Context, req *distributiontypes.QueryDelegatorValidatorsRequest, opts ...config.CallOpt) (*distributiontypes.QueryDelegatorValidatorsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryDelegatorValidatorsResponse, error) {
-		return client.Distribution().DelegatorValidators(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "DelegatorValidators", req, opts, func() (*distributiontypes.QueryDelegatorValidatorsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "DelegatorValidators", req, opts, func() (*distributiontypes.QueryDelegatorValidatorsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryDelegatorValidatorsResponse, error) {
+				return client.Distribution().DelegatorValidators(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) DelegatorWithdrawAddress(ctx context.Context, req *distributiontypes.QueryDelegatorWithdrawAddressRequest, opts ...config.CallOpt) (*distributiontypes.QueryDelegatorWithdrawAddressResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryDelegatorWithdrawAddressResponse, error) {
-		return client.Distribution().DelegatorWithdrawAddress(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "DelegatorWithdrawAddress", req, opts, func() (*distributiontypes.QueryDelegatorWithdrawAddressResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "DelegatorWithdrawAddress", req, opts, func() (*distributiontypes.QueryDelegatorWithdrawAddressResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryDelegatorWithdrawAddressResponse, error) {
+				return client.Distribution().DelegatorWithdrawAddress(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) Params(ctx context.Context, req *distributiontypes.QueryParamsRequest, opts ...config.CallOpt) (*distributiontypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryParamsResponse, error) {
-		return client.Distribution().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "Params", req, opts, func() (*distributiontypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "Params", req, opts, func() (*distributiontypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryParamsResponse, error) {
+				return client.Distribution().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) ValidatorCommission(ctx context.Context, req *distributiontypes.QueryValidatorCommissionRequest, opts ...config.CallOpt) (*distributiontypes.QueryValidatorCommissionResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryValidatorCommissionResponse, error) {
-		return client.Distribution().ValidatorCommission(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "ValidatorCommission", req, opts, func() (*distributiontypes.QueryValidatorCommissionResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "ValidatorCommission", req, opts, func() (*distributiontypes.QueryValidatorCommissionResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryValidatorCommissionResponse, error) {
+				return client.Distribution().ValidatorCommission(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) ValidatorDistributionInfo(ctx context.Context, req *distributiontypes.QueryValidatorDistributionInfoRequest, opts ...config.CallOpt) (*distributiontypes.QueryValidatorDistributionInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryValidatorDistributionInfoResponse, error) {
-		return client.Distribution().ValidatorDistributionInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "ValidatorDistributionInfo", req, opts, func() (*distributiontypes.QueryValidatorDistributionInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "ValidatorDistributionInfo", req, opts, func() (*distributiontypes.QueryValidatorDistributionInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryValidatorDistributionInfoResponse, error) {
+				return client.Distribution().ValidatorDistributionInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) ValidatorOutstandingRewards(ctx context.Context, req *distributiontypes.QueryValidatorOutstandingRewardsRequest, opts ...config.CallOpt) (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
-		return client.Distribution().ValidatorOutstandingRewards(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "ValidatorOutstandingRewards", req, opts, func() (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "ValidatorOutstandingRewards", req, opts, func() (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
+				return client.Distribution().ValidatorOutstandingRewards(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *DistributionClientWrapper) ValidatorSlashes(ctx context.Context, req *distributiontypes.QueryValidatorSlashesRequest, opts ...config.CallOpt) (*distributiontypes.QueryValidatorSlashesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*distributiontypes.QueryValidatorSlashesResponse, error) {
-		return client.Distribution().ValidatorSlashes(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "distribution", "ValidatorSlashes", req, opts, func() (*distributiontypes.QueryValidatorSlashesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "distribution", "ValidatorSlashes", req, opts, func() (*distributiontypes.QueryValidatorSlashesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*distributiontypes.QueryValidatorSlashesResponse, error) {
+				return client.Distribution().ValidatorSlashes(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,663 +6,1212 @@ import (
 	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// EmissionsClientWrapper wraps the emissions module with pool management and retry logic
+// EmissionsClientWrapper wraps the emissions module with pool management, retry logic, response caching and request coalescing
 type EmissionsClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewEmissionsClientWrapper creates a new emissions client wrapper
-func NewEmissionsClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *EmissionsClientWrapper {
+func NewEmissionsClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *EmissionsClientWrapper {
 	return &EmissionsClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "emissions").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *EmissionsClientWrapper) pinned(pin *pool.Pin) *EmissionsClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *EmissionsClientWrapper) CanCreateTopic(ctx context.Context, req *emissionstypes.CanCreateTopicRequest, opts ...config.CallOpt) (*emissionstypes.CanCreateTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanCreateTopicResponse, error) {
-		return client.Emissions().CanCreateTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanCreateTopic", req, opts, func() (*emissionstypes.CanCreateTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanCreateTopic", req, opts, func() (*emissionstypes.CanCreateTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanCreateTopicResponse, error) {
+				return client.Emissions().CanCreateTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanSubmitReputerPayload(ctx context.Context, req *emissionstypes.CanSubmitReputerPayloadRequest, opts ...config.CallOpt) (*emissionstypes.CanSubmitReputerPayloadResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanSubmitReputerPayloadResponse, error) {
-		return client.Emissions().CanSubmitReputerPayload(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanSubmitReputerPayload", req, opts, func() (*emissionstypes.CanSubmitReputerPayloadResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanSubmitReputerPayload", req, opts, func() (*emissionstypes.CanSubmitReputerPayloadResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanSubmitReputerPayloadResponse, error) {
+				return client.Emissions().CanSubmitReputerPayload(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanSubmitWorkerPayload(ctx context.Context, req *emissionstypes.CanSubmitWorkerPayloadRequest, opts ...config.CallOpt) (*emissionstypes.CanSubmitWorkerPayloadResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanSubmitWorkerPayloadResponse, error) {
-		return client.Emissions().CanSubmitWorkerPayload(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanSubmitWorkerPayload", req, opts, func() (*emissionstypes.CanSubmitWorkerPayloadResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanSubmitWorkerPayload", req, opts, func() (*emissionstypes.CanSubmitWorkerPayloadResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanSubmitWorkerPayloadResponse, error) {
+				return client.Emissions().CanSubmitWorkerPayload(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanUpdateAllGlobalWhitelists(ctx context.Context, req *emissionstypes.CanUpdateAllGlobalWhitelistsRequest, opts ...config.CallOpt) (*emissionstypes.CanUpdateAllGlobalWhitelistsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanUpdateAllGlobalWhitelistsResponse, error) {
-		return client.Emissions().CanUpdateAllGlobalWhitelists(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanUpdateAllGlobalWhitelists", req, opts, func() (*emissionstypes.CanUpdateAllGlobalWhitelistsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanUpdateAllGlobalWhitelists", req, opts, func() (*emissionstypes.CanUpdateAllGlobalWhitelistsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanUpdateAllGlobalWhitelistsResponse, error) {
+				return client.Emissions().CanUpdateAllGlobalWhitelists(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanUpdateGlobalReputerWhitelist(ctx context.Context, req *emissionstypes.CanUpdateGlobalReputerWhitelistRequest, opts ...config.CallOpt) (*emissionstypes.CanUpdateGlobalReputerWhitelistResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanUpdateGlobalReputerWhitelistResponse, error) {
-		return client.Emissions().CanUpdateGlobalReputerWhitelist(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanUpdateGlobalReputerWhitelist", req, opts, func() (*emissionstypes.CanUpdateGlobalReputerWhitelistResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanUpdateGlobalReputerWhitelist", req, opts, func() (*emissionstypes.CanUpdateGlobalReputerWhitelistResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanUpdateGlobalReputerWhitelistResponse, error) {
+				return client.Emissions().CanUpdateGlobalReputerWhitelist(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanUpdateGlobalWorkerWhitelist(ctx context.Context, req *emissionstypes.CanUpdateGlobalWorkerWhitelistRequest, opts ...config.CallOpt) (*emissionstypes.CanUpdateGlobalWorkerWhitelistResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanUpdateGlobalWorkerWhitelistResponse, error) {
-		return client.Emissions().CanUpdateGlobalWorkerWhitelist(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanUpdateGlobalWorkerWhitelist", req, opts, func() (*emissionstypes.CanUpdateGlobalWorkerWhitelistResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanUpdateGlobalWorkerWhitelist", req, opts, func() (*emissionstypes.CanUpdateGlobalWorkerWhitelistResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanUpdateGlobalWorkerWhitelistResponse, error) {
+				return client.Emissions().CanUpdateGlobalWorkerWhitelist(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanUpdateParams(ctx context.Context, req *emissionstypes.CanUpdateParamsRequest, opts ...config.CallOpt) (*emissionstypes.CanUpdateParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanUpdateParamsResponse, error) {
-		return client.Emissions().CanUpdateParams(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanUpdateParams", req, opts, func() (*emissionstypes.CanUpdateParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanUpdateParams", req, opts, func() (*emissionstypes.CanUpdateParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanUpdateParamsResponse, error) {
+				return client.Emissions().CanUpdateParams(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) CanUpdateTopicWhitelist(ctx context.Context, req *emissionstypes.CanUpdateTopicWhitelistRequest, opts ...config.CallOpt) (*emissionstypes.CanUpdateTopicWhitelistResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.CanUpdateTopicWhitelistResponse, error) {
-		return client.Emissions().CanUpdateTopicWhitelist(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "CanUpdateTopicWhitelist", req, opts, func() (*emissionstypes.CanUpdateTopicWhitelistResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "CanUpdateTopicWhitelist", req, opts, func() (*emissionstypes.CanUpdateTopicWhitelistResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.CanUpdateTopicWhitelistResponse, error) {
+				return client.Emissions().CanUpdateTopicWhitelist(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetActiveTopicsAtBlock(ctx context.Context, req *emissionstypes.GetActiveTopicsAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetActiveTopicsAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetActiveTopicsAtBlockResponse, error) {
-		return client.Emissions().GetActiveTopicsAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetActiveTopicsAtBlock", req, opts, func() (*emissionstypes.GetActiveTopicsAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetActiveTopicsAtBlock", req, opts, func() (*emissionstypes.GetActiveTopicsAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetActiveTopicsAtBlockResponse, error) {
+				return client.Emissions().GetActiveTopicsAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetCountForecasterInclusionsInTopic(ctx context.Context, req *emissionstypes.GetCountForecasterInclusionsInTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetCountForecasterInclusionsInTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetCountForecasterInclusionsInTopicResponse, error) {
-		return client.Emissions().GetCountForecasterInclusionsInTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetCountForecasterInclusionsInTopic", req, opts, func() (*emissionstypes.GetCountForecasterInclusionsInTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetCountForecasterInclusionsInTopic", req, opts, func() (*emissionstypes.GetCountForecasterInclusionsInTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetCountForecasterInclusionsInTopicResponse, error) {
+				return client.Emissions().GetCountForecasterInclusionsInTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetCountInfererInclusionsInTopic(ctx context.Context, req *emissionstypes.GetCountInfererInclusionsInTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetCountInfererInclusionsInTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetCountInfererInclusionsInTopicResponse, error) {
-		return client.Emissions().GetCountInfererInclusionsInTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetCountInfererInclusionsInTopic", req, opts, func() (*emissionstypes.GetCountInfererInclusionsInTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetCountInfererInclusionsInTopic", req, opts, func() (*emissionstypes.GetCountInfererInclusionsInTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetCountInfererInclusionsInTopicResponse, error) {
+				return client.Emissions().GetCountInfererInclusionsInTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetCurrentLowestForecasterScore(ctx context.Context, req *emissionstypes.GetCurrentLowestForecasterScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetCurrentLowestForecasterScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestForecasterScoreResponse, error) {
-		return client.Emissions().GetCurrentLowestForecasterScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetCurrentLowestForecasterScore", req, opts, func() (*emissionstypes.GetCurrentLowestForecasterScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetCurrentLowestForecasterScore", req, opts, func() (*emissionstypes.GetCurrentLowestForecasterScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestForecasterScoreResponse, error) {
+				return client.Emissions().GetCurrentLowestForecasterScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetCurrentLowestInfererScore(ctx context.Context, req *emissionstypes.GetCurrentLowestInfererScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetCurrentLowestInfererScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestInfererScoreResponse, error) {
-		return client.Emissions().GetCurrentLowestInfererScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetCurrentLowestInfererScore", req, opts, func() (*emissionstypes.GetCurrentLowestInfererScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetCurrentLowestInfererScore", req, opts, func() (*emissionstypes.GetCurrentLowestInfererScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestInfererScoreResponse, error) {
+				return client.Emissions().GetCurrentLowestInfererScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetCurrentLowestReputerScore(ctx context.Context, req *emissionstypes.GetCurrentLowestReputerScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetCurrentLowestReputerScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestReputerScoreResponse, error) {
-		return client.Emissions().GetCurrentLowestReputerScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetCurrentLowestReputerScore", req, opts, func() (*emissionstypes.GetCurrentLowestReputerScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetCurrentLowestReputerScore", req, opts, func() (*emissionstypes.GetCurrentLowestReputerScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetCurrentLowestReputerScoreResponse, error) {
+				return client.Emissions().GetCurrentLowestReputerScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateRewardPerShare(ctx context.Context, req *emissionstypes.GetDelegateRewardPerShareRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateRewardPerShareResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateRewardPerShareResponse, error) {
-		return client.Emissions().GetDelegateRewardPerShare(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateRewardPerShare", req, opts, func() (*emissionstypes.GetDelegateRewardPerShareResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateRewardPerShare", req, opts, func() (*emissionstypes.GetDelegateRewardPerShareResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateRewardPerShareResponse, error) {
+				return client.Emissions().GetDelegateRewardPerShare(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakeInTopicInReputer(ctx context.Context, req *emissionstypes.GetDelegateStakeInTopicInReputerRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakeInTopicInReputerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeInTopicInReputerResponse, error) {
-		return client.Emissions().GetDelegateStakeInTopicInReputer(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakeInTopicInReputer", req, opts, func() (*emissionstypes.GetDelegateStakeInTopicInReputerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakeInTopicInReputer", req, opts, func() (*emissionstypes.GetDelegateStakeInTopicInReputerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeInTopicInReputerResponse, error) {
+				return client.Emissions().GetDelegateStakeInTopicInReputer(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakePlacement(ctx context.Context, req *emissionstypes.GetDelegateStakePlacementRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakePlacementResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakePlacementResponse, error) {
-		return client.Emissions().GetDelegateStakePlacement(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakePlacement", req, opts, func() (*emissionstypes.GetDelegateStakePlacementResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakePlacement", req, opts, func() (*emissionstypes.GetDelegateStakePlacementResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakePlacementResponse, error) {
+				return client.Emissions().GetDelegateStakePlacement(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakeRemoval(ctx context.Context, req *emissionstypes.GetDelegateStakeRemovalRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakeRemovalResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalResponse, error) {
-		return client.Emissions().GetDelegateStakeRemoval(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakeRemoval", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakeRemoval", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalResponse, error) {
+				return client.Emissions().GetDelegateStakeRemoval(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakeRemovalInfo(ctx context.Context, req *emissionstypes.GetDelegateStakeRemovalInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakeRemovalInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalInfoResponse, error) {
-		return client.Emissions().GetDelegateStakeRemovalInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakeRemovalInfo", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakeRemovalInfo", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalInfoResponse, error) {
+				return client.Emissions().GetDelegateStakeRemovalInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakeRemovalsUpUntilBlock(ctx context.Context, req *emissionstypes.GetDelegateStakeRemovalsUpUntilBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakeRemovalsUpUntilBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalsUpUntilBlockResponse, error) {
-		return client.Emissions().GetDelegateStakeRemovalsUpUntilBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakeRemovalsUpUntilBlock", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalsUpUntilBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakeRemovalsUpUntilBlock", req, opts, func() (*emissionstypes.GetDelegateStakeRemovalsUpUntilBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeRemovalsUpUntilBlockResponse, error) {
+				return client.Emissions().GetDelegateStakeRemovalsUpUntilBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetDelegateStakeUponReputer(ctx context.Context, req *emissionstypes.GetDelegateStakeUponReputerRequest, opts ...config.CallOpt) (*emissionstypes.GetDelegateStakeUponReputerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeUponReputerResponse, error) {
-		return client.Emissions().GetDelegateStakeUponReputer(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetDelegateStakeUponReputer", req, opts, func() (*emissionstypes.GetDelegateStakeUponReputerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetDelegateStakeUponReputer", req, opts, func() (*emissionstypes.GetDelegateStakeUponReputerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetDelegateStakeUponReputerResponse, error) {
+				return client.Emissions().GetDelegateStakeUponReputer(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetForecastScoresUntilBlock(ctx context.Context, req *emissionstypes.GetForecastScoresUntilBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetForecastScoresUntilBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetForecastScoresUntilBlockResponse, error) {
-		return client.Emissions().GetForecastScoresUntilBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetForecastScoresUntilBlock", req, opts, func() (*emissionstypes.GetForecastScoresUntilBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetForecastScoresUntilBlock", req, opts, func() (*emissionstypes.GetForecastScoresUntilBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetForecastScoresUntilBlockResponse, error) {
+				return client.Emissions().GetForecastScoresUntilBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetForecasterNetworkRegret(ctx context.Context, req *emissionstypes.GetForecasterNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetForecasterNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetForecasterNetworkRegretResponse, error) {
-		return client.Emissions().GetForecasterNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetForecasterNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetForecasterNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetForecasterNetworkRegretResponse, error) {
+				return client.Emissions().GetForecasterNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetForecasterScoreEma(ctx context.Context, req *emissionstypes.GetForecasterScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetForecasterScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetForecasterScoreEmaResponse, error) {
-		return client.Emissions().GetForecasterScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetForecasterScoreEma", req, opts, func() (*emissionstypes.GetForecasterScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetForecasterScoreEma", req, opts, func() (*emissionstypes.GetForecasterScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetForecasterScoreEmaResponse, error) {
+				return client.Emissions().GetForecasterScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetForecastsAtBlock(ctx context.Context, req *emissionstypes.GetForecastsAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetForecastsAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetForecastsAtBlockResponse, error) {
-		return client.Emissions().GetForecastsAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetForecastsAtBlock", req, opts, func() (*emissionstypes.GetForecastsAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetForecastsAtBlock", req, opts, func() (*emissionstypes.GetForecastsAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetForecastsAtBlockResponse, error) {
+				return client.Emissions().GetForecastsAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetInferenceScoresUntilBlock(ctx context.Context, req *emissionstypes.GetInferenceScoresUntilBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetInferenceScoresUntilBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetInferenceScoresUntilBlockResponse, error) {
-		return client.Emissions().GetInferenceScoresUntilBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetInferenceScoresUntilBlock", req, opts, func() (*emissionstypes.GetInferenceScoresUntilBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetInferenceScoresUntilBlock", req, opts, func() (*emissionstypes.GetInferenceScoresUntilBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetInferenceScoresUntilBlockResponse, error) {
+				return client.Emissions().GetInferenceScoresUntilBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetInferencesAtBlock(ctx context.Context, req *emissionstypes.GetInferencesAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetInferencesAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetInferencesAtBlockResponse, error) {
-		return client.Emissions().GetInferencesAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetInferencesAtBlock", req, opts, func() (*emissionstypes.GetInferencesAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetInferencesAtBlock", req, opts, func() (*emissionstypes.GetInferencesAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetInferencesAtBlockResponse, error) {
+				return client.Emissions().GetInferencesAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetInfererNetworkRegret(ctx context.Context, req *emissionstypes.GetInfererNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetInfererNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetInfererNetworkRegretResponse, error) {
-		return client.Emissions().GetInfererNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetInfererNetworkRegret", req, opts, func() (*emissionstypes.GetInfererNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetInfererNetworkRegret", req, opts, func() (*emissionstypes.GetInfererNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetInfererNetworkRegretResponse, error) {
+				return client.Emissions().GetInfererNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetInfererScoreEma(ctx context.Context, req *emissionstypes.GetInfererScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetInfererScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetInfererScoreEmaResponse, error) {
-		return client.Emissions().GetInfererScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetInfererScoreEma", req, opts, func() (*emissionstypes.GetInfererScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetInfererScoreEma", req, opts, func() (*emissionstypes.GetInfererScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetInfererScoreEmaResponse, error) {
+				return client.Emissions().GetInfererScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestForecasterWeight(ctx context.Context, req *emissionstypes.GetLatestForecasterWeightRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestForecasterWeightResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestForecasterWeightResponse, error) {
-		return client.Emissions().GetLatestForecasterWeight(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestForecasterWeight", req, opts, func() (*emissionstypes.GetLatestForecasterWeightResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestForecasterWeight", req, opts, func() (*emissionstypes.GetLatestForecasterWeightResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestForecasterWeightResponse, error) {
+				return client.Emissions().GetLatestForecasterWeight(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestInfererWeight(ctx context.Context, req *emissionstypes.GetLatestInfererWeightRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestInfererWeightResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestInfererWeightResponse, error) {
-		return client.Emissions().GetLatestInfererWeight(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestInfererWeight", req, opts, func() (*emissionstypes.GetLatestInfererWeightResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestInfererWeight", req, opts, func() (*emissionstypes.GetLatestInfererWeightResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestInfererWeightResponse, error) {
+				return client.Emissions().GetLatestInfererWeight(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestNetworkInferences(ctx context.Context, req *emissionstypes.GetLatestNetworkInferencesRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
-		return client.Emissions().GetLatestNetworkInferences(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestNetworkInferences", req, opts, func() (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestNetworkInferences", req, opts, func() (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
+				return client.Emissions().GetLatestNetworkInferences(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestNetworkInferencesOutlierResistant(ctx context.Context, req *emissionstypes.GetLatestNetworkInferencesOutlierResistantRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestNetworkInferencesOutlierResistantResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestNetworkInferencesOutlierResistantResponse, error) {
-		return client.Emissions().GetLatestNetworkInferencesOutlierResistant(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestNetworkInferencesOutlierResistant", req, opts, func() (*emissionstypes.GetLatestNetworkInferencesOutlierResistantResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestNetworkInferencesOutlierResistant", req, opts, func() (*emissionstypes.GetLatestNetworkInferencesOutlierResistantResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestNetworkInferencesOutlierResistantResponse, error) {
+				return client.Emissions().GetLatestNetworkInferencesOutlierResistant(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestRegretStdNorm(ctx context.Context, req *emissionstypes.GetLatestRegretStdNormRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestRegretStdNormResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestRegretStdNormResponse, error) {
-		return client.Emissions().GetLatestRegretStdNorm(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestRegretStdNorm", req, opts, func() (*emissionstypes.GetLatestRegretStdNormResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestRegretStdNorm", req, opts, func() (*emissionstypes.GetLatestRegretStdNormResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestRegretStdNormResponse, error) {
+				return client.Emissions().GetLatestRegretStdNorm(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetLatestTopicInferences(ctx context.Context, req *emissionstypes.GetLatestTopicInferencesRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestTopicInferencesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetLatestTopicInferencesResponse, error) {
-		return client.Emissions().GetLatestTopicInferences(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetLatestTopicInferences", req, opts, func() (*emissionstypes.GetLatestTopicInferencesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetLatestTopicInferences", req, opts, func() (*emissionstypes.GetLatestTopicInferencesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetLatestTopicInferencesResponse, error) {
+				return client.Emissions().GetLatestTopicInferences(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetListeningCoefficient(ctx context.Context, req *emissionstypes.GetListeningCoefficientRequest, opts ...config.CallOpt) (*emissionstypes.GetListeningCoefficientResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetListeningCoefficientResponse, error) {
-		return client.Emissions().GetListeningCoefficient(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetListeningCoefficient", req, opts, func() (*emissionstypes.GetListeningCoefficientResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetListeningCoefficient", req, opts, func() (*emissionstypes.GetListeningCoefficientResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetListeningCoefficientResponse, error) {
+				return client.Emissions().GetListeningCoefficient(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetMultiReputerStakeInTopic(ctx context.Context, req *emissionstypes.GetMultiReputerStakeInTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetMultiReputerStakeInTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetMultiReputerStakeInTopicResponse, error) {
-		return client.Emissions().GetMultiReputerStakeInTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetMultiReputerStakeInTopic", req, opts, func() (*emissionstypes.GetMultiReputerStakeInTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetMultiReputerStakeInTopic", req, opts, func() (*emissionstypes.GetMultiReputerStakeInTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetMultiReputerStakeInTopicResponse, error) {
+				return client.Emissions().GetMultiReputerStakeInTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNaiveInfererNetworkRegret(ctx context.Context, req *emissionstypes.GetNaiveInfererNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetNaiveInfererNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNaiveInfererNetworkRegretResponse, error) {
-		return client.Emissions().GetNaiveInfererNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNaiveInfererNetworkRegret", req, opts, func() (*emissionstypes.GetNaiveInfererNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNaiveInfererNetworkRegret", req, opts, func() (*emissionstypes.GetNaiveInfererNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNaiveInfererNetworkRegretResponse, error) {
+				return client.Emissions().GetNaiveInfererNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNetworkInferencesAtBlock(ctx context.Context, req *emissionstypes.GetNetworkInferencesAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetNetworkInferencesAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNetworkInferencesAtBlockResponse, error) {
-		return client.Emissions().GetNetworkInferencesAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNetworkInferencesAtBlock", req, opts, func() (*emissionstypes.GetNetworkInferencesAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNetworkInferencesAtBlock", req, opts, func() (*emissionstypes.GetNetworkInferencesAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNetworkInferencesAtBlockResponse, error) {
+				return client.Emissions().GetNetworkInferencesAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNetworkInferencesAtBlockOutlierResistant(ctx context.Context, req *emissionstypes.GetNetworkInferencesAtBlockOutlierResistantRequest, opts ...config.CallOpt) (*emissionstypes.GetNetworkInferencesAtBlockOutlierResistantResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNetworkInferencesAtBlockOutlierResistantResponse, error) {
-		return client.Emissions().GetNetworkInferencesAtBlockOutlierResistant(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNetworkInferencesAtBlockOutlierResistant", req, opts, func() (*emissionstypes.GetNetworkInferencesAtBlockOutlierResistantResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNetworkInferencesAtBlockOutlierResistant", req, opts, func() (*emissionstypes.GetNetworkInferencesAtBlockOutlierResistantResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNetworkInferencesAtBlockOutlierResistantResponse, error) {
+				return client.Emissions().GetNetworkInferencesAtBlockOutlierResistant(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNetworkLossBundleAtBlock(ctx context.Context, req *emissionstypes.GetNetworkLossBundleAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetNetworkLossBundleAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNetworkLossBundleAtBlockResponse, error) {
-		return client.Emissions().GetNetworkLossBundleAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNetworkLossBundleAtBlock", req, opts, func() (*emissionstypes.GetNetworkLossBundleAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNetworkLossBundleAtBlock", req, opts, func() (*emissionstypes.GetNetworkLossBundleAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNetworkLossBundleAtBlockResponse, error) {
+				return client.Emissions().GetNetworkLossBundleAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNextChurningBlockByTopicId(ctx context.Context, req *emissionstypes.GetNextChurningBlockByTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.GetNextChurningBlockByTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNextChurningBlockByTopicIdResponse, error) {
-		return client.Emissions().GetNextChurningBlockByTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNextChurningBlockByTopicId", req, opts, func() (*emissionstypes.GetNextChurningBlockByTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNextChurningBlockByTopicId", req, opts, func() (*emissionstypes.GetNextChurningBlockByTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNextChurningBlockByTopicIdResponse, error) {
+				return client.Emissions().GetNextChurningBlockByTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetNextTopicId(ctx context.Context, req *emissionstypes.GetNextTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.GetNextTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetNextTopicIdResponse, error) {
-		return client.Emissions().GetNextTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetNextTopicId", req, opts, func() (*emissionstypes.GetNextTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetNextTopicId", req, opts, func() (*emissionstypes.GetNextTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetNextTopicIdResponse, error) {
+				return client.Emissions().GetNextTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOneInForecasterNetworkRegret(ctx context.Context, req *emissionstypes.GetOneInForecasterNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetOneInForecasterNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOneInForecasterNetworkRegretResponse, error) {
-		return client.Emissions().GetOneInForecasterNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOneInForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneInForecasterNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOneInForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneInForecasterNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOneInForecasterNetworkRegretResponse, error) {
+				return client.Emissions().GetOneInForecasterNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOneOutForecasterForecasterNetworkRegret(ctx context.Context, req *emissionstypes.GetOneOutForecasterForecasterNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetOneOutForecasterForecasterNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOneOutForecasterForecasterNetworkRegretResponse, error) {
-		return client.Emissions().GetOneOutForecasterForecasterNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOneOutForecasterForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutForecasterForecasterNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOneOutForecasterForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutForecasterForecasterNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOneOutForecasterForecasterNetworkRegretResponse, error) {
+				return client.Emissions().GetOneOutForecasterForecasterNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOneOutForecasterInfererNetworkRegret(ctx context.Context, req *emissionstypes.GetOneOutForecasterInfererNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetOneOutForecasterInfererNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOneOutForecasterInfererNetworkRegretResponse, error) {
-		return client.Emissions().GetOneOutForecasterInfererNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOneOutForecasterInfererNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutForecasterInfererNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOneOutForecasterInfererNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutForecasterInfererNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOneOutForecasterInfererNetworkRegretResponse, error) {
+				return client.Emissions().GetOneOutForecasterInfererNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOneOutInfererForecasterNetworkRegret(ctx context.Context, req *emissionstypes.GetOneOutInfererForecasterNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetOneOutInfererForecasterNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOneOutInfererForecasterNetworkRegretResponse, error) {
-		return client.Emissions().GetOneOutInfererForecasterNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOneOutInfererForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutInfererForecasterNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOneOutInfererForecasterNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutInfererForecasterNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOneOutInfererForecasterNetworkRegretResponse, error) {
+				return client.Emissions().GetOneOutInfererForecasterNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOneOutInfererInfererNetworkRegret(ctx context.Context, req *emissionstypes.GetOneOutInfererInfererNetworkRegretRequest, opts ...config.CallOpt) (*emissionstypes.GetOneOutInfererInfererNetworkRegretResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOneOutInfererInfererNetworkRegretResponse, error) {
-		return client.Emissions().GetOneOutInfererInfererNetworkRegret(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOneOutInfererInfererNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutInfererInfererNetworkRegretResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOneOutInfererInfererNetworkRegret", req, opts, func() (*emissionstypes.GetOneOutInfererInfererNetworkRegretResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOneOutInfererInfererNetworkRegretResponse, error) {
+				return client.Emissions().GetOneOutInfererInfererNetworkRegret(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOpenReputerSubmissionWindows(ctx context.Context, req *emissionstypes.GetOpenReputerSubmissionWindowsRequest, opts ...config.CallOpt) (*emissionstypes.GetOpenReputerSubmissionWindowsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOpenReputerSubmissionWindowsResponse, error) {
-		return client.Emissions().GetOpenReputerSubmissionWindows(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOpenReputerSubmissionWindows", req, opts, func() (*emissionstypes.GetOpenReputerSubmissionWindowsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOpenReputerSubmissionWindows", req, opts, func() (*emissionstypes.GetOpenReputerSubmissionWindowsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOpenReputerSubmissionWindowsResponse, error) {
+				return client.Emissions().GetOpenReputerSubmissionWindows(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetOpenWorkerSubmissionWindows(ctx context.Context, req *emissionstypes.GetOpenWorkerSubmissionWindowsRequest, opts ...config.CallOpt) (*emissionstypes.GetOpenWorkerSubmissionWindowsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetOpenWorkerSubmissionWindowsResponse, error) {
-		return client.Emissions().GetOpenWorkerSubmissionWindows(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetOpenWorkerSubmissionWindows", req, opts, func() (*emissionstypes.GetOpenWorkerSubmissionWindowsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetOpenWorkerSubmissionWindows", req, opts, func() (*emissionstypes.GetOpenWorkerSubmissionWindowsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetOpenWorkerSubmissionWindowsResponse, error) {
+				return client.Emissions().GetOpenWorkerSubmissionWindows(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetParams(ctx context.Context, req *emissionstypes.GetParamsRequest, opts ...config.CallOpt) (*emissionstypes.GetParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetParamsResponse, error) {
-		return client.Emissions().GetParams(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetParams", req, opts, func() (*emissionstypes.GetParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetParams", req, opts, func() (*emissionstypes.GetParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetParamsResponse, error) {
+				return client.Emissions().GetParams(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousForecastRewardFraction(ctx context.Context, req *emissionstypes.GetPreviousForecastRewardFractionRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousForecastRewardFractionResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousForecastRewardFractionResponse, error) {
-		return client.Emissions().GetPreviousForecastRewardFraction(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousForecastRewardFraction", req, opts, func() (*emissionstypes.GetPreviousForecastRewardFractionResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousForecastRewardFraction", req, opts, func() (*emissionstypes.GetPreviousForecastRewardFractionResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousForecastRewardFractionResponse, error) {
+				return client.Emissions().GetPreviousForecastRewardFraction(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousInferenceRewardFraction(ctx context.Context, req *emissionstypes.GetPreviousInferenceRewardFractionRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousInferenceRewardFractionResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousInferenceRewardFractionResponse, error) {
-		return client.Emissions().GetPreviousInferenceRewardFraction(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousInferenceRewardFraction", req, opts, func() (*emissionstypes.GetPreviousInferenceRewardFractionResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousInferenceRewardFraction", req, opts, func() (*emissionstypes.GetPreviousInferenceRewardFractionResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousInferenceRewardFractionResponse, error) {
+				return client.Emissions().GetPreviousInferenceRewardFraction(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousPercentageRewardToStakedReputers(ctx context.Context, req *emissionstypes.GetPreviousPercentageRewardToStakedReputersRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousPercentageRewardToStakedReputersResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousPercentageRewardToStakedReputersResponse, error) {
-		return client.Emissions().GetPreviousPercentageRewardToStakedReputers(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousPercentageRewardToStakedReputers", req, opts, func() (*emissionstypes.GetPreviousPercentageRewardToStakedReputersResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousPercentageRewardToStakedReputers", req, opts, func() (*emissionstypes.GetPreviousPercentageRewardToStakedReputersResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousPercentageRewardToStakedReputersResponse, error) {
+				return client.Emissions().GetPreviousPercentageRewardToStakedReputers(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousReputerRewardFraction(ctx context.Context, req *emissionstypes.GetPreviousReputerRewardFractionRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousReputerRewardFractionResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousReputerRewardFractionResponse, error) {
-		return client.Emissions().GetPreviousReputerRewardFraction(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousReputerRewardFraction", req, opts, func() (*emissionstypes.GetPreviousReputerRewardFractionResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousReputerRewardFraction", req, opts, func() (*emissionstypes.GetPreviousReputerRewardFractionResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousReputerRewardFractionResponse, error) {
+				return client.Emissions().GetPreviousReputerRewardFraction(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousTopicQuantileForecasterScoreEma(ctx context.Context, req *emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaResponse, error) {
-		return client.Emissions().GetPreviousTopicQuantileForecasterScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousTopicQuantileForecasterScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousTopicQuantileForecasterScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileForecasterScoreEmaResponse, error) {
+				return client.Emissions().GetPreviousTopicQuantileForecasterScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousTopicQuantileInfererScoreEma(ctx context.Context, req *emissionstypes.GetPreviousTopicQuantileInfererScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousTopicQuantileInfererScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileInfererScoreEmaResponse, error) {
-		return client.Emissions().GetPreviousTopicQuantileInfererScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousTopicQuantileInfererScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileInfererScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousTopicQuantileInfererScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileInfererScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileInfererScoreEmaResponse, error) {
+				return client.Emissions().GetPreviousTopicQuantileInfererScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousTopicQuantileReputerScoreEma(ctx context.Context, req *emissionstypes.GetPreviousTopicQuantileReputerScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousTopicQuantileReputerScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileReputerScoreEmaResponse, error) {
-		return client.Emissions().GetPreviousTopicQuantileReputerScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousTopicQuantileReputerScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileReputerScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousTopicQuantileReputerScoreEma", req, opts, func() (*emissionstypes.GetPreviousTopicQuantileReputerScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicQuantileReputerScoreEmaResponse, error) {
+				return client.Emissions().GetPreviousTopicQuantileReputerScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetPreviousTopicWeight(ctx context.Context, req *emissionstypes.GetPreviousTopicWeightRequest, opts ...config.CallOpt) (*emissionstypes.GetPreviousTopicWeightResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicWeightResponse, error) {
-		return client.Emissions().GetPreviousTopicWeight(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetPreviousTopicWeight", req, opts, func() (*emissionstypes.GetPreviousTopicWeightResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetPreviousTopicWeight", req, opts, func() (*emissionstypes.GetPreviousTopicWeightResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetPreviousTopicWeightResponse, error) {
+				return client.Emissions().GetPreviousTopicWeight(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputerLossBundlesAtBlock(ctx context.Context, req *emissionstypes.GetReputerLossBundlesAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetReputerLossBundlesAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputerLossBundlesAtBlockResponse, error) {
-		return client.Emissions().GetReputerLossBundlesAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputerLossBundlesAtBlock", req, opts, func() (*emissionstypes.GetReputerLossBundlesAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputerLossBundlesAtBlock", req, opts, func() (*emissionstypes.GetReputerLossBundlesAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputerLossBundlesAtBlockResponse, error) {
+				return client.Emissions().GetReputerLossBundlesAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputerNodeInfo(ctx context.Context, req *emissionstypes.GetReputerNodeInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetReputerNodeInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputerNodeInfoResponse, error) {
-		return client.Emissions().GetReputerNodeInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputerNodeInfo", req, opts, func() (*emissionstypes.GetReputerNodeInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputerNodeInfo", req, opts, func() (*emissionstypes.GetReputerNodeInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputerNodeInfoResponse, error) {
+				return client.Emissions().GetReputerNodeInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputerScoreEma(ctx context.Context, req *emissionstypes.GetReputerScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetReputerScoreEmaResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputerScoreEmaResponse, error) {
-		return client.Emissions().GetReputerScoreEma(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputerScoreEma", req, opts, func() (*emissionstypes.GetReputerScoreEmaResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputerScoreEma", req, opts, func() (*emissionstypes.GetReputerScoreEmaResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputerScoreEmaResponse, error) {
+				return client.Emissions().GetReputerScoreEma(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputerStakeInTopic(ctx context.Context, req *emissionstypes.GetReputerStakeInTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetReputerStakeInTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputerStakeInTopicResponse, error) {
-		return client.Emissions().GetReputerStakeInTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputerStakeInTopic", req, opts, func() (*emissionstypes.GetReputerStakeInTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputerStakeInTopic", req, opts, func() (*emissionstypes.GetReputerStakeInTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputerStakeInTopicResponse, error) {
+				return client.Emissions().GetReputerStakeInTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputerSubmissionWindowStatus(ctx context.Context, req *emissionstypes.GetReputerSubmissionWindowStatusRequest, opts ...config.CallOpt) (*emissionstypes.GetReputerSubmissionWindowStatusResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputerSubmissionWindowStatusResponse, error) {
-		return client.Emissions().GetReputerSubmissionWindowStatus(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputerSubmissionWindowStatus", req, opts, func() (*emissionstypes.GetReputerSubmissionWindowStatusResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputerSubmissionWindowStatus", req, opts, func() (*emissionstypes.GetReputerSubmissionWindowStatusResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputerSubmissionWindowStatusResponse, error) {
+				return client.Emissions().GetReputerSubmissionWindowStatus(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetReputersScoresAtBlock(ctx context.Context, req *emissionstypes.GetReputersScoresAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetReputersScoresAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetReputersScoresAtBlockResponse, error) {
-		return client.Emissions().GetReputersScoresAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetReputersScoresAtBlock", req, opts, func() (*emissionstypes.GetReputersScoresAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetReputersScoresAtBlock", req, opts, func() (*emissionstypes.GetReputersScoresAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetReputersScoresAtBlockResponse, error) {
+				return client.Emissions().GetReputersScoresAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeFromDelegatorInTopic(ctx context.Context, req *emissionstypes.GetStakeFromDelegatorInTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeFromDelegatorInTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeFromDelegatorInTopicResponse, error) {
-		return client.Emissions().GetStakeFromDelegatorInTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeFromDelegatorInTopic", req, opts, func() (*emissionstypes.GetStakeFromDelegatorInTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeFromDelegatorInTopic", req, opts, func() (*emissionstypes.GetStakeFromDelegatorInTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeFromDelegatorInTopicResponse, error) {
+				return client.Emissions().GetStakeFromDelegatorInTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeFromDelegatorInTopicInReputer(ctx context.Context, req *emissionstypes.GetStakeFromDelegatorInTopicInReputerRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeFromDelegatorInTopicInReputerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeFromDelegatorInTopicInReputerResponse, error) {
-		return client.Emissions().GetStakeFromDelegatorInTopicInReputer(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeFromDelegatorInTopicInReputer", req, opts, func() (*emissionstypes.GetStakeFromDelegatorInTopicInReputerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeFromDelegatorInTopicInReputer", req, opts, func() (*emissionstypes.GetStakeFromDelegatorInTopicInReputerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeFromDelegatorInTopicInReputerResponse, error) {
+				return client.Emissions().GetStakeFromDelegatorInTopicInReputer(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeFromReputerInTopicInSelf(ctx context.Context, req *emissionstypes.GetStakeFromReputerInTopicInSelfRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeFromReputerInTopicInSelfResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeFromReputerInTopicInSelfResponse, error) {
-		return client.Emissions().GetStakeFromReputerInTopicInSelf(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeFromReputerInTopicInSelf", req, opts, func() (*emissionstypes.GetStakeFromReputerInTopicInSelfResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeFromReputerInTopicInSelf", req, opts, func() (*emissionstypes.GetStakeFromReputerInTopicInSelfResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeFromReputerInTopicInSelfResponse, error) {
+				return client.Emissions().GetStakeFromReputerInTopicInSelf(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeRemovalForReputerAndTopicId(ctx context.Context, req *emissionstypes.GetStakeRemovalForReputerAndTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeRemovalForReputerAndTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalForReputerAndTopicIdResponse, error) {
-		return client.Emissions().GetStakeRemovalForReputerAndTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeRemovalForReputerAndTopicId", req, opts, func() (*emissionstypes.GetStakeRemovalForReputerAndTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeRemovalForReputerAndTopicId", req, opts, func() (*emissionstypes.GetStakeRemovalForReputerAndTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalForReputerAndTopicIdResponse, error) {
+				return client.Emissions().GetStakeRemovalForReputerAndTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeRemovalInfo(ctx context.Context, req *emissionstypes.GetStakeRemovalInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeRemovalInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalInfoResponse, error) {
-		return client.Emissions().GetStakeRemovalInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeRemovalInfo", req, opts, func() (*emissionstypes.GetStakeRemovalInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeRemovalInfo", req, opts, func() (*emissionstypes.GetStakeRemovalInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalInfoResponse, error) {
+				return client.Emissions().GetStakeRemovalInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeRemovalsUpUntilBlock(ctx context.Context, req *emissionstypes.GetStakeRemovalsUpUntilBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeRemovalsUpUntilBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalsUpUntilBlockResponse, error) {
-		return client.Emissions().GetStakeRemovalsUpUntilBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeRemovalsUpUntilBlock", req, opts, func() (*emissionstypes.GetStakeRemovalsUpUntilBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeRemovalsUpUntilBlock", req, opts, func() (*emissionstypes.GetStakeRemovalsUpUntilBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeRemovalsUpUntilBlockResponse, error) {
+				return client.Emissions().GetStakeRemovalsUpUntilBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetStakeReputerAuthority(ctx context.Context, req *emissionstypes.GetStakeReputerAuthorityRequest, opts ...config.CallOpt) (*emissionstypes.GetStakeReputerAuthorityResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetStakeReputerAuthorityResponse, error) {
-		return client.Emissions().GetStakeReputerAuthority(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetStakeReputerAuthority", req, opts, func() (*emissionstypes.GetStakeReputerAuthorityResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetStakeReputerAuthority", req, opts, func() (*emissionstypes.GetStakeReputerAuthorityResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetStakeReputerAuthorityResponse, error) {
+				return client.Emissions().GetStakeReputerAuthority(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopic(ctx context.Context, req *emissionstypes.GetTopicRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicResponse, error) {
-		return client.Emissions().GetTopic(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopic", req, opts, func() (*emissionstypes.GetTopicResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopic", req, opts, func() (*emissionstypes.GetTopicResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicResponse, error) {
+				return client.Emissions().GetTopic(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicFeeRevenue(ctx context.Context, req *emissionstypes.GetTopicFeeRevenueRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicFeeRevenueResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicFeeRevenueResponse, error) {
-		return client.Emissions().GetTopicFeeRevenue(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicFeeRevenue", req, opts, func() (*emissionstypes.GetTopicFeeRevenueResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicFeeRevenue", req, opts, func() (*emissionstypes.GetTopicFeeRevenueResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicFeeRevenueResponse, error) {
+				return client.Emissions().GetTopicFeeRevenue(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicInitialForecasterEmaScore(ctx context.Context, req *emissionstypes.GetTopicInitialForecasterEmaScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicInitialForecasterEmaScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialForecasterEmaScoreResponse, error) {
-		return client.Emissions().GetTopicInitialForecasterEmaScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicInitialForecasterEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialForecasterEmaScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicInitialForecasterEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialForecasterEmaScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialForecasterEmaScoreResponse, error) {
+				return client.Emissions().GetTopicInitialForecasterEmaScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicInitialInfererEmaScore(ctx context.Context, req *emissionstypes.GetTopicInitialInfererEmaScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicInitialInfererEmaScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialInfererEmaScoreResponse, error) {
-		return client.Emissions().GetTopicInitialInfererEmaScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicInitialInfererEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialInfererEmaScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicInitialInfererEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialInfererEmaScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialInfererEmaScoreResponse, error) {
+				return client.Emissions().GetTopicInitialInfererEmaScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicInitialReputerEmaScore(ctx context.Context, req *emissionstypes.GetTopicInitialReputerEmaScoreRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicInitialReputerEmaScoreResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialReputerEmaScoreResponse, error) {
-		return client.Emissions().GetTopicInitialReputerEmaScore(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicInitialReputerEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialReputerEmaScoreResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicInitialReputerEmaScore", req, opts, func() (*emissionstypes.GetTopicInitialReputerEmaScoreResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicInitialReputerEmaScoreResponse, error) {
+				return client.Emissions().GetTopicInitialReputerEmaScore(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicLastReputerCommitInfo(ctx context.Context, req *emissionstypes.GetTopicLastReputerCommitInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicLastReputerCommitInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicLastReputerCommitInfoResponse, error) {
-		return client.Emissions().GetTopicLastReputerCommitInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicLastReputerCommitInfo", req, opts, func() (*emissionstypes.GetTopicLastReputerCommitInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicLastReputerCommitInfo", req, opts, func() (*emissionstypes.GetTopicLastReputerCommitInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicLastReputerCommitInfoResponse, error) {
+				return client.Emissions().GetTopicLastReputerCommitInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicLastWorkerCommitInfo(ctx context.Context, req *emissionstypes.GetTopicLastWorkerCommitInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicLastWorkerCommitInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicLastWorkerCommitInfoResponse, error) {
-		return client.Emissions().GetTopicLastWorkerCommitInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicLastWorkerCommitInfo", req, opts, func() (*emissionstypes.GetTopicLastWorkerCommitInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicLastWorkerCommitInfo", req, opts, func() (*emissionstypes.GetTopicLastWorkerCommitInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicLastWorkerCommitInfoResponse, error) {
+				return client.Emissions().GetTopicLastWorkerCommitInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicRewardNonce(ctx context.Context, req *emissionstypes.GetTopicRewardNonceRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicRewardNonceResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicRewardNonceResponse, error) {
-		return client.Emissions().GetTopicRewardNonce(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicRewardNonce", req, opts, func() (*emissionstypes.GetTopicRewardNonceResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicRewardNonce", req, opts, func() (*emissionstypes.GetTopicRewardNonceResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicRewardNonceResponse, error) {
+				return client.Emissions().GetTopicRewardNonce(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTopicStake(ctx context.Context, req *emissionstypes.GetTopicStakeRequest, opts ...config.CallOpt) (*emissionstypes.GetTopicStakeResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTopicStakeResponse, error) {
-		return client.Emissions().GetTopicStake(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTopicStake", req, opts, func() (*emissionstypes.GetTopicStakeResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTopicStake", req, opts, func() (*emissionstypes.GetTopicStakeResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTopicStakeResponse, error) {
+				return client.Emissions().GetTopicStake(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTotalRewardToDistribute(ctx context.Context, req *emissionstypes.GetTotalRewardToDistributeRequest, opts ...config.CallOpt) (*emissionstypes.GetTotalRewardToDistributeResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTotalRewardToDistributeResponse, error) {
-		return client.Emissions().GetTotalRewardToDistribute(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTotalRewardToDistribute", req, opts, func() (*emissionstypes.GetTotalRewardToDistributeResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTotalRewardToDistribute", req, opts, func() (*emissionstypes.GetTotalRewardToDistributeResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTotalRewardToDistributeResponse, error) {
+				return client.Emissions().GetTotalRewardToDistribute(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTotalStake(ctx context.Context, req *emissionstypes.GetTotalStakeRequest, opts ...config.CallOpt) (*emissionstypes.GetTotalStakeResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTotalStakeResponse, error) {
-		return client.Emissions().GetTotalStake(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTotalStake", req, opts, func() (*emissionstypes.GetTotalStakeResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTotalStake", req, opts, func() (*emissionstypes.GetTotalStakeResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTotalStakeResponse, error) {
+				return client.Emissions().GetTotalStake(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetTotalSumPreviousTopicWeights(ctx context.Context, req *emissionstypes.GetTotalSumPreviousTopicWeightsRequest, opts ...config.CallOpt) (*emissionstypes.GetTotalSumPreviousTopicWeightsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetTotalSumPreviousTopicWeightsResponse, error) {
-		return client.Emissions().GetTotalSumPreviousTopicWeights(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetTotalSumPreviousTopicWeights", req, opts, func() (*emissionstypes.GetTotalSumPreviousTopicWeightsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetTotalSumPreviousTopicWeights", req, opts, func() (*emissionstypes.GetTotalSumPreviousTopicWeightsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetTotalSumPreviousTopicWeightsResponse, error) {
+				return client.Emissions().GetTotalSumPreviousTopicWeights(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetUnfulfilledReputerNonces(ctx context.Context, req *emissionstypes.GetUnfulfilledReputerNoncesRequest, opts ...config.CallOpt) (*emissionstypes.GetUnfulfilledReputerNoncesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetUnfulfilledReputerNoncesResponse, error) {
-		return client.Emissions().GetUnfulfilledReputerNonces(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetUnfulfilledReputerNonces", req, opts, func() (*emissionstypes.GetUnfulfilledReputerNoncesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetUnfulfilledReputerNonces", req, opts, func() (*emissionstypes.GetUnfulfilledReputerNoncesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetUnfulfilledReputerNoncesResponse, error) {
+				return client.Emissions().GetUnfulfilledReputerNonces(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetUnfulfilledWorkerNonces(ctx context.Context, req *emissionstypes.GetUnfulfilledWorkerNoncesRequest, opts ...config.CallOpt) (*emissionstypes.GetUnfulfilledWorkerNoncesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetUnfulfilledWorkerNoncesResponse, error) {
-		return client.Emissions().GetUnfulfilledWorkerNonces(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetUnfulfilledWorkerNonces", req, opts, func() (*emissionstypes.GetUnfulfilledWorkerNoncesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetUnfulfilledWorkerNonces", req, opts, func() (*emissionstypes.GetUnfulfilledWorkerNoncesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetUnfulfilledWorkerNoncesResponse, error) {
+				return client.Emissions().GetUnfulfilledWorkerNonces(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetWorkerForecastScoresAtBlock(ctx context.Context, req *emissionstypes.GetWorkerForecastScoresAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetWorkerForecastScoresAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetWorkerForecastScoresAtBlockResponse, error) {
-		return client.Emissions().GetWorkerForecastScoresAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetWorkerForecastScoresAtBlock", req, opts, func() (*emissionstypes.GetWorkerForecastScoresAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetWorkerForecastScoresAtBlock", req, opts, func() (*emissionstypes.GetWorkerForecastScoresAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetWorkerForecastScoresAtBlockResponse, error) {
+				return client.Emissions().GetWorkerForecastScoresAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetWorkerInferenceScoresAtBlock(ctx context.Context, req *emissionstypes.GetWorkerInferenceScoresAtBlockRequest, opts ...config.CallOpt) (*emissionstypes.GetWorkerInferenceScoresAtBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetWorkerInferenceScoresAtBlockResponse, error) {
-		return client.Emissions().GetWorkerInferenceScoresAtBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetWorkerInferenceScoresAtBlock", req, opts, func() (*emissionstypes.GetWorkerInferenceScoresAtBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetWorkerInferenceScoresAtBlock", req, opts, func() (*emissionstypes.GetWorkerInferenceScoresAtBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetWorkerInferenceScoresAtBlockResponse, error) {
+				return client.Emissions().GetWorkerInferenceScoresAtBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetWorkerLatestInputInferenceByTopicId(ctx context.Context, req *emissionstypes.GetWorkerLatestInputInferenceByTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.GetWorkerLatestInputInferenceByTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetWorkerLatestInputInferenceByTopicIdResponse, error) {
-		return client.Emissions().GetWorkerLatestInputInferenceByTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetWorkerLatestInputInferenceByTopicId", req, opts, func() (*emissionstypes.GetWorkerLatestInputInferenceByTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetWorkerLatestInputInferenceByTopicId", req, opts, func() (*emissionstypes.GetWorkerLatestInputInferenceByTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetWorkerLatestInputInferenceByTopicIdResponse, error) {
+				return client.Emissions().GetWorkerLatestInputInferenceByTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetWorkerNodeInfo(ctx context.Context, req *emissionstypes.GetWorkerNodeInfoRequest, opts ...config.CallOpt) (*emissionstypes.GetWorkerNodeInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetWorkerNodeInfoResponse, error) {
-		return client.Emissions().GetWorkerNodeInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetWorkerNodeInfo", req, opts, func() (*emissionstypes.GetWorkerNodeInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetWorkerNodeInfo", req, opts, func() (*emissionstypes.GetWorkerNodeInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetWorkerNodeInfoResponse, error) {
+				return client.Emissions().GetWorkerNodeInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) GetWorkerSubmissionWindowStatus(ctx context.Context, req *emissionstypes.GetWorkerSubmissionWindowStatusRequest, opts ...config.CallOpt) (*emissionstypes.GetWorkerSubmissionWindowStatusResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.GetWorkerSubmissionWindowStatusResponse, error) {
-		return client.Emissions().GetWorkerSubmissionWindowStatus(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "GetWorkerSubmissionWindowStatus", req, opts, func() (*emissionstypes.GetWorkerSubmissionWindowStatusResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "GetWorkerSubmissionWindowStatus", req, opts, func() (*emissionstypes.GetWorkerSubmissionWindowStatusResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.GetWorkerSubmissionWindowStatusResponse, error) {
+				return client.Emissions().GetWorkerSubmissionWindowStatus(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsReputerNonceUnfulfilled(ctx context.Context, req *emissionstypes.IsReputerNonceUnfulfilledRequest, opts ...config.CallOpt) (*emissionstypes.IsReputerNonceUnfulfilledResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsReputerNonceUnfulfilledResponse, error) {
-		return client.Emissions().IsReputerNonceUnfulfilled(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsReputerNonceUnfulfilled", req, opts, func() (*emissionstypes.IsReputerNonceUnfulfilledResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsReputerNonceUnfulfilled", req, opts, func() (*emissionstypes.IsReputerNonceUnfulfilledResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsReputerNonceUnfulfilledResponse, error) {
+				return client.Emissions().IsReputerNonceUnfulfilled(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsReputerRegisteredInTopicId(ctx context.Context, req *emissionstypes.IsReputerRegisteredInTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.IsReputerRegisteredInTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsReputerRegisteredInTopicIdResponse, error) {
-		return client.Emissions().IsReputerRegisteredInTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsReputerRegisteredInTopicId", req, opts, func() (*emissionstypes.IsReputerRegisteredInTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsReputerRegisteredInTopicId", req, opts, func() (*emissionstypes.IsReputerRegisteredInTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsReputerRegisteredInTopicIdResponse, error) {
+				return client.Emissions().IsReputerRegisteredInTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsTopicActive(ctx context.Context, req *emissionstypes.IsTopicActiveRequest, opts ...config.CallOpt) (*emissionstypes.IsTopicActiveResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsTopicActiveResponse, error) {
-		return client.Emissions().IsTopicActive(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsTopicActive", req, opts, func() (*emissionstypes.IsTopicActiveResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsTopicActive", req, opts, func() (*emissionstypes.IsTopicActiveResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsTopicActiveResponse, error) {
+				return client.Emissions().IsTopicActive(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsTopicReputerWhitelistEnabled(ctx context.Context, req *emissionstypes.IsTopicReputerWhitelistEnabledRequest, opts ...config.CallOpt) (*emissionstypes.IsTopicReputerWhitelistEnabledResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsTopicReputerWhitelistEnabledResponse, error) {
-		return client.Emissions().IsTopicReputerWhitelistEnabled(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsTopicReputerWhitelistEnabled", req, opts, func() (*emissionstypes.IsTopicReputerWhitelistEnabledResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsTopicReputerWhitelistEnabled", req, opts, func() (*emissionstypes.IsTopicReputerWhitelistEnabledResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsTopicReputerWhitelistEnabledResponse, error) {
+				return client.Emissions().IsTopicReputerWhitelistEnabled(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsTopicWorkerWhitelistEnabled(ctx context.Context, req *emissionstypes.IsTopicWorkerWhitelistEnabledRequest, opts ...config.CallOpt) (*emissionstypes.IsTopicWorkerWhitelistEnabledResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsTopicWorkerWhitelistEnabledResponse, error) {
-		return client.Emissions().IsTopicWorkerWhitelistEnabled(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsTopicWorkerWhitelistEnabled", req, opts, func() (*emissionstypes.IsTopicWorkerWhitelistEnabledResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsTopicWorkerWhitelistEnabled", req, opts, func() (*emissionstypes.IsTopicWorkerWhitelistEnabledResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsTopicWorkerWhitelistEnabledResponse, error) {
+				return client.Emissions().IsTopicWorkerWhitelistEnabled(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistAdmin(ctx context.Context, req *emissionstypes.IsWhitelistAdminRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistAdminResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistAdminResponse, error) {
-		return client.Emissions().IsWhitelistAdmin(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistAdmin", req, opts, func() (*emissionstypes.IsWhitelistAdminResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistAdmin", req, opts, func() (*emissionstypes.IsWhitelistAdminResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistAdminResponse, error) {
+				return client.Emissions().IsWhitelistAdmin(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedGlobalActor(ctx context.Context, req *emissionstypes.IsWhitelistedGlobalActorRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedGlobalActorResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalActorResponse, error) {
-		return client.Emissions().IsWhitelistedGlobalActor(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedGlobalActor", req, opts, func() (*emissionstypes.IsWhitelistedGlobalActorResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedGlobalActor", req, opts, func() (*emissionstypes.IsWhitelistedGlobalActorResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalActorResponse, error) {
+				return client.Emissions().IsWhitelistedGlobalActor(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedGlobalAdmin(ctx context.Context, req *emissionstypes.IsWhitelistedGlobalAdminRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedGlobalAdminResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalAdminResponse, error) {
-		return client.Emissions().IsWhitelistedGlobalAdmin(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedGlobalAdmin", req, opts, func() (*emissionstypes.IsWhitelistedGlobalAdminResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedGlobalAdmin", req, opts, func() (*emissionstypes.IsWhitelistedGlobalAdminResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalAdminResponse, error) {
+				return client.Emissions().IsWhitelistedGlobalAdmin(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedGlobalReputer(ctx context.Context, req *emissionstypes.IsWhitelistedGlobalReputerRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedGlobalReputerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalReputerResponse, error) {
-		return client.Emissions().IsWhitelistedGlobalReputer(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedGlobalReputer", req, opts, func() (*emissionstypes.IsWhitelistedGlobalReputerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedGlobalReputer", req, opts, func() (*emissionstypes.IsWhitelistedGlobalReputerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalReputerResponse, error) {
+				return client.Emissions().IsWhitelistedGlobalReputer(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedGlobalWorker(ctx context.Context, req *emissionstypes.IsWhitelistedGlobalWorkerRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedGlobalWorkerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalWorkerResponse, error) {
-		return client.Emissions().IsWhitelistedGlobalWorker(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedGlobalWorker", req, opts, func() (*emissionstypes.IsWhitelistedGlobalWorkerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedGlobalWorker", req, opts, func() (*emissionstypes.IsWhitelistedGlobalWorkerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedGlobalWorkerResponse, error) {
+				return client.Emissions().IsWhitelistedGlobalWorker(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedTopicCreator(ctx context.Context, req *emissionstypes.IsWhitelistedTopicCreatorRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedTopicCreatorResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicCreatorResponse, error) {
-		return client.Emissions().IsWhitelistedTopicCreator(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedTopicCreator", req, opts, func() (*emissionstypes.IsWhitelistedTopicCreatorResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedTopicCreator", req, opts, func() (*emissionstypes.IsWhitelistedTopicCreatorResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicCreatorResponse, error) {
+				return client.Emissions().IsWhitelistedTopicCreator(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedTopicReputer(ctx context.Context, req *emissionstypes.IsWhitelistedTopicReputerRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedTopicReputerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicReputerResponse, error) {
-		return client.Emissions().IsWhitelistedTopicReputer(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedTopicReputer", req, opts, func() (*emissionstypes.IsWhitelistedTopicReputerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedTopicReputer", req, opts, func() (*emissionstypes.IsWhitelistedTopicReputerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicReputerResponse, error) {
+				return client.Emissions().IsWhitelistedTopicReputer(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWhitelistedTopicWorker(ctx context.Context, req *emissionstypes.IsWhitelistedTopicWorkerRequest, opts ...config.CallOpt) (*emissionstypes.IsWhitelistedTopicWorkerResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicWorkerResponse, error) {
-		return client.Emissions().IsWhitelistedTopicWorker(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWhitelistedTopicWorker", req, opts, func() (*emissionstypes.IsWhitelistedTopicWorkerResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWhitelistedTopicWorker", req, opts, func() (*emissionstypes.IsWhitelistedTopicWorkerResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWhitelistedTopicWorkerResponse, error) {
+				return client.Emissions().IsWhitelistedTopicWorker(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWorkerNonceUnfulfilled(ctx context.Context, req *emissionstypes.IsWorkerNonceUnfulfilledRequest, opts ...config.CallOpt) (*emissionstypes.IsWorkerNonceUnfulfilledResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWorkerNonceUnfulfilledResponse, error) {
-		return client.Emissions().IsWorkerNonceUnfulfilled(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWorkerNonceUnfulfilled", req, opts, func() (*emissionstypes.IsWorkerNonceUnfulfilledResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWorkerNonceUnfulfilled", req, opts, func() (*emissionstypes.IsWorkerNonceUnfulfilledResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWorkerNonceUnfulfilledResponse, error) {
+				return client.Emissions().IsWorkerNonceUnfulfilled(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) IsWorkerRegisteredInTopicId(ctx context.Context, req *emissionstypes.IsWorkerRegisteredInTopicIdRequest, opts ...config.CallOpt) (*emissionstypes.IsWorkerRegisteredInTopicIdResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.IsWorkerRegisteredInTopicIdResponse, error) {
-		return client.Emissions().IsWorkerRegisteredInTopicId(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "IsWorkerRegisteredInTopicId", req, opts, func() (*emissionstypes.IsWorkerRegisteredInTopicIdResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "IsWorkerRegisteredInTopicId", req, opts, func() (*emissionstypes.IsWorkerRegisteredInTopicIdResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.IsWorkerRegisteredInTopicIdResponse, error) {
+				return client.Emissions().IsWorkerRegisteredInTopicId(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EmissionsClientWrapper) TopicExists(ctx context.Context, req *emissionstypes.TopicExistsRequest, opts ...config.CallOpt) (*emissionstypes.TopicExistsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*emissionstypes.TopicExistsResponse, error) {
-		return client.Emissions().TopicExists(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "emissions", "TopicExists", req, opts, func() (*emissionstypes.TopicExistsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "emissions", "TopicExists", req, opts, func() (*emissionstypes.TopicExistsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*emissionstypes.TopicExistsResponse, error) {
+				return client.Emissions().TopicExists(ctx, req, opts...)
+			})
+		})
 	})
 }
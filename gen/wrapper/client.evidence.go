@@ -6,33 +6,57 @@ import (
 	evidencetypes "cosmossdk.io/x/evidence/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// EvidenceClientWrapper wraps the evidence module with pool management and retry logic
+// EvidenceClientWrapper wraps the evidence module with pool management, retry logic, response caching and request coalescing
 type EvidenceClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewEvidenceClientWrapper creates a new evidence client wrapper
-func NewEvidenceClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *EvidenceClientWrapper {
+func NewEvidenceClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *EvidenceClientWrapper {
 	return &EvidenceClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "evidence").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *EvidenceClientWrapper) pinned(pin *pool.Pin) *EvidenceClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *EvidenceClientWrapper) AllEvidence(ctx context.Context, req *evidencetypes.QueryAllEvidenceRequest, opts ...config.CallOpt) (*evidencetypes.QueryAllEvidenceResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*evidencetypes.QueryAllEvidenceResponse, error) {
-		return client.Evidence().AllEvidence(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "evidence", "AllEvidence", req, opts, func() (*evidencetypes.QueryAllEvidenceResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "evidence", "AllEvidence", req, opts, func() (*evidencetypes.QueryAllEvidenceResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*evidencetypes.QueryAllEvidenceResponse, error) {
+				return client.Evidence().AllEvidence(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *EvidenceClientWrapper) Evidence(ctx context.Context, req *evidencetypes.QueryEvidenceRequest, opts ...config.CallOpt) (*evidencetypes.QueryEvidenceResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*evidencetypes.QueryEvidenceResponse, error) {
-		return client.Evidence().Evidence(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "evidence", "Evidence", req, opts, func() (*evidencetypes.QueryEvidenceResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "evidence", "Evidence", req, opts, func() (*evidencetypes.QueryEvidenceResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*evidencetypes.QueryEvidenceResponse, error) {
+				return client.Evidence().Evidence(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,39 +6,68 @@ import (
 	feegrant "cosmossdk.io/x/feegrant"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// FeegrantClientWrapper wraps the feegrant module with pool management and retry logic
+// FeegrantClientWrapper wraps the feegrant module with pool management, retry logic, response caching and request coalescing
 type FeegrantClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewFeegrantClientWrapper creates a new feegrant client wrapper
-func NewFeegrantClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *FeegrantClientWrapper {
+func NewFeegrantClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *FeegrantClientWrapper {
 	return &FeegrantClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "feegrant").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *FeegrantClientWrapper) pinned(pin *pool.Pin) *FeegrantClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *FeegrantClientWrapper) Allowance(ctx context.Context, req *feegrant.QueryAllowanceRequest, opts ...config.CallOpt) (*feegrant.QueryAllowanceResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*feegrant.QueryAllowanceResponse, error) {
-		return client.Feegrant().Allowance(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "feegrant", "Allowance", req, opts, func() (*feegrant.QueryAllowanceResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "feegrant", "Allowance", req, opts, func() (*feegrant.QueryAllowanceResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*feegrant.QueryAllowanceResponse, error) {
+				return client.Feegrant().Allowance(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *FeegrantClientWrapper) Allowances(ctx context.Context, req *feegrant.QueryAllowancesRequest, opts ...config.CallOpt) (*feegrant.QueryAllowancesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*feegrant.QueryAllowancesResponse, error) {
-		return client.Feegrant().Allowances(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "feegrant", "Allowances", req, opts, func() (*feegrant.QueryAllowancesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "feegrant", "Allowances", req, opts, func() (*feegrant.QueryAllowancesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*feegrant.QueryAllowancesResponse, error) {
+				return client.Feegrant().Allowances(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *FeegrantClientWrapper) AllowancesByGranter(ctx context.Context, req *feegrant.QueryAllowancesByGranterRequest, opts ...config.CallOpt) (*feegrant.QueryAllowancesByGranterResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*feegrant.QueryAllowancesByGranterResponse, error) {
-		return client.Feegrant().AllowancesByGranter(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "feegrant", "AllowancesByGranter", req, opts, func() (*feegrant.QueryAllowancesByGranterResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "feegrant", "AllowancesByGranter", req, opts, func() (*feegrant.QueryAllowancesByGranterResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*feegrant.QueryAllowancesByGranterResponse, error) {
+				return client.Feegrant().AllowancesByGranter(ctx, req, opts...)
+			})
+		})
 	})
 }
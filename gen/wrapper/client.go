@@ -3,6 +3,8 @@ package wrapper
 import (
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
@@ -26,24 +28,48 @@ type WrapperClient struct {
 	staking      *StakingClientWrapper
 }
 
-func NewWrapperClient(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *WrapperClient {
+// NewWrapperClient creates a client that runs every query on the pool. responseCache
+// and coalescer may be nil to disable caching and request coalescing.
+func NewWrapperClient(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *WrapperClient {
 	return &WrapperClient{
-		evidence:     NewEvidenceClientWrapper(poolManager, logger),
-		feegrant:     NewFeegrantClientWrapper(poolManager, logger),
-		emissions:    NewEmissionsClientWrapper(poolManager, logger),
-		mint:         NewMintClientWrapper(poolManager, logger),
-		tendermint:   NewTendermintClientWrapper(poolManager, logger),
-		node:         NewNodeClientWrapper(poolManager, logger),
-		tx:           NewTxClientWrapper(poolManager, logger),
-		auth:         NewAuthClientWrapper(poolManager, logger),
-		authz:        NewAuthzClientWrapper(poolManager, logger),
-		bank:         NewBankClientWrapper(poolManager, logger),
-		consensus:    NewConsensusClientWrapper(poolManager, logger),
-		distribution: NewDistributionClientWrapper(poolManager, logger),
-		gov:          NewGovClientWrapper(poolManager, logger),
-		params:       NewParamsClientWrapper(poolManager, logger),
-		slashing:     NewSlashingClientWrapper(poolManager, logger),
-		staking:      NewStakingClientWrapper(poolManager, logger),
+		evidence:     NewEvidenceClientWrapper(poolManager, responseCache, coalescer, logger),
+		feegrant:     NewFeegrantClientWrapper(poolManager, responseCache, coalescer, logger),
+		emissions:    NewEmissionsClientWrapper(poolManager, responseCache, coalescer, logger),
+		mint:         NewMintClientWrapper(poolManager, responseCache, coalescer, logger),
+		tendermint:   NewTendermintClientWrapper(poolManager, responseCache, coalescer, logger),
+		node:         NewNodeClientWrapper(poolManager, responseCache, coalescer, logger),
+		tx:           NewTxClientWrapper(poolManager, responseCache, coalescer, logger),
+		auth:         NewAuthClientWrapper(poolManager, responseCache, coalescer, logger),
+		authz:        NewAuthzClientWrapper(poolManager, responseCache, coalescer, logger),
+		bank:         NewBankClientWrapper(poolManager, responseCache, coalescer, logger),
+		consensus:    NewConsensusClientWrapper(poolManager, responseCache, coalescer, logger),
+		distribution: NewDistributionClientWrapper(poolManager, responseCache, coalescer, logger),
+		gov:          NewGovClientWrapper(poolManager, responseCache, coalescer, logger),
+		params:       NewParamsClientWrapper(poolManager, responseCache, coalescer, logger),
+		slashing:     NewSlashingClientWrapper(poolManager, responseCache, coalescer, logger),
+		staking:      NewStakingClientWrapper(poolManager, responseCache, coalescer, logger),
+	}
+}
+
+// Pinned returns a view of the client that runs every query as pinned by pin
+func (c *WrapperClient) Pinned(pin *pool.Pin) *WrapperClient {
+	return &WrapperClient{
+		evidence:     c.evidence.pinned(pin),
+		feegrant:     c.feegrant.pinned(pin),
+		emissions:    c.emissions.pinned(pin),
+		mint:         c.mint.pinned(pin),
+		tendermint:   c.tendermint.pinned(pin),
+		node:         c.node.pinned(pin),
+		tx:           c.tx.pinned(pin),
+		auth:         c.auth.pinned(pin),
+		authz:        c.authz.pinned(pin),
+		bank:         c.bank.pinned(pin),
+		consensus:    c.consensus.pinned(pin),
+		distribution: c.distribution.pinned(pin),
+		gov:          c.gov.pinned(pin),
+		params:       c.params.pinned(pin),
+		slashing:     c.slashing.pinned(pin),
+		staking:      c.staking.pinned(pin),
 	}
 }
 
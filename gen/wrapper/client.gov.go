@@ -6,75 +6,134 @@ import (
 	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// GovClientWrapper wraps the gov module with pool management and retry logic
+// GovClientWrapper wraps the gov module with pool management, retry logic, response caching and request coalescing
 type GovClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewGovClientWrapper creates a new gov client wrapper
-func NewGovClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *GovClientWrapper {
+func NewGovClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *GovClientWrapper {
 	return &GovClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "gov").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *GovClientWrapper) pinned(pin *pool.Pin) *GovClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *GovClientWrapper) Constitution(ctx context.Context, req *govv1.QueryConstitutionRequest, opts ...config.CallOpt) (*govv1.QueryConstitutionResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryConstitutionResponse, error) {
-		return client.Gov().Constitution(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Constitution", req, opts, func() (*govv1.QueryConstitutionResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Constitution", req, opts, func() (*govv1.QueryConstitutionResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryConstitutionResponse, error) {
+				return client.Gov().Constitution(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Deposit(ctx context.Context, req *govv1.QueryDepositRequest, opts ...config.CallOpt) (*govv1.QueryDepositResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryDepositResponse, error) {
-		return client.Gov().Deposit(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Deposit", req, opts, func() (*govv1.QueryDepositResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Deposit", req, opts, func() (*govv1.QueryDepositResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryDepositResponse, error) {
+				return client.Gov().Deposit(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Deposits(ctx context.Context, req *govv1.QueryDepositsRequest, opts ...config.CallOpt) (*govv1.QueryDepositsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryDepositsResponse, error) {
-		return client.Gov().Deposits(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Deposits", req, opts, func() (*govv1.QueryDepositsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Deposits", req, opts, func() (*govv1.QueryDepositsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryDepositsResponse, error) {
+				return client.Gov().Deposits(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Params(ctx context.Context, req *govv1.QueryParamsRequest, opts ...config.CallOpt) (*govv1.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryParamsResponse, error) {
-		return client.Gov().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Params", req, opts, func() (*govv1.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Params", req, opts, func() (*govv1.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryParamsResponse, error) {
+				return client.Gov().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Proposal(ctx context.Context, req *govv1.QueryProposalRequest, opts ...config.CallOpt) (*govv1.QueryProposalResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryProposalResponse, error) {
-		return client.Gov().Proposal(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Proposal", req, opts, func() (*govv1.QueryProposalResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Proposal", req, opts, func() (*govv1.QueryProposalResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryProposalResponse, error) {
+				return client.Gov().Proposal(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Proposals(ctx context.Context, req *govv1.QueryProposalsRequest, opts ...config.CallOpt) (*govv1.QueryProposalsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryProposalsResponse, error) {
-		return client.Gov().Proposals(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Proposals", req, opts, func() (*govv1.QueryProposalsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Proposals", req, opts, func() (*govv1.QueryProposalsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryProposalsResponse, error) {
+				return client.Gov().Proposals(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) TallyResult(ctx context.Context, req *govv1.QueryTallyResultRequest, opts ...config.CallOpt) (*govv1.QueryTallyResultResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryTallyResultResponse, error) {
-		return client.Gov().TallyResult(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "TallyResult", req, opts, func() (*govv1.QueryTallyResultResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "TallyResult", req, opts, func() (*govv1.QueryTallyResultResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryTallyResultResponse, error) {
+				return client.Gov().TallyResult(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Vote(ctx context.Context, req *govv1.QueryVoteRequest, opts ...config.CallOpt) (*govv1.QueryVoteResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryVoteResponse, error) {
-		return client.Gov().Vote(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Vote", req, opts, func() (*govv1.QueryVoteResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Vote", req, opts, func() (*govv1.QueryVoteResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryVoteResponse, error) {
+				return client.Gov().Vote(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *GovClientWrapper) Votes(ctx context.Context, req *govv1.QueryVotesRequest, opts ...config.CallOpt) (*govv1.QueryVotesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*govv1.QueryVotesResponse, error) {
-		return client.Gov().Votes(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "gov", "Votes", req, opts, func() (*govv1.QueryVotesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "gov", "Votes", req, opts, func() (*govv1.QueryVotesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*govv1.QueryVotesResponse, error) {
+				return client.Gov().Votes(ctx, req, opts...)
+			})
+		})
 	})
 }
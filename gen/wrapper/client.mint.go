@@ -6,39 +6,68 @@ import (
 	minttypes "github.com/allora-network/allora-chain/x/mint/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// MintClientWrapper wraps the mint module with pool management and retry logic
+// MintClientWrapper wraps the mint module with pool management, retry logic, response caching and request coalescing
 type MintClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewMintClientWrapper creates a new mint client wrapper
-func NewMintClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *MintClientWrapper {
+func NewMintClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *MintClientWrapper {
 	return &MintClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "mint").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *MintClientWrapper) pinned(pin *pool.Pin) *MintClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *MintClientWrapper) EmissionInfo(ctx context.Context, req *minttypes.QueryServiceEmissionInfoRequest, opts ...config.CallOpt) (*minttypes.QueryServiceEmissionInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*minttypes.QueryServiceEmissionInfoResponse, error) {
-		return client.Mint().EmissionInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "mint", "EmissionInfo", req, opts, func() (*minttypes.QueryServiceEmissionInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "mint", "EmissionInfo", req, opts, func() (*minttypes.QueryServiceEmissionInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*minttypes.QueryServiceEmissionInfoResponse, error) {
+				return client.Mint().EmissionInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *MintClientWrapper) Inflation(ctx context.Context, req *minttypes.QueryServiceInflationRequest, opts ...config.CallOpt) (*minttypes.QueryServiceInflationResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*minttypes.QueryServiceInflationResponse, error) {
-		return client.Mint().Inflation(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "mint", "Inflation", req, opts, func() (*minttypes.QueryServiceInflationResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "mint", "Inflation", req, opts, func() (*minttypes.QueryServiceInflationResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*minttypes.QueryServiceInflationResponse, error) {
+				return client.Mint().Inflation(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *MintClientWrapper) Params(ctx context.Context, req *minttypes.QueryServiceParamsRequest, opts ...config.CallOpt) (*minttypes.QueryServiceParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*minttypes.QueryServiceParamsResponse, error) {
-		return client.Mint().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "mint", "Params", req, opts, func() (*minttypes.QueryServiceParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "mint", "Params", req, opts, func() (*minttypes.QueryServiceParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*minttypes.QueryServiceParamsResponse, error) {
+				return client.Mint().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,33 +6,57 @@ import (
 	node "github.com/cosmos/cosmos-sdk/client/grpc/node"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// NodeClientWrapper wraps the node module with pool management and retry logic
+// NodeClientWrapper wraps the node module with pool management, retry logic, response caching and request coalescing
 type NodeClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewNodeClientWrapper creates a new node client wrapper
-func NewNodeClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *NodeClientWrapper {
+func NewNodeClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *NodeClientWrapper {
 	return &NodeClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "node").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *NodeClientWrapper) pinned(pin *pool.Pin) *NodeClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *NodeClientWrapper) Config(ctx context.Context, req *node.ConfigRequest, opts ...config.CallOpt) (*node.ConfigResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*node.ConfigResponse, error) {
-		return client.Node().Config(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "node", "Config", req, opts, func() (*node.ConfigResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "node", "Config", req, opts, func() (*node.ConfigResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*node.ConfigResponse, error) {
+				return client.Node().Config(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *NodeClientWrapper) Status(ctx context.Context, req *node.StatusRequest, opts ...config.CallOpt) (*node.StatusResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*node.StatusResponse, error) {
-		return client.Node().Status(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "node", "Status", req, opts, func() (*node.StatusResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "node", "Status", req, opts, func() (*node.StatusResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*node.StatusResponse, error) {
+				return client.Node().Status(ctx, req, opts...)
+			})
+		})
 	})
 }
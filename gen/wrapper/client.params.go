@@ -6,33 +6,57 @@ import (
 	proposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// ParamsClientWrapper wraps the params module with pool management and retry logic
+// ParamsClientWrapper wraps the params module with pool management, retry logic, response caching and request coalescing
 type ParamsClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewParamsClientWrapper creates a new params client wrapper
-func NewParamsClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *ParamsClientWrapper {
+func NewParamsClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *ParamsClientWrapper {
 	return &ParamsClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "params").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *ParamsClientWrapper) pinned(pin *pool.Pin) *ParamsClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *ParamsClientWrapper) Params(ctx context.Context, req *proposal.QueryParamsRequest, opts ...config.CallOpt) (*proposal.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*proposal.QueryParamsResponse, error) {
-		return client.Params().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "params", "Params", req, opts, func() (*proposal.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "params", "Params", req, opts, func() (*proposal.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*proposal.QueryParamsResponse, error) {
+				return client.Params().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *ParamsClientWrapper) Subspaces(ctx context.Context, req *proposal.QuerySubspacesRequest, opts ...config.CallOpt) (*proposal.QuerySubspacesResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*proposal.QuerySubspacesResponse, error) {
-		return client.Params().Subspaces(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "params", "Subspaces", req, opts, func() (*proposal.QuerySubspacesResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "params", "Subspaces", req, opts, func() (*proposal.QuerySubspacesResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*proposal.QuerySubspacesResponse, error) {
+				return client.Params().Subspaces(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,39 +6,68 @@ import (
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// SlashingClientWrapper wraps the slashing module with pool management and retry logic
+// SlashingClientWrapper wraps the slashing module with pool management, retry logic, response caching and request coalescing
 type SlashingClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewSlashingClientWrapper creates a new slashing client wrapper
-func NewSlashingClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *SlashingClientWrapper {
+func NewSlashingClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *SlashingClientWrapper {
 	return &SlashingClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "slashing").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *SlashingClientWrapper) pinned(pin *pool.Pin) *SlashingClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *SlashingClientWrapper) Params(ctx context.Context, req *slashingtypes.QueryParamsRequest, opts ...config.CallOpt) (*slashingtypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*slashingtypes.QueryParamsResponse, error) {
-		return client.Slashing().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "slashing", "Params", req, opts, func() (*slashingtypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "slashing", "Params", req, opts, func() (*slashingtypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*slashingtypes.QueryParamsResponse, error) {
+				return client.Slashing().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *SlashingClientWrapper) SigningInfo(ctx context.Context, req *slashingtypes.QuerySigningInfoRequest, opts ...config.CallOpt) (*slashingtypes.QuerySigningInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*slashingtypes.QuerySigningInfoResponse, error) {
-		return client.Slashing().SigningInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "slashing", "SigningInfo", req, opts, func() (*slashingtypes.QuerySigningInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "slashing", "SigningInfo", req, opts, func() (*slashingtypes.QuerySigningInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*slashingtypes.QuerySigningInfoResponse, error) {
+				return client.Slashing().SigningInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *SlashingClientWrapper) SigningInfos(ctx context.Context, req *slashingtypes.QuerySigningInfosRequest, opts ...config.CallOpt) (*slashingtypes.QuerySigningInfosResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*slashingtypes.QuerySigningInfosResponse, error) {
-		return client.Slashing().SigningInfos(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "slashing", "SigningInfos", req, opts, func() (*slashingtypes.QuerySigningInfosResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "slashing", "SigningInfos", req, opts, func() (*slashingtypes.QuerySigningInfosResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*slashingtypes.QuerySigningInfosResponse, error) {
+				return client.Slashing().SigningInfos(ctx, req, opts...)
+			})
+		})
 	})
 }
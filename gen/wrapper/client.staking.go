@@ -6,105 +6,189 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// StakingClientWrapper wraps the staking module with pool management and retry logic
+// StakingClientWrapper wraps the staking module with pool management, retry logic, response caching and request coalescing
 type StakingClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewStakingClientWrapper creates a new staking client wrapper
-func NewStakingClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *StakingClientWrapper {
+func NewStakingClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *StakingClientWrapper {
 	return &StakingClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "staking").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *StakingClientWrapper) pinned(pin *pool.Pin) *StakingClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *StakingClientWrapper) Delegation(ctx context.Context, req *stakingtypes.QueryDelegationRequest, opts ...config.CallOpt) (*stakingtypes.QueryDelegationResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryDelegationResponse, error) {
-		return client.Staking().Delegation(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Delegation", req, opts, func() (*stakingtypes.QueryDelegationResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Delegation", req, opts, func() (*stakingtypes.QueryDelegationResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryDelegationResponse, error) {
+				return client.Staking().Delegation(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) DelegatorDelegations(ctx context.Context, req *stakingtypes.QueryDelegatorDelegationsRequest, opts ...config.CallOpt) (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
-		return client.Staking().DelegatorDelegations(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "DelegatorDelegations", req, opts, func() (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "DelegatorDelegations", req, opts, func() (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
+				return client.Staking().DelegatorDelegations(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) DelegatorUnbondingDelegations(ctx context.Context, req *stakingtypes.QueryDelegatorUnbondingDelegationsRequest, opts ...config.CallOpt) (*stakingtypes.QueryDelegatorUnbondingDelegationsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorUnbondingDelegationsResponse, error) {
-		return client.Staking().DelegatorUnbondingDelegations(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "DelegatorUnbondingDelegations", req, opts, func() (*stakingtypes.QueryDelegatorUnbondingDelegationsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "DelegatorUnbondingDelegations", req, opts, func() (*stakingtypes.QueryDelegatorUnbondingDelegationsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorUnbondingDelegationsResponse, error) {
+				return client.Staking().DelegatorUnbondingDelegations(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) DelegatorValidator(ctx context.Context, req *stakingtypes.QueryDelegatorValidatorRequest, opts ...config.CallOpt) (*stakingtypes.QueryDelegatorValidatorResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorValidatorResponse, error) {
-		return client.Staking().DelegatorValidator(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "DelegatorValidator", req, opts, func() (*stakingtypes.QueryDelegatorValidatorResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "DelegatorValidator", req, opts, func() (*stakingtypes.QueryDelegatorValidatorResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorValidatorResponse, error) {
+				return client.Staking().DelegatorValidator(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) DelegatorValidators(ctx context.Context, req *stakingtypes.QueryDelegatorValidatorsRequest, opts ...config.CallOpt) (*stakingtypes.QueryDelegatorValidatorsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorValidatorsResponse, error) {
-		return client.Staking().DelegatorValidators(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "DelegatorValidators", req, opts, func() (*stakingtypes.QueryDelegatorValidatorsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "DelegatorValidators", req, opts, func() (*stakingtypes.QueryDelegatorValidatorsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryDelegatorValidatorsResponse, error) {
+				return client.Staking().DelegatorValidators(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) HistoricalInfo(ctx context.Context, req *stakingtypes.QueryHistoricalInfoRequest, opts ...config.CallOpt) (*stakingtypes.QueryHistoricalInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryHistoricalInfoResponse, error) {
-		return client.Staking().HistoricalInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "HistoricalInfo", req, opts, func() (*stakingtypes.QueryHistoricalInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "HistoricalInfo", req, opts, func() (*stakingtypes.QueryHistoricalInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryHistoricalInfoResponse, error) {
+				return client.Staking().HistoricalInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) Params(ctx context.Context, req *stakingtypes.QueryParamsRequest, opts ...config.CallOpt) (*stakingtypes.QueryParamsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryParamsResponse, error) {
-		return client.Staking().Params(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Params", req, opts, func() (*stakingtypes.QueryParamsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Params", req, opts, func() (*stakingtypes.QueryParamsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryParamsResponse, error) {
+				return client.Staking().Params(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) Pool(ctx context.Context, req *stakingtypes.QueryPoolRequest, opts ...config.CallOpt) (*stakingtypes.QueryPoolResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryPoolResponse, error) {
-		return client.Staking().Pool(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Pool", req, opts, func() (*stakingtypes.QueryPoolResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Pool", req, opts, func() (*stakingtypes.QueryPoolResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryPoolResponse, error) {
+				return client.Staking().Pool(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) Redelegations(ctx context.Context, req *stakingtypes.QueryRedelegationsRequest, opts ...config.CallOpt) (*stakingtypes.QueryRedelegationsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryRedelegationsResponse, error) {
-		return client.Staking().Redelegations(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Redelegations", req, opts, func() (*stakingtypes.QueryRedelegationsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Redelegations", req, opts, func() (*stakingtypes.QueryRedelegationsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryRedelegationsResponse, error) {
+				return client.Staking().Redelegations(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) UnbondingDelegation(ctx context.Context, req *stakingtypes.QueryUnbondingDelegationRequest, opts ...config.CallOpt) (*stakingtypes.QueryUnbondingDelegationResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryUnbondingDelegationResponse, error) {
-		return client.Staking().UnbondingDelegation(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "UnbondingDelegation", req, opts, func() (*stakingtypes.QueryUnbondingDelegationResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "UnbondingDelegation", req, opts, func() (*stakingtypes.QueryUnbondingDelegationResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryUnbondingDelegationResponse, error) {
+				return client.Staking().UnbondingDelegation(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) Validator(ctx context.Context, req *stakingtypes.QueryValidatorRequest, opts ...config.CallOpt) (*stakingtypes.QueryValidatorResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryValidatorResponse, error) {
-		return client.Staking().Validator(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Validator", req, opts, func() (*stakingtypes.QueryValidatorResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Validator", req, opts, func() (*stakingtypes.QueryValidatorResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryValidatorResponse, error) {
+				return client.Staking().Validator(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) ValidatorDelegations(ctx context.Context, req *stakingtypes.QueryValidatorDelegationsRequest, opts ...config.CallOpt) (*stakingtypes.QueryValidatorDelegationsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryValidatorDelegationsResponse, error) {
-		return client.Staking().ValidatorDelegations(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "ValidatorDelegations", req, opts, func() (*stakingtypes.QueryValidatorDelegationsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "ValidatorDelegations", req, opts, func() (*stakingtypes.QueryValidatorDelegationsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryValidatorDelegationsResponse, error) {
+				return client.Staking().ValidatorDelegations(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) ValidatorUnbondingDelegations(ctx context.Context, req *stakingtypes.QueryValidatorUnbondingDelegationsRequest, opts ...config.CallOpt) (*stakingtypes.QueryValidatorUnbondingDelegationsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryValidatorUnbondingDelegationsResponse, error) {
-		return client.Staking().ValidatorUnbondingDelegations(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "ValidatorUnbondingDelegations", req, opts, func() (*stakingtypes.QueryValidatorUnbondingDelegationsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "ValidatorUnbondingDelegations", req, opts, func() (*stakingtypes.QueryValidatorUnbondingDelegationsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryValidatorUnbondingDelegationsResponse, error) {
+				return client.Staking().ValidatorUnbondingDelegations(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *StakingClientWrapper) Validators(ctx context.Context, req *stakingtypes.QueryValidatorsRequest, opts ...config.CallOpt) (*stakingtypes.QueryValidatorsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*stakingtypes.QueryValidatorsResponse, error) {
-		return client.Staking().Validators(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "staking", "Validators", req, opts, func() (*stakingtypes.QueryValidatorsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "staking", "Validators", req, opts, func() (*stakingtypes.QueryValidatorsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*stakingtypes.QueryValidatorsResponse, error) {
+				return client.Staking().Validators(ctx, req, opts...)
+			})
+		})
 	})
 }
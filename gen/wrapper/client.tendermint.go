@@ -6,63 +6,112 @@ import (
 	cmtservice "github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// TendermintClientWrapper wraps the tendermint module with pool management and retry logic
+// TendermintClientWrapper wraps the tendermint module with pool management, retry logic, response caching and request coalescing
 type TendermintClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewTendermintClientWrapper creates a new tendermint client wrapper
-func NewTendermintClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *TendermintClientWrapper {
+func NewTendermintClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *TendermintClientWrapper {
 	return &TendermintClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "tendermint").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *TendermintClientWrapper) pinned(pin *pool.Pin) *TendermintClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *TendermintClientWrapper) ABCIQuery(ctx context.Context, req *cmtservice.ABCIQueryRequest, opts ...config.CallOpt) (*cmtservice.ABCIQueryResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.ABCIQueryResponse, error) {
-		return client.Tendermint().ABCIQuery(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "ABCIQuery", req, opts, func() (*cmtservice.ABCIQueryResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "ABCIQuery", req, opts, func() (*cmtservice.ABCIQueryResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.ABCIQueryResponse, error) {
+				return client.Tendermint().ABCIQuery(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetBlockByHeight(ctx context.Context, req *cmtservice.GetBlockByHeightRequest, opts ...config.CallOpt) (*cmtservice.GetBlockByHeightResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetBlockByHeightResponse, error) {
-		return client.Tendermint().GetBlockByHeight(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetBlockByHeight", req, opts, func() (*cmtservice.GetBlockByHeightResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetBlockByHeight", req, opts, func() (*cmtservice.GetBlockByHeightResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetBlockByHeightResponse, error) {
+				return client.Tendermint().GetBlockByHeight(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetLatestBlock(ctx context.Context, req *cmtservice.GetLatestBlockRequest, opts ...config.CallOpt) (*cmtservice.GetLatestBlockResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetLatestBlockResponse, error) {
-		return client.Tendermint().GetLatestBlock(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetLatestBlock", req, opts, func() (*cmtservice.GetLatestBlockResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetLatestBlock", req, opts, func() (*cmtservice.GetLatestBlockResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetLatestBlockResponse, error) {
+				return client.Tendermint().GetLatestBlock(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetLatestValidatorSet(ctx context.Context, req *cmtservice.GetLatestValidatorSetRequest, opts ...config.CallOpt) (*cmtservice.GetLatestValidatorSetResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetLatestValidatorSetResponse, error) {
-		return client.Tendermint().GetLatestValidatorSet(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetLatestValidatorSet", req, opts, func() (*cmtservice.GetLatestValidatorSetResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetLatestValidatorSet", req, opts, func() (*cmtservice.GetLatestValidatorSetResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetLatestValidatorSetResponse, error) {
+				return client.Tendermint().GetLatestValidatorSet(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetNodeInfo(ctx context.Context, req *cmtservice.GetNodeInfoRequest, opts ...config.CallOpt) (*cmtservice.GetNodeInfoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetNodeInfoResponse, error) {
-		return client.Tendermint().GetNodeInfo(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetNodeInfo", req, opts, func() (*cmtservice.GetNodeInfoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetNodeInfo", req, opts, func() (*cmtservice.GetNodeInfoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetNodeInfoResponse, error) {
+				return client.Tendermint().GetNodeInfo(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetSyncing(ctx context.Context, req *cmtservice.GetSyncingRequest, opts ...config.CallOpt) (*cmtservice.GetSyncingResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetSyncingResponse, error) {
-		return client.Tendermint().GetSyncing(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetSyncing", req, opts, func() (*cmtservice.GetSyncingResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetSyncing", req, opts, func() (*cmtservice.GetSyncingResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetSyncingResponse, error) {
+				return client.Tendermint().GetSyncing(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TendermintClientWrapper) GetValidatorSetByHeight(ctx context.Context, req *cmtservice.GetValidatorSetByHeightRequest, opts ...config.CallOpt) (*cmtservice.GetValidatorSetByHeightResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*cmtservice.GetValidatorSetByHeightResponse, error) {
-		return client.Tendermint().GetValidatorSetByHeight(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tendermint", "GetValidatorSetByHeight", req, opts, func() (*cmtservice.GetValidatorSetByHeightResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tendermint", "GetValidatorSetByHeight", req, opts, func() (*cmtservice.GetValidatorSetByHeightResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*cmtservice.GetValidatorSetByHeightResponse, error) {
+				return client.Tendermint().GetValidatorSetByHeight(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -6,75 +6,126 @@ import (
 	tx "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/rs/zerolog"
 
+	"github.com/allora-network/allora-sdk-go/cache"
+	"github.com/allora-network/allora-sdk-go/coalesce"
 	"github.com/allora-network/allora-sdk-go/config"
 	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 	"github.com/allora-network/allora-sdk-go/pool"
 )
 
-// TxClientWrapper wraps the tx module with pool management and retry logic
+// TxClientWrapper wraps the tx module with pool management, retry logic, response caching and request coalescing
 type TxClientWrapper struct {
 	poolManager *pool.ClientPoolManager[interfaces.CosmosClient]
+	cache       *cache.Cache
+	coalescer   *coalesce.Group
+	pin         *pool.Pin
 	logger      zerolog.Logger
 }
 
 // NewTxClientWrapper creates a new tx client wrapper
-func NewTxClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], logger zerolog.Logger) *TxClientWrapper {
+func NewTxClientWrapper(poolManager *pool.ClientPoolManager[interfaces.CosmosClient], responseCache *cache.Cache, coalescer *coalesce.Group, logger zerolog.Logger) *TxClientWrapper {
 	return &TxClientWrapper{
 		poolManager: poolManager,
+		cache:       responseCache,
+		coalescer:   coalescer,
 		logger:      logger.With().Str("module", "tx").Logger(),
 	}
 }
 
+// pinned returns a copy of the wrapper whose queries run as pinned by pin
+func (c *TxClientWrapper) pinned(pin *pool.Pin) *TxClientWrapper {
+	pinned := *c
+	pinned.pin = pin
+	return &pinned
+}
+
 func (c *TxClientWrapper) BroadcastTx(ctx context.Context, req *tx.BroadcastTxRequest, opts ...config.CallOpt) (*tx.BroadcastTxResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.BroadcastTxResponse, error) {
+	// Transactions are neither cached, coalesced nor hedged: a hedge would send them twice
+	return pool.ExecuteWithRetry(pool.WithoutHedging(ctx), c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.BroadcastTxResponse, error) {
 		return client.Tx().BroadcastTx(ctx, req, opts...)
 	})
 }
 
 func (c *TxClientWrapper) GetBlockWithTxs(ctx context.Context, req *tx.GetBlockWithTxsRequest, opts ...config.CallOpt) (*tx.GetBlockWithTxsResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.GetBlockWithTxsResponse, error) {
-		return client.Tx().GetBlockWithTxs(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "GetBlockWithTxs", req, opts, func() (*tx.GetBlockWithTxsResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "GetBlockWithTxs", req, opts, func() (*tx.GetBlockWithTxsResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.GetBlockWithTxsResponse, error) {
+				return client.Tx().GetBlockWithTxs(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) GetTx(ctx context.Context, req *tx.GetTxRequest, opts ...config.CallOpt) (*tx.GetTxResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.GetTxResponse, error) {
-		return client.Tx().GetTx(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "GetTx", req, opts, func() (*tx.GetTxResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "GetTx", req, opts, func() (*tx.GetTxResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.GetTxResponse, error) {
+				return client.Tx().GetTx(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) GetTxsEvent(ctx context.Context, req *tx.GetTxsEventRequest, opts ...config.CallOpt) (*tx.GetTxsEventResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.GetTxsEventResponse, error) {
-		return client.Tx().GetTxsEvent(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "GetTxsEvent", req, opts, func() (*tx.GetTxsEventResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "GetTxsEvent", req, opts, func() (*tx.GetTxsEventResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.GetTxsEventResponse, error) {
+				return client.Tx().GetTxsEvent(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) Simulate(ctx context.Context, req *tx.SimulateRequest, opts ...config.CallOpt) (*tx.SimulateResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.SimulateResponse, error) {
+	// Transactions are neither cached, coalesced nor hedged: a hedge would send them twice
+	return pool.ExecuteWithRetry(pool.WithoutHedging(ctx), c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.SimulateResponse, error) {
 		return client.Tx().Simulate(ctx, req, opts...)
 	})
 }
 
 func (c *TxClientWrapper) TxDecode(ctx context.Context, req *tx.TxDecodeRequest, opts ...config.CallOpt) (*tx.TxDecodeResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.TxDecodeResponse, error) {
-		return client.Tx().TxDecode(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "TxDecode", req, opts, func() (*tx.TxDecodeResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "TxDecode", req, opts, func() (*tx.TxDecodeResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.TxDecodeResponse, error) {
+				return client.Tx().TxDecode(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) TxDecodeAmino(ctx context.Context, req *tx.TxDecodeAminoRequest, opts ...config.CallOpt) (*tx.TxDecodeAminoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.TxDecodeAminoResponse, error) {
-		return client.Tx().TxDecodeAmino(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "TxDecodeAmino", req, opts, func() (*tx.TxDecodeAminoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "TxDecodeAmino", req, opts, func() (*tx.TxDecodeAminoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.TxDecodeAminoResponse, error) {
+				return client.Tx().TxDecodeAmino(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) TxEncode(ctx context.Context, req *tx.TxEncodeRequest, opts ...config.CallOpt) (*tx.TxEncodeResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.TxEncodeResponse, error) {
-		return client.Tx().TxEncode(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "TxEncode", req, opts, func() (*tx.TxEncodeResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "TxEncode", req, opts, func() (*tx.TxEncodeResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.TxEncodeResponse, error) {
+				return client.Tx().TxEncode(ctx, req, opts...)
+			})
+		})
 	})
 }
 
 func (c *TxClientWrapper) TxEncodeAmino(ctx context.Context, req *tx.TxEncodeAminoRequest, opts ...config.CallOpt) (*tx.TxEncodeAminoResponse, error) {
-	return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(client interfaces.CosmosClient) (*tx.TxEncodeAminoResponse, error) {
-		return client.Tx().TxEncodeAmino(ctx, req, opts...)
+	ctx, opts = c.pin.Apply(ctx, opts)
+	return cache.Query(c.cache, "tx", "TxEncodeAmino", req, opts, func() (*tx.TxEncodeAminoResponse, error) {
+		return coalesce.Do(ctx, c.coalescer, "tx", "TxEncodeAmino", req, opts, func() (*tx.TxEncodeAminoResponse, error) {
+			return pool.ExecuteWithRetry(ctx, c.poolManager, &c.logger, func(ctx context.Context, client interfaces.CosmosClient) (*tx.TxEncodeAminoResponse, error) {
+				return client.Tx().TxEncodeAmino(ctx, req, opts...)
+			})
+		})
 	})
 }
@@ -0,0 +1,150 @@
+package allora
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// DiscoverAddresses stops walking an account (as in BIP44)
+const DefaultGapLimit = 20
+
+// HDPath returns the BIP44 derivation path m/44'/118'/account'/0/index
+func HDPath(account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", DefaultBIP44CoinType, account, index)
+}
+
+// NewWalletFromMnemonicAt derives the wallet at the given account and address index
+func NewWalletFromMnemonicAt(mnemonic string, account, index uint32) (*Wallet, error) {
+	return NewWalletFromMnemonic(mnemonic, HDPath(account, index))
+}
+
+// DerivedWallet is a wallet together with its position in the HD tree
+type DerivedWallet struct {
+	*Wallet
+	Account uint32
+	Index   uint32
+	HDPath  string
+}
+
+// DeriveWallets derives count consecutive wallets of an account, starting at address index start
+//
+// Example:
+//
+//	// Deposit addresses 0..99 of account 0
+//	wallets, err := allora.DeriveWallets(mnemonic, 0, 0, 100)
+//	for _, w := range wallets {
+//	    fmt.Println(w.HDPath, w.GetAddress())
+//	}
+func DeriveWallets(mnemonic string, account, start, count uint32) ([]DerivedWallet, error) {
+	if uint64(start)+uint64(count) > 1<<31 {
+		return nil, fmt.Errorf("address index range [%d, %d) exceeds the non-hardened range", start, uint64(start)+uint64(count))
+	}
+
+	wallets := make([]DerivedWallet, 0, count)
+	for i := start; i < start+count; i++ {
+		wallet, err := deriveWallet(mnemonic, account, i)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, nil
+}
+
+func deriveWallet(mnemonic string, account, index uint32) (DerivedWallet, error) {
+	path := HDPath(account, index)
+	wallet, err := NewWalletFromMnemonic(mnemonic, path)
+	if err != nil {
+		return DerivedWallet{}, fmt.Errorf("failed to derive %s: %w", path, err)
+	}
+	return DerivedWallet{Wallet: wallet, Account: account, Index: index, HDPath: path}, nil
+}
+
+// AddressActivity describes the on-chain state of a derived address
+type AddressActivity struct {
+	DerivedWallet
+	Balances      sdk.Coins
+	AccountExists bool
+}
+
+// Used reports whether the address has on-chain history, i.e. it holds a balance
+// or the chain has created an account for it
+func (a AddressActivity) Used() bool {
+	return a.AccountExists || !a.Balances.IsZero()
+}
+
+// DiscoverAddresses walks the address indices of an account, starting at 0, and returns
+// every derived address with on-chain history. The walk stops once gapLimit consecutive
+// addresses are unused; a gapLimit of 0 uses DefaultGapLimit.
+//
+// An address counts as used when Bank().AllBalances reports a non-zero balance or
+// Auth().Account finds an account for it (accounts are created on first receipt of funds
+// and are never removed, so emptied addresses are still found).
+//
+// Example:
+//
+//	used, err := allora.DiscoverAddresses(ctx, client, mnemonic, 0, 0)
+//	for _, a := range used {
+//	    fmt.Printf("%s %s %s\n", a.HDPath, a.GetAddress(), a.Balances)
+//	}
+func DiscoverAddresses(ctx context.Context, client Client, mnemonic string, account, gapLimit uint32) ([]AddressActivity, error) {
+	return discoverAddresses(ctx, mnemonic, account, gapLimit, func(ctx context.Context, addr sdk.AccAddress) (sdk.Coins, bool, error) {
+		return queryAddressActivity(ctx, client, addr)
+	})
+}
+
+type activityFunc func(ctx context.Context, addr sdk.AccAddress) (balances sdk.Coins, accountExists bool, err error)
+
+func discoverAddresses(ctx context.Context, mnemonic string, account, gapLimit uint32, activity activityFunc) ([]AddressActivity, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	var used []AddressActivity
+	var gap uint32
+	for index := uint32(0); gap < gapLimit && index < 1<<31; index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		wallet, err := deriveWallet(mnemonic, account, index)
+		if err != nil {
+			return nil, err
+		}
+
+		balances, exists, err := activity(ctx, wallet.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s (%s): %w", wallet.GetAddress(), wallet.HDPath, err)
+		}
+
+		a := AddressActivity{DerivedWallet: wallet, Balances: balances, AccountExists: exists}
+		if !a.Used() {
+			gap++
+			continue
+		}
+		gap = 0
+		used = append(used, a)
+	}
+	return used, nil
+}
+
+func queryAddressActivity(ctx context.Context, client Client, addr sdk.AccAddress) (sdk.Coins, bool, error) {
+	balResp, err := client.Cosmos().Bank().AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: FormatAccAddress(addr)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query balances: %w", err)
+	}
+
+	_, err = client.Cosmos().Auth().Account(ctx, &authtypes.QueryAccountRequest{Address: FormatAccAddress(addr)})
+	if err != nil {
+		if isNotFound(err) {
+			return balResp.Balances, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to query account: %w", err)
+	}
+	return balResp.Balances, true, nil
+}
@@ -0,0 +1,90 @@
+package allora
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestHDPath(t *testing.T) {
+	if got := HDPath(0, 0); got != DefaultHDPath {
+		t.Fatalf("expected %s, got %s", DefaultHDPath, got)
+	}
+	if got := HDPath(3, 17); got != "m/44'/118'/3'/0/17" {
+		t.Fatalf("unexpected path %s", got)
+	}
+}
+
+func TestDeriveWallets(t *testing.T) {
+	wallets, err := DeriveWallets(testMnemonic, 1, 5, 3)
+	if err != nil {
+		t.Fatalf("failed to derive wallets: %v", err)
+	}
+	if len(wallets) != 3 {
+		t.Fatalf("expected 3 wallets, got %d", len(wallets))
+	}
+
+	seen := map[string]bool{}
+	for i, w := range wallets {
+		if w.Account != 1 || w.Index != uint32(5+i) || w.HDPath != HDPath(1, uint32(5+i)) {
+			t.Fatalf("unexpected position %d/%d %s", w.Account, w.Index, w.HDPath)
+		}
+		single, err := NewWalletFromMnemonicAt(testMnemonic, 1, w.Index)
+		if err != nil {
+			t.Fatalf("failed to derive wallet: %v", err)
+		}
+		if single.GetAddress() != w.GetAddress() {
+			t.Fatalf("range derivation mismatch at index %d", w.Index)
+		}
+		seen[w.GetAddress()] = true
+	}
+	if len(seen) != 3 {
+		t.Fatal("expected distinct addresses")
+	}
+
+	if _, err := DeriveWallets(testMnemonic, 0, 1<<31-1, 2); err == nil {
+		t.Fatal("expected hardened index range to be rejected")
+	}
+}
+
+func TestDiscoverAddressesGapLimit(t *testing.T) {
+	wallets, err := DeriveWallets(testMnemonic, 0, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to derive wallets: %v", err)
+	}
+
+	// Index 0 holds funds, index 2 was emptied (account exists), index 6 is beyond the gap
+	funded := map[string]sdk.Coins{wallets[0].GetAddress(): sdk.NewCoins(sdk.NewInt64Coin("uallo", 10))}
+	accounts := map[string]bool{wallets[0].GetAddress(): true, wallets[2].GetAddress(): true, wallets[6].GetAddress(): true}
+
+	var queried int
+	used, err := discoverAddresses(context.Background(), testMnemonic, 0, 3, func(_ context.Context, addr sdk.AccAddress) (sdk.Coins, bool, error) {
+		queried++
+		return funded[FormatAccAddress(addr)], accounts[FormatAccAddress(addr)], nil
+	})
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	if len(used) != 2 || used[0].Index != 0 || used[1].Index != 2 {
+		t.Fatalf("unexpected discovered addresses: %+v", used)
+	}
+	if !used[0].Balances.Equal(funded[wallets[0].GetAddress()]) || !used[1].AccountExists {
+		t.Fatal("expected activity to be recorded")
+	}
+	// Indices 3, 4, 5 are unused, so the walk stops before index 6
+	if queried != 6 {
+		t.Fatalf("expected 6 queries, got %d", queried)
+	}
+
+	queryErr := errors.New("unavailable")
+	_, err = discoverAddresses(context.Background(), testMnemonic, 0, 3, func(context.Context, sdk.AccAddress) (sdk.Coins, bool, error) {
+		return nil, false, queryErr
+	})
+	if !errors.Is(err, queryErr) {
+		t.Fatalf("expected query error, got %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+// Package indexer walks the chain's blocks over Tendermint RPC, decodes their
+// transactions and stores blocks, transactions, messages and events in a Store.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+
+	allora "github.com/allora-network/allora-sdk-go"
+	"github.com/allora-network/allora-sdk-go/codec"
+	"github.com/allora-network/allora-sdk-go/tmrpc"
+)
+
+// BlockTxIndex is the tx index of events emitted during finalize-block rather than
+// by a transaction
+const BlockTxIndex = -1
+
+// Block is an indexed block with its transactions and finalize-block events
+type Block struct {
+	Height   int64
+	Hash     string
+	Time     time.Time
+	ChainID  string
+	Proposer string
+	AppHash  string
+	Txs      []Tx
+	Events   []Event
+}
+
+// Tx is an indexed transaction with its execution result
+type Tx struct {
+	Hash      string
+	Height    int64
+	Index     int
+	Code      uint32
+	Codespace string
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+	Memo      string
+	Fee       string
+	Msgs      []Msg
+	Events    []Event
+}
+
+// Msg is a transaction message encoded as proto JSON
+type Msg struct {
+	Index   int
+	TypeURL string
+	JSON    string
+}
+
+// Event is an ABCI event emitted by a transaction or during finalize-block
+type Event struct {
+	Index      int
+	Type       string
+	Attributes []EventAttribute
+}
+
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Store persists indexed blocks. SaveBlock is called in height order.
+type Store interface {
+	// LastHeight returns the highest stored height, or 0 if the store is empty
+	LastHeight(ctx context.Context) (int64, error)
+	// SaveBlock stores the block with its transactions, messages and events atomically.
+	// Saving a height again replaces it.
+	SaveBlock(ctx context.Context, block *Block) error
+	Close() error
+}
+
+// Opt is a functional option for configuring an Indexer
+type Opt func(*Opts)
+
+// Opts holds configuration options for an Indexer
+type Opts struct {
+	StartHeight  int64         // first height to index when the store is empty; 1 by default
+	Concurrency  int           // blocks fetched in parallel
+	PollInterval time.Duration // how often to check for new blocks once caught up
+}
+
+// Apply applies the provided options to Opts
+func (o *Opts) Apply(opts ...Opt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultOpts returns default indexer options
+func DefaultOpts() *Opts {
+	return &Opts{
+		StartHeight:  1,
+		Concurrency:  8,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// WithStartHeight sets the first height to index when the store is empty, e.g. the
+// earliest height a pruned node still serves
+func WithStartHeight(height int64) Opt {
+	return func(opts *Opts) {
+		opts.StartHeight = height
+	}
+}
+
+// WithConcurrency sets how many blocks are fetched in parallel
+func WithConcurrency(n int) Opt {
+	return func(opts *Opts) {
+		opts.Concurrency = n
+	}
+}
+
+// WithPollInterval sets how often the indexer checks for new blocks once caught up
+func WithPollInterval(interval time.Duration) Opt {
+	return func(opts *Opts) {
+		opts.PollInterval = interval
+	}
+}
+
+// Indexer fetches blocks concurrently and commits them to the store in height order,
+// so that the store always holds a gapless prefix of the chain and indexing resumes
+// after the last stored height.
+type Indexer struct {
+	rpc    tmrpc.ClientPool
+	store  Store
+	opts   *Opts
+	logger zerolog.Logger
+}
+
+func New(rpc tmrpc.ClientPool, store Store, logger zerolog.Logger, opts ...Opt) *Indexer {
+	o := DefaultOpts()
+	o.Apply(opts...)
+	o.Concurrency = max(o.Concurrency, 1)
+	o.StartHeight = max(o.StartHeight, 1)
+
+	return &Indexer{
+		rpc:    rpc,
+		store:  store,
+		opts:   o,
+		logger: logger.With().Str("component", "indexer").Logger(),
+	}
+}
+
+// Run indexes from the last stored height up to the chain head, then follows new
+// blocks until ctx is done. Failed fetches are retried from the last stored height.
+func (ix *Indexer) Run(ctx context.Context) error {
+	for {
+		caughtUp, err := ix.catchUp(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			ix.logger.Error().Err(err).Msg("indexing failed, retrying")
+		}
+		if caughtUp || err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(ix.opts.PollInterval):
+			}
+		}
+	}
+}
+
+// catchUp indexes up to the current chain head and reports whether there was nothing
+// left to index
+func (ix *Indexer) catchUp(ctx context.Context) (bool, error) {
+	last, err := ix.store.LastHeight(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get last indexed height: %w", err)
+	}
+	status, err := ix.rpc.Status(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get chain status: %w", err)
+	}
+
+	from := max(last+1, ix.opts.StartHeight)
+	to := status.SyncInfo.LatestBlockHeight
+	if from > to {
+		return true, nil
+	}
+	return false, ix.IndexRange(ctx, from, to)
+}
+
+// IndexRange fetches the blocks from..to (inclusive) concurrently and saves them in
+// order. It stops at the first error; the blocks before it are saved.
+func (ix *Indexer) IndexRange(ctx context.Context, from, to int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type fetched struct {
+		block *Block
+		err   error
+	}
+
+	// Futures are queued in height order; the buffer bounds the blocks in flight
+	pending := make(chan chan fetched, ix.opts.Concurrency)
+	go func() {
+		defer close(pending)
+		for height := from; height <= to; height++ {
+			future := make(chan fetched, 1)
+			select {
+			case pending <- future:
+			case <-ctx.Done():
+				return
+			}
+			go func(height int64) {
+				block, err := ix.fetchBlock(ctx, height)
+				future <- fetched{block, err}
+			}(height)
+		}
+	}()
+
+	for future := range pending {
+		res := <-future
+		if res.err != nil {
+			return res.err
+		}
+		if err := ix.store.SaveBlock(ctx, res.block); err != nil {
+			return fmt.Errorf("failed to save block %d: %w", res.block.Height, err)
+		}
+		ix.logger.Debug().Int64("height", res.block.Height).Int("txs", len(res.block.Txs)).Msg("indexed block")
+	}
+	return ctx.Err()
+}
+
+func (ix *Indexer) fetchBlock(ctx context.Context, height int64) (*Block, error) {
+	block, err := ix.rpc.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+	results, err := ix.rpc.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block results %d: %w", height, err)
+	}
+	return DecodeBlock(block, results)
+}
+
+// DecodeBlock builds a Block from the results of the Block and BlockResults RPCs.
+// Transactions that cannot be decoded are kept with their results but without messages.
+func DecodeBlock(block *coretypes.ResultBlock, results *coretypes.ResultBlockResults) (*Block, error) {
+	if block.Block == nil {
+		return nil, errors.New("block is missing")
+	}
+	header := block.Block.Header
+	if len(results.TxsResults) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("block %d has %d txs but %d tx results", header.Height, len(block.Block.Txs), len(results.TxsResults))
+	}
+
+	b := &Block{
+		Height:   header.Height,
+		Hash:     block.BlockID.Hash.String(),
+		Time:     header.Time,
+		ChainID:  header.ChainID,
+		Proposer: header.ProposerAddress.String(),
+		AppHash:  header.AppHash.String(),
+		Events:   decodeEvents(results.FinalizeBlockEvents),
+	}
+	for i, txBytes := range block.Block.Txs {
+		b.Txs = append(b.Txs, decodeTx(header.Height, i, txBytes, results.TxsResults[i]))
+	}
+	return b, nil
+}
+
+func decodeTx(height int64, index int, txBytes []byte, result *abcitypes.ExecTxResult) Tx {
+	tx := Tx{
+		Hash:      allora.TxHash(txBytes),
+		Height:    height,
+		Index:     index,
+		Code:      result.Code,
+		Codespace: result.Codespace,
+		Log:       result.Log,
+		GasWanted: result.GasWanted,
+		GasUsed:   result.GasUsed,
+		Events:    decodeEvents(result.Events),
+	}
+
+	decoded, err := allora.ParseTxBytes(txBytes)
+	if err != nil {
+		return tx
+	}
+	if memoTx, ok := (*decoded).(sdk.TxWithMemo); ok {
+		tx.Memo = memoTx.GetMemo()
+	}
+	if feeTx, ok := (*decoded).(sdk.FeeTx); ok {
+		tx.Fee = feeTx.GetFee().String()
+	}
+	for i, msg := range (*decoded).GetMsgs() {
+		m := Msg{Index: i, TypeURL: sdk.MsgTypeURL(msg)}
+		if bz, err := codec.CosmosCodec().MarshalJSON(msg); err == nil {
+			m.JSON = string(bz)
+		}
+		tx.Msgs = append(tx.Msgs, m)
+	}
+	return tx
+}
+
+func decodeEvents(events []abcitypes.Event) []Event {
+	decoded := make([]Event, 0, len(events))
+	for i, evt := range events {
+		e := Event{Index: i, Type: evt.Type}
+		for _, attr := range evt.Attributes {
+			e.Attributes = append(e.Attributes, EventAttribute{Key: attr.Key, Value: attr.Value})
+		}
+		decoded = append(decoded, e)
+	}
+	return decoded
+}
@@ -0,0 +1,171 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+
+	allora "github.com/allora-network/allora-sdk-go"
+	"github.com/allora-network/allora-sdk-go/indexer"
+	"github.com/allora-network/allora-sdk-go/indexer/sqlite"
+	"github.com/allora-network/allora-sdk-go/tmrpc"
+)
+
+// fakeChain serves blocks up to latest with random latency; every block carries one
+// send transaction. Heights in failOnce fail on their first request.
+type fakeChain struct {
+	tmrpc.ClientPool
+
+	txBytes []byte
+
+	mu       sync.Mutex
+	latest   int64
+	failOnce map[int64]bool
+}
+
+func newFakeChain(t *testing.T, latest int64) *fakeChain {
+	t.Helper()
+
+	from, err := allora.GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	to, err := allora.GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	txBytes, err := allora.CreateUnsignedSendTx(from.Address, to.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000)), &allora.TxParams{
+		ChainID:   "allora-testnet-1",
+		GasLimit:  200000,
+		FeeAmount: sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+		Memo:      "indexed",
+	})
+	if err != nil {
+		t.Fatalf("failed to create tx: %v", err)
+	}
+	return &fakeChain{txBytes: txBytes, latest: latest, failOnce: make(map[int64]bool)}
+}
+
+func (c *fakeChain) Status(context.Context) (*coretypes.ResultStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *fakeChain) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	time.Sleep(time.Duration(rand.IntN(5)) * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failOnce[*height] {
+		delete(c.failOnce, *height)
+		return nil, errors.New("connection reset")
+	}
+	return &coretypes.ResultBlock{Block: &ctypes.Block{
+		Header: ctypes.Header{ChainID: "allora-testnet-1", Height: *height, Time: time.Unix(*height, 0)},
+		Data:   ctypes.Data{Txs: ctypes.Txs{c.txBytes}},
+	}}, nil
+}
+
+func (c *fakeChain) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{
+		Height: *height,
+		TxsResults: []*abcitypes.ExecTxResult{{
+			GasUsed: 1000,
+			Events:  []abcitypes.Event{{Type: "transfer", Attributes: []abcitypes.EventAttribute{{Key: "amount", Value: "1000uallo"}}}},
+		}},
+		FinalizeBlockEvents: []abcitypes.Event{{Type: "mint"}},
+	}, nil
+}
+
+// recordingStore records the order in which blocks are saved
+type recordingStore struct {
+	*sqlite.Store
+
+	mu      sync.Mutex
+	heights []int64
+}
+
+func (s *recordingStore) SaveBlock(ctx context.Context, block *indexer.Block) error {
+	s.mu.Lock()
+	s.heights = append(s.heights, block.Height)
+	s.mu.Unlock()
+	return s.Store.SaveBlock(ctx, block)
+}
+
+func TestIndexerCommitsInOrderAndResumes(t *testing.T) {
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer db.Close()
+	store := &recordingStore{Store: db}
+
+	chain := newFakeChain(t, 10)
+	ix := indexer.New(chain, store, zerolog.Nop(), indexer.WithConcurrency(4), indexer.WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := ix.IndexRange(ctx, 1, 10); err != nil {
+		t.Fatalf("failed to index: %v", err)
+	}
+
+	// The chain advances and one fetch fails; Run resumes after the last saved height
+	chain.mu.Lock()
+	chain.latest = 25
+	chain.failOnce[18] = true
+	chain.mu.Unlock()
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- ix.Run(runCtx) }()
+	for {
+		if last, err := store.LastHeight(ctx); err == nil && last == 25 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the indexer to catch up")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	stop()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected Run error %v", err)
+	}
+
+	store.mu.Lock()
+	for i, height := range store.heights {
+		if height != int64(i+1) {
+			t.Fatalf("blocks saved out of order: %v", store.heights)
+		}
+	}
+	store.mu.Unlock()
+
+	var txs, msgs, events int
+	var memo, hash string
+	row := db.DB().QueryRow(`SELECT COUNT(*), MAX(memo), MAX(hash) FROM txs`)
+	if err := row.Scan(&txs, &memo, &hash); err != nil {
+		t.Fatalf("failed to query txs: %v", err)
+	}
+	if txs != 25 || memo != "indexed" || hash != allora.TxHash(chain.txBytes) {
+		t.Fatalf("unexpected txs: %d %q %s", txs, memo, hash)
+	}
+	if err := db.DB().QueryRow(`SELECT COUNT(*) FROM messages WHERE type_url = '/cosmos.bank.v1beta1.MsgSend'`).Scan(&msgs); err != nil || msgs != 25 {
+		t.Fatalf("expected 25 decoded messages, got %d (%v)", msgs, err)
+	}
+	if err := db.DB().QueryRow(`SELECT COUNT(*) FROM events WHERE tx_index = ?`, indexer.BlockTxIndex).Scan(&events); err != nil || events != 25 {
+		t.Fatalf("expected 25 finalize-block events, got %d (%v)", events, err)
+	}
+}
@@ -0,0 +1,168 @@
+// Package sqlite implements indexer.Store with an embedded SQLite database.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+
+	"github.com/allora-network/allora-sdk-go/indexer"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	height   INTEGER PRIMARY KEY,
+	hash     TEXT NOT NULL,
+	time     INTEGER NOT NULL, -- unix nanoseconds
+	chain_id TEXT NOT NULL,
+	proposer TEXT NOT NULL,
+	app_hash TEXT NOT NULL,
+	num_txs  INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS txs (
+	height     INTEGER NOT NULL,
+	tx_index   INTEGER NOT NULL,
+	hash       TEXT NOT NULL,
+	code       INTEGER NOT NULL,
+	codespace  TEXT NOT NULL,
+	log        TEXT NOT NULL,
+	gas_wanted INTEGER NOT NULL,
+	gas_used   INTEGER NOT NULL,
+	memo       TEXT NOT NULL,
+	fee        TEXT NOT NULL,
+	PRIMARY KEY (height, tx_index)
+);
+CREATE INDEX IF NOT EXISTS txs_hash ON txs (hash);
+
+CREATE TABLE IF NOT EXISTS messages (
+	height    INTEGER NOT NULL,
+	tx_index  INTEGER NOT NULL,
+	msg_index INTEGER NOT NULL,
+	type_url  TEXT NOT NULL,
+	json      TEXT NOT NULL,
+	PRIMARY KEY (height, tx_index, msg_index)
+);
+CREATE INDEX IF NOT EXISTS messages_type_url ON messages (type_url, height);
+
+CREATE TABLE IF NOT EXISTS events (
+	height      INTEGER NOT NULL,
+	tx_index    INTEGER NOT NULL, -- -1 for finalize-block events
+	event_index INTEGER NOT NULL,
+	type        TEXT NOT NULL,
+	attributes  TEXT NOT NULL, -- JSON array of {"key", "value"}
+	PRIMARY KEY (height, tx_index, event_index)
+);
+CREATE INDEX IF NOT EXISTS events_type ON events (type, height);
+`
+
+// Store is an indexer.Store backed by a SQLite database file
+type Store struct {
+	db *sql.DB
+}
+
+var _ indexer.Store = (*Store)(nil)
+
+// Open opens or creates the database at path and migrates its schema. Use ":memory:"
+// for a throwaway in-memory database.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// SQLite allows a single writer; this also keeps ":memory:" on one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// DB returns the underlying database, e.g. to query the indexed data
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) LastHeight(ctx context.Context) (int64, error) {
+	var height int64
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(height), 0) FROM blocks`).Scan(&height)
+	return height, err
+}
+
+func (s *Store) SaveBlock(ctx context.Context, block *indexer.Block) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, table := range []string{"blocks", "txs", "messages", "events"} {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE height = ?`, block.Height); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO blocks (height, hash, time, chain_id, proposer, app_hash, num_txs) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		block.Height, block.Hash, block.Time.UnixNano(), block.ChainID, block.Proposer, block.AppHash, len(block.Txs))
+	if err != nil {
+		return fmt.Errorf("failed to insert block: %w", err)
+	}
+
+	for _, t := range block.Txs {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO txs (height, tx_index, hash, code, codespace, log, gas_wanted, gas_used, memo, fee) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			block.Height, t.Index, t.Hash, t.Code, t.Codespace, t.Log, t.GasWanted, t.GasUsed, t.Memo, t.Fee)
+		if err != nil {
+			return fmt.Errorf("failed to insert tx %s: %w", t.Hash, err)
+		}
+		for _, msg := range t.Msgs {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO messages (height, tx_index, msg_index, type_url, json) VALUES (?, ?, ?, ?, ?)`,
+				block.Height, t.Index, msg.Index, msg.TypeURL, msg.JSON)
+			if err != nil {
+				return fmt.Errorf("failed to insert message %d of tx %s: %w", msg.Index, t.Hash, err)
+			}
+		}
+		if err = insertEvents(ctx, tx, block.Height, t.Index, t.Events); err != nil {
+			return err
+		}
+	}
+	if err = insertEvents(ctx, tx, block.Height, indexer.BlockTxIndex, block.Events); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertEvents(ctx context.Context, tx *sql.Tx, height int64, txIndex int, events []indexer.Event) error {
+	for _, evt := range events {
+		attrs := evt.Attributes
+		if attrs == nil {
+			attrs = []indexer.EventAttribute{}
+		}
+		bz, err := json.Marshal(attrs)
+		if err != nil {
+			return fmt.Errorf("failed to encode attributes of %s: %w", evt.Type, err)
+		}
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO events (height, tx_index, event_index, type, attributes) VALUES (?, ?, ?, ?, ?)`,
+			height, txIndex, evt.Index, evt.Type, string(bz))
+		if err != nil {
+			return fmt.Errorf("failed to insert event %s: %w", evt.Type, err)
+		}
+	}
+	return nil
+}
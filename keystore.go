@@ -0,0 +1,267 @@
+package allora
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+)
+
+var (
+	// ErrKeyNotFound is returned when a named key does not exist in the keystore
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrKeyExists is returned when storing a key under a name that is already taken
+	ErrKeyExists = errors.New("key already exists")
+)
+
+const keystoreFileExt = ".key.json"
+
+var keyNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ExportArmor encrypts the wallet's private key with the passphrase and returns it
+// in the ASCII-armored format used by `allorad keys export` / `keys import`
+func (w *Wallet) ExportArmor(passphrase string) (string, error) {
+	if w.PrivKey == nil {
+		return "", ErrWalletClosed
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase is required")
+	}
+	return crypto.EncryptArmorPrivKey(w.PrivKey, passphrase, string(hd.Secp256k1Type)), nil
+}
+
+// NewWalletFromArmor decrypts an ASCII-armored private key, such as the output of
+// `allorad keys export`, and returns the corresponding wallet
+func NewWalletFromArmor(armor, passphrase string) (*Wallet, error) {
+	privKey, algo, err := crypto.UnarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt armored private key: %w", err)
+	}
+	if algo != string(hd.Secp256k1Type) {
+		return nil, fmt.Errorf("unsupported key algorithm %q", algo)
+	}
+	if _, ok := privKey.(*secp256k1.PrivKey); !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", privKey)
+	}
+	return newWalletFromPrivKey(privKey, "")
+}
+
+// KeyInfo describes a key stored in a Keystore. It is readable without the passphrase.
+type KeyInfo struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	PubKey  string `json:"pubkey"` // hex-encoded compressed secp256k1 public key
+}
+
+// keystoreFile is the on-disk representation of a stored key
+type keystoreFile struct {
+	KeyInfo
+	Armor string `json:"armor"`
+}
+
+// Keystore stores named wallets on disk, encrypted with a passphrase
+//
+// Each key is kept in its own file (mode 0600) holding the key's name, address and
+// public key in clear text and the private key in the Cosmos SDK armored format
+// (argon2/bcrypt key derivation with xsalsa20-poly1305), the same format produced by
+// `allorad keys export`. Mnemonics are never persisted.
+//
+// Example:
+//
+//	ks, err := allora.NewKeystore("/var/lib/exchange/keys")
+//
+//	err = ks.Store("hot-wallet", wallet, passphrase)
+//	wallet, err = ks.Load("hot-wallet", passphrase)
+//	defer wallet.Close()
+type Keystore struct {
+	dir string
+}
+
+// NewKeystore opens the keystore in dir, creating the directory (mode 0700) if needed
+func NewKeystore(dir string) (*Keystore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("keystore directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+	return &Keystore{dir: dir}, nil
+}
+
+// Store encrypts the wallet's private key with the passphrase and saves it under name
+func (k *Keystore) Store(name string, wallet *Wallet, passphrase string) error {
+	if wallet == nil {
+		return fmt.Errorf("wallet is required")
+	}
+	armor, err := wallet.ExportArmor(passphrase)
+	if err != nil {
+		return err
+	}
+	return k.write(name, wallet, armor)
+}
+
+// Load decrypts the key stored under name and returns it as a wallet. Close the
+// wallet when done to wipe the key from memory.
+func (k *Keystore) Load(name, passphrase string) (*Wallet, error) {
+	f, err := k.read(name)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := NewWalletFromArmor(f.Armor, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key %q: %w", name, err)
+	}
+	if wallet.GetAddress() != f.Address {
+		wallet.Close()
+		return nil, fmt.Errorf("key %q does not match its recorded address %s", name, f.Address)
+	}
+	return wallet, nil
+}
+
+// Get returns the public information of the key stored under name
+func (k *Keystore) Get(name string) (KeyInfo, error) {
+	f, err := k.read(name)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	return f.KeyInfo, nil
+}
+
+// List returns the public information of all stored keys, sorted by name
+func (k *Keystore) List() ([]KeyInfo, error) {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore directory: %w", err)
+	}
+
+	var infos []KeyInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keystoreFileExt) {
+			continue
+		}
+		f, err := k.read(strings.TrimSuffix(entry.Name(), keystoreFileExt))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, f.KeyInfo)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// Delete removes the key stored under name
+func (k *Keystore) Delete(name string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%q: %w", name, ErrKeyNotFound)
+		}
+		return fmt.Errorf("failed to delete key %q: %w", name, err)
+	}
+	return nil
+}
+
+// Import stores an ASCII-armored private key (e.g. from `allorad keys export`)
+// under name. The key is kept encrypted with the passphrase it was exported with.
+func (k *Keystore) Import(name, armor, passphrase string) (KeyInfo, error) {
+	wallet, err := NewWalletFromArmor(armor, passphrase)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	defer wallet.Close()
+
+	if err := k.write(name, wallet, armor); err != nil {
+		return KeyInfo{}, err
+	}
+	return k.Get(name)
+}
+
+// Export returns the key stored under name in the ASCII-armored format, re-encrypted
+// with exportPassphrase, suitable for `allorad keys import`
+func (k *Keystore) Export(name, passphrase, exportPassphrase string) (string, error) {
+	wallet, err := k.Load(name, passphrase)
+	if err != nil {
+		return "", err
+	}
+	defer wallet.Close()
+
+	return wallet.ExportArmor(exportPassphrase)
+}
+
+func (k *Keystore) path(name string) (string, error) {
+	if !keyNameRegexp.MatchString(name) {
+		return "", fmt.Errorf("invalid key name %q", name)
+	}
+	return filepath.Join(k.dir, name+keystoreFileExt), nil
+}
+
+func (k *Keystore) read(name string) (*keystoreFile, error) {
+	path, err := k.path(name)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%q: %w", name, ErrKeyNotFound)
+		}
+		return nil, fmt.Errorf("failed to read key %q: %w", name, err)
+	}
+
+	var f keystoreFile
+	if err := json.Unmarshal(bz, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode key %q: %w", name, err)
+	}
+	return &f, nil
+}
+
+func (k *Keystore) write(name string, wallet *Wallet, armor string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(keystoreFile{
+		KeyInfo: KeyInfo{
+			Name:    name,
+			Address: FormatAccAddress(wallet.Address),
+			PubKey:  hex.EncodeToString(wallet.PubKey.Bytes()),
+		},
+		Armor: armor,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key %q: %w", name, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%q: %w", name, ErrKeyExists)
+		}
+		return fmt.Errorf("failed to create key file: %w", err)
+	}
+	if _, err := file.Write(bz); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write key %q: %w", name, err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write key %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package allora
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeystoreStoreLoadListDelete(t *testing.T) {
+	ks, err := NewKeystore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open keystore: %v", err)
+	}
+
+	alice, _ := GenerateWallet()
+	bob, _ := GenerateWallet()
+	if err := ks.Store("bob", bob, "bob-pass"); err != nil {
+		t.Fatalf("failed to store key: %v", err)
+	}
+	if err := ks.Store("alice", alice, "alice-pass"); err != nil {
+		t.Fatalf("failed to store key: %v", err)
+	}
+	if err := ks.Store("alice", bob, "bob-pass"); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("expected ErrKeyExists, got %v", err)
+	}
+
+	infos, err := ks.List()
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(infos) != 2 || infos[0].Name != "alice" || infos[0].Address != alice.GetAddress() || infos[1].Name != "bob" {
+		t.Fatalf("unexpected key list: %+v", infos)
+	}
+
+	info, err := os.Stat(filepath.Join(ks.dir, "alice"+keystoreFileExt))
+	if err != nil {
+		t.Fatalf("failed to stat key file: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected key file mode 0600, got %o", info.Mode().Perm())
+	}
+
+	loaded, err := ks.Load("alice", "alice-pass")
+	if err != nil {
+		t.Fatalf("failed to load key: %v", err)
+	}
+	if loaded.GetAddress() != alice.GetAddress() {
+		t.Fatalf("expected address %s, got %s", alice.GetAddress(), loaded.GetAddress())
+	}
+	if loaded.GetMnemonic() != "" {
+		t.Error("mnemonic must not be persisted")
+	}
+
+	if _, err := ks.Load("alice", "wrong"); err == nil {
+		t.Fatal("expected wrong passphrase to fail")
+	}
+	if _, err := ks.Load("carol", "pass"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+	if _, err := ks.Load("../alice", "alice-pass"); err == nil {
+		t.Fatal("expected invalid key name to be rejected")
+	}
+
+	if err := ks.Delete("alice"); err != nil {
+		t.Fatalf("failed to delete key: %v", err)
+	}
+	if err := ks.Delete("alice"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+	if infos, _ := ks.List(); len(infos) != 1 {
+		t.Fatalf("expected 1 key after delete, got %d", len(infos))
+	}
+}
+
+func TestKeystoreImportExport(t *testing.T) {
+	ks, err := NewKeystore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open keystore: %v", err)
+	}
+
+	wallet, _ := GenerateWallet()
+	armor, err := wallet.ExportArmor("export-pass")
+	if err != nil {
+		t.Fatalf("failed to export armor: %v", err)
+	}
+
+	info, err := ks.Import("imported", armor, "export-pass")
+	if err != nil {
+		t.Fatalf("failed to import key: %v", err)
+	}
+	if info.Address != wallet.GetAddress() {
+		t.Fatalf("expected address %s, got %s", wallet.GetAddress(), info.Address)
+	}
+
+	exported, err := ks.Export("imported", "export-pass", "other-pass")
+	if err != nil {
+		t.Fatalf("failed to export key: %v", err)
+	}
+	roundTrip, err := NewWalletFromArmor(exported, "other-pass")
+	if err != nil {
+		t.Fatalf("failed to decrypt exported key: %v", err)
+	}
+	if roundTrip.GetAddress() != wallet.GetAddress() {
+		t.Fatalf("expected address %s, got %s", wallet.GetAddress(), roundTrip.GetAddress())
+	}
+}
+
+func TestWalletCloseZeroesKey(t *testing.T) {
+	wallet, _ := GenerateWallet()
+	key := wallet.GetPrivateKeyBytes()
+
+	if err := wallet.Close(); err != nil {
+		t.Fatalf("failed to close wallet: %v", err)
+	}
+	for _, b := range key {
+		if b != 0 {
+			t.Fatal("expected private key bytes to be zeroed")
+		}
+	}
+	if wallet.GetMnemonic() != "" {
+		t.Error("expected mnemonic to be dropped")
+	}
+	if _, err := wallet.Sign([]byte("msg")); !errors.Is(err, ErrWalletClosed) {
+		t.Fatalf("expected ErrWalletClosed, got %v", err)
+	}
+	if wallet.GetAddress() == "" {
+		t.Error("address should remain available after Close")
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	breakerMetricsOnce sync.Once
+	breakerState       *prometheus.GaugeVec
+	breakerTransitions *prometheus.CounterVec
+)
+
+// breakerStateValues are the gauge values of the circuit breaker states
+var breakerStateValues = map[string]float64{
+	"closed":    0,
+	"half_open": 1,
+	"open":      2,
+}
+
+func initBreakerMetrics() {
+	breakerMetricsOnce.Do(func() {
+		breakerState = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricName("circuit_breaker_state"),
+				Help: "State of the circuit breaker of an endpoint: closed (0), half-open (1) or open (2).",
+			},
+			[]string{"endpoint"},
+		)
+
+		breakerTransitions = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("circuit_breaker_transitions_total"),
+				Help: "Total circuit breaker state changes grouped by endpoint and the states changed from and to.",
+			},
+			[]string{"endpoint", "from", "to"},
+		)
+
+		prometheus.MustRegister(breakerState, breakerTransitions)
+	})
+}
+
+// SetBreakerState records the state of an endpoint's circuit breaker.
+func SetBreakerState(endpoint, state string) {
+	initBreakerMetrics()
+	breakerState.WithLabelValues(endpoint).Set(breakerStateValues[state])
+}
+
+// ObserveBreakerTransition records a change of an endpoint's circuit breaker state.
+func ObserveBreakerTransition(endpoint, from, to string) {
+	SetBreakerState(endpoint, to)
+	breakerTransitions.WithLabelValues(endpoint, from, to).Inc()
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheMetricsOnce sync.Once
+	cacheLookups     *prometheus.CounterVec
+	cacheEvictions   prometheus.Counter
+)
+
+func initCacheMetrics() {
+	cacheMetricsOnce.Do(func() {
+		cacheLookups = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("cache_lookups_total"),
+				Help: "Total response cache lookups grouped by module, method, and result (hit or miss).",
+			},
+			[]string{"module", "method", "result"},
+		)
+
+		cacheEvictions = prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: metricName("cache_evictions_total"),
+				Help: "Total responses evicted from the in-memory response cache.",
+			},
+		)
+
+		prometheus.MustRegister(cacheLookups, cacheEvictions)
+	})
+}
+
+// ObserveCacheLookup counts a response cache lookup as a hit or a miss.
+func ObserveCacheLookup(module, method string, hit bool) {
+	initCacheMetrics()
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(module, method, result).Inc()
+}
+
+// ObserveCacheEviction counts a response evicted from the in-memory cache.
+func ObserveCacheEviction() {
+	initCacheMetrics()
+	cacheEvictions.Inc()
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	coalesceMetricsOnce sync.Once
+	coalescedRequests   *prometheus.CounterVec
+)
+
+func initCoalesceMetrics() {
+	coalesceMetricsOnce.Do(func() {
+		coalescedRequests = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("coalesce_requests_total"),
+				Help: "Total coalescing queries grouped by module, method, and result (executed or coalesced).",
+			},
+			[]string{"module", "method", "result"},
+		)
+
+		prometheus.MustRegister(coalescedRequests)
+	})
+}
+
+// ObserveCoalescedRequest counts a query that was either executed or merged into an
+// identical query in flight.
+func ObserveCoalescedRequest(module, method string, coalesced bool) {
+	initCoalesceMetrics()
+	result := "executed"
+	if coalesced {
+		result = "coalesced"
+	}
+	coalescedRequests.WithLabelValues(module, method, result).Inc()
+}
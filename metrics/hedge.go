@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	hedgeMetricsOnce sync.Once
+	hedgesIssued     *prometheus.CounterVec
+	hedgesWon        *prometheus.CounterVec
+)
+
+func initHedgeMetrics() {
+	hedgeMetricsOnce.Do(func() {
+		hedgesIssued = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("rpc_hedges_issued_total"),
+				Help: "Total hedged RPC attempts sent to a second endpoint grouped by service and method.",
+			},
+			[]string{"service", "method"},
+		)
+
+		hedgesWon = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("rpc_hedges_won_total"),
+				Help: "Total hedged RPC attempts that answered before the original attempt grouped by service and method.",
+			},
+			[]string{"service", "method"},
+		)
+
+		prometheus.MustRegister(hedgesIssued, hedgesWon)
+	})
+}
+
+// ObserveHedgeIssued counts a query sent to a second endpoint because the first was slow.
+func ObserveHedgeIssued(service, method string) {
+	initHedgeMetrics()
+	hedgesIssued.WithLabelValues(service, method).Inc()
+}
+
+// ObserveHedgeWon counts a hedged query whose second attempt succeeded first.
+func ObserveHedgeWon(service, method string) {
+	initHedgeMetrics()
+	hedgesWon.WithLabelValues(service, method).Inc()
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	websocketMetricsOnce    sync.Once
+	websocketConnected      *prometheus.GaugeVec
+	websocketDisconnects    *prometheus.CounterVec
+	websocketDialFailures   *prometheus.CounterVec
+	websocketReconnectDelay *prometheus.HistogramVec
+)
+
+func initWebsocketMetrics() {
+	websocketMetricsOnce.Do(func() {
+		websocketConnected = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricName("websocket_connected"),
+				Help: "Whether the websocket to an endpoint is connected (1) or not (0).",
+			},
+			[]string{"endpoint"},
+		)
+
+		websocketDisconnects = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("websocket_disconnects_total"),
+				Help: "Total websocket disconnects grouped by endpoint and reason.",
+			},
+			[]string{"endpoint", "reason"},
+		)
+
+		websocketDialFailures = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricName("websocket_dial_failures_total"),
+				Help: "Total failed websocket dial attempts grouped by endpoint.",
+			},
+			[]string{"endpoint"},
+		)
+
+		websocketReconnectDelay = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    metricName("websocket_reconnect_delay_seconds"),
+				Help:    "Backoff delays before websocket dial retries grouped by endpoint.",
+				Buckets: []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32, 64},
+			},
+			[]string{"endpoint"},
+		)
+
+		prometheus.MustRegister(websocketConnected, websocketDisconnects, websocketDialFailures, websocketReconnectDelay)
+	})
+}
+
+// SetWebsocketConnected records whether the websocket to an endpoint is connected.
+func SetWebsocketConnected(endpoint string, connected bool) {
+	initWebsocketMetrics()
+	value := 0.0
+	if connected {
+		value = 1
+	}
+	websocketConnected.WithLabelValues(endpoint).Set(value)
+}
+
+// ObserveWebsocketDisconnect counts a lost websocket connection.
+func ObserveWebsocketDisconnect(endpoint, reason string) {
+	initWebsocketMetrics()
+	websocketDisconnects.WithLabelValues(endpoint, reason).Inc()
+}
+
+// ObserveWebsocketDialFailure counts a failed dial and the delay before the next attempt.
+func ObserveWebsocketDialFailure(endpoint string, retryIn time.Duration) {
+	initWebsocketMetrics()
+	websocketDialFailures.WithLabelValues(endpoint).Inc()
+	websocketReconnectDelay.WithLabelValues(endpoint).Observe(retryIn.Seconds())
+}
@@ -0,0 +1,243 @@
+package allora
+
+import (
+	"fmt"
+
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// NewMultisigPubKey creates a legacy amino multisig public key requiring threshold
+// of the given public keys to sign. The order of the keys determines the multisig
+// address, so every party must use the same order.
+//
+// Example:
+//
+//	multisigPubKey, err := allora.NewMultisigPubKey(2, alice.PubKey, bob.PubKey, carol.PubKey)
+//	multisigAddr := sdk.AccAddress(multisigPubKey.Address())
+func NewMultisigPubKey(threshold int, pubKeys ...cryptotypes.PubKey) (*kmultisig.LegacyAminoPubKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, fmt.Errorf("at least one public key is required")
+	}
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return nil, fmt.Errorf("threshold must be between 1 and %d, got %d", len(pubKeys), threshold)
+	}
+
+	seen := make(map[string]struct{}, len(pubKeys))
+	for i, pk := range pubKeys {
+		if pk == nil {
+			return nil, fmt.Errorf("public key %d is nil", i)
+		}
+		key := string(pk.Bytes())
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("duplicate public key %d", i)
+		}
+		seen[key] = struct{}{}
+	}
+
+	return kmultisig.NewLegacyAminoPubKey(threshold, pubKeys), nil
+}
+
+// NewMultisigPubKeyFromWallets creates a multisig public key from the wallets' public keys
+func NewMultisigPubKeyFromWallets(threshold int, wallets ...*Wallet) (*kmultisig.LegacyAminoPubKey, error) {
+	pubKeys := make([]cryptotypes.PubKey, 0, len(wallets))
+	for i, w := range wallets {
+		if w == nil {
+			return nil, fmt.Errorf("wallet %d is nil", i)
+		}
+		pubKeys = append(pubKeys, w.PubKey)
+	}
+	return NewMultisigPubKey(threshold, pubKeys...)
+}
+
+// SignMultisigPartial produces one signer's partial signature for a transaction
+// sent from a multisig account
+//
+// This can be done offline by each signer: the unsigned transaction and the
+// params (the multisig account's number and sequence) are shared with every
+// signer, and the returned signatures are collected and passed to
+// CombineMultisigSignatures. Partial signatures use SIGN_MODE_LEGACY_AMINO_JSON,
+// the only sign mode supported by legacy amino multisig keys.
+//
+// Example:
+//
+//	sigAlice, err := allora.SignMultisigPartial(unsignedTx, alice, multisigPubKey, params)
+//	sigBob, err := allora.SignMultisigPartial(unsignedTx, bob, multisigPubKey, params)
+//
+//	signedTx, err := allora.CombineMultisigSignatures(unsignedTx, multisigPubKey,
+//	    []signing.SignatureV2{sigAlice, sigBob}, params)
+func SignMultisigPartial(
+	unsignedTx []byte,
+	signer Signer,
+	multisigPubKey *kmultisig.LegacyAminoPubKey,
+	params *TxParams,
+) (signingtypes.SignatureV2, error) {
+	if err := params.Validate(); err != nil {
+		return signingtypes.SignatureV2{}, fmt.Errorf("invalid transaction parameters: %w", err)
+	}
+	if len(unsignedTx) == 0 {
+		return signingtypes.SignatureV2{}, fmt.Errorf("unsigned transaction is empty")
+	}
+	pubKey, err := signerPubKey(signer)
+	if err != nil {
+		return signingtypes.SignatureV2{}, err
+	}
+	if multisigPubKey == nil {
+		return signingtypes.SignatureV2{}, fmt.Errorf("multisig public key is required")
+	}
+	if multisigIndex(multisigPubKey, pubKey) < 0 {
+		return signingtypes.SignatureV2{}, fmt.Errorf("signer %s is not a member of multisig %s",
+			sdk.AccAddress(pubKey.Address()), sdk.AccAddress(multisigPubKey.Address()))
+	}
+
+	builder := newTxBuilder()
+	txBuilder, err := builder.decodeTxBuilder(unsignedTx)
+	if err != nil {
+		return signingtypes.SignatureV2{}, err
+	}
+
+	bytesToSign, err := builder.signBytes(txBuilder, signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, multisigPubKey, params)
+	if err != nil {
+		return signingtypes.SignatureV2{}, err
+	}
+
+	signature, err := signer.Sign(bytesToSign)
+	if err != nil {
+		return signingtypes.SignatureV2{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return signingtypes.SignatureV2{
+		PubKey: pubKey,
+		Data: &signingtypes.SingleSignatureData{
+			SignMode:  signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+			Signature: signature,
+		},
+		Sequence: params.Sequence,
+	}, nil
+}
+
+// CombineMultisigSignatures combines partial signatures from SignMultisigPartial
+// into a MultiSignatureData and returns the signed transaction
+//
+// Every partial signature is verified before it is added; at least threshold
+// distinct members must have signed.
+func CombineMultisigSignatures(
+	unsignedTx []byte,
+	multisigPubKey *kmultisig.LegacyAminoPubKey,
+	partials []signingtypes.SignatureV2,
+	params *TxParams,
+) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction parameters: %w", err)
+	}
+	if len(unsignedTx) == 0 {
+		return nil, fmt.Errorf("unsigned transaction is empty")
+	}
+	if multisigPubKey == nil {
+		return nil, fmt.Errorf("multisig public key is required")
+	}
+
+	builder := newTxBuilder()
+	txBuilder, err := builder.decodeTxBuilder(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesToSign, err := builder.signBytes(txBuilder, signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, multisigPubKey, params)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys := multisigPubKey.GetPubKeys()
+	multisigData := multisig.NewMultisig(len(pubKeys))
+	signed := make(map[int]struct{}, len(partials))
+	for i, partial := range partials {
+		idx := multisigIndex(multisigPubKey, partial.PubKey)
+		if idx < 0 {
+			return nil, fmt.Errorf("partial signature %d is not from a member of the multisig", i)
+		}
+		single, ok := partial.Data.(*signingtypes.SingleSignatureData)
+		if !ok {
+			return nil, fmt.Errorf("partial signature %d is not a single signature", i)
+		}
+		if single.SignMode != signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+			return nil, fmt.Errorf("partial signature %d uses sign mode %s, expected %s",
+				i, single.SignMode, signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+		}
+		if partial.Sequence != params.Sequence {
+			return nil, fmt.Errorf("partial signature %d was made for sequence %d, expected %d", i, partial.Sequence, params.Sequence)
+		}
+		if !partial.PubKey.VerifySignature(bytesToSign, single.Signature) {
+			return nil, fmt.Errorf("partial signature %d from %s is invalid", i, sdk.AccAddress(partial.PubKey.Address()))
+		}
+
+		if err := multisig.AddSignatureV2(multisigData, partial, pubKeys); err != nil {
+			return nil, fmt.Errorf("failed to add partial signature %d: %w", i, err)
+		}
+		signed[idx] = struct{}{}
+	}
+
+	threshold := int(multisigPubKey.Threshold)
+	if len(signed) < threshold {
+		return nil, fmt.Errorf("not enough signatures: have %d, need %d", len(signed), threshold)
+	}
+
+	getSignBytes := func(signingtypes.SignMode) ([]byte, error) { return bytesToSign, nil }
+	if err := multisigPubKey.VerifyMultisignature(getSignBytes, multisigData); err != nil {
+		return nil, fmt.Errorf("combined multisignature is invalid: %w", err)
+	}
+
+	sig := signingtypes.SignatureV2{
+		PubKey:   multisigPubKey,
+		Data:     multisigData,
+		Sequence: params.Sequence,
+	}
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, fmt.Errorf("failed to set signatures: %w", err)
+	}
+
+	signedTxBytes, err := builder.txConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed transaction: %w", err)
+	}
+	return signedTxBytes, nil
+}
+
+// MarshalPartialSignature encodes a partial signature as JSON so it can be moved
+// between offline signers. The format is the one produced by the Cosmos SDK CLI
+// (`tx sign --multisig`), so signatures can be exchanged with other tools.
+func MarshalPartialSignature(sig signingtypes.SignatureV2) ([]byte, error) {
+	bz, err := newTxBuilder().txConfig.MarshalSignatureJSON([]signingtypes.SignatureV2{sig})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+	return bz, nil
+}
+
+// UnmarshalPartialSignature decodes a partial signature encoded by MarshalPartialSignature
+func UnmarshalPartialSignature(bz []byte) (signingtypes.SignatureV2, error) {
+	sigs, err := newTxBuilder().txConfig.UnmarshalSignatureJSON(bz)
+	if err != nil {
+		return signingtypes.SignatureV2{}, fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+	if len(sigs) != 1 {
+		return signingtypes.SignatureV2{}, fmt.Errorf("expected 1 signature, got %d", len(sigs))
+	}
+	return sigs[0], nil
+}
+
+// multisigIndex returns the position of pubKey among the members of the multisig, or -1
+func multisigIndex(multisigPubKey *kmultisig.LegacyAminoPubKey, pubKey cryptotypes.PubKey) int {
+	if pubKey == nil {
+		return -1
+	}
+	for i, pk := range multisigPubKey.GetPubKeys() {
+		if pk.Equals(pubKey) {
+			return i
+		}
+	}
+	return -1
+}
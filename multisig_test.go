@@ -0,0 +1,163 @@
+package allora
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// Fixed vector: 2-of-3 multisig over deterministic keys sending 1000000uallo
+const (
+	multisigVectorAddress = "allo1jgfvnad4c568er3fuk46zwhryutj76nujejry4"
+	multisigVectorTxHash  = "599960CD02E4E3ECAA1774F424FAA03386671BE8CD116A9C8A229FC3C99A0BDC"
+)
+
+func multisigVectorWallets(t *testing.T) []*Wallet {
+	t.Helper()
+	wallets := make([]*Wallet, 0, 3)
+	for _, seed := range []string{"multisig-signer-1", "multisig-signer-2", "multisig-signer-3"} {
+		key := sha256.Sum256([]byte(seed))
+		w, err := NewWalletFromPrivateKey(key[:])
+		if err != nil {
+			t.Fatalf("failed to create wallet: %v", err)
+		}
+		wallets = append(wallets, w)
+	}
+	return wallets
+}
+
+func multisigVectorParams() *TxParams {
+	return &TxParams{
+		ChainID:       "allora-testnet-1",
+		AccountNumber: 42,
+		Sequence:      7,
+		GasLimit:      300000,
+		FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 7500)),
+		Memo:          "multisig-vector",
+	}
+}
+
+func TestMultisigFixedVector(t *testing.T) {
+	wallets := multisigVectorWallets(t)
+	params := multisigVectorParams()
+
+	multisigPubKey, err := NewMultisigPubKeyFromWallets(2, wallets...)
+	if err != nil {
+		t.Fatalf("failed to create multisig public key: %v", err)
+	}
+	multisigAddr := sdk.AccAddress(multisigPubKey.Address())
+	if FormatAccAddress(multisigAddr) != multisigVectorAddress {
+		t.Fatalf("expected multisig address %s, got %s", multisigVectorAddress, FormatAccAddress(multisigAddr))
+	}
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000))
+	unsignedTx, err := CreateUnsignedSendTx(multisigAddr, wallets[0].Address, amount, params)
+	if err != nil {
+		t.Fatalf("failed to create unsigned transaction: %v", err)
+	}
+
+	// Signers 1 and 3 sign offline and exchange their signatures as JSON
+	var partials []signingtypes.SignatureV2
+	for _, w := range []*Wallet{wallets[0], wallets[2]} {
+		sig, err := SignMultisigPartial(unsignedTx, w, multisigPubKey, params)
+		if err != nil {
+			t.Fatalf("failed to sign partial: %v", err)
+		}
+		bz, err := MarshalPartialSignature(sig)
+		if err != nil {
+			t.Fatalf("failed to marshal partial signature: %v", err)
+		}
+		decoded, err := UnmarshalPartialSignature(bz)
+		if err != nil {
+			t.Fatalf("failed to unmarshal partial signature: %v", err)
+		}
+		partials = append(partials, decoded)
+	}
+
+	signedTx, err := CombineMultisigSignatures(unsignedTx, multisigPubKey, partials, params)
+	if err != nil {
+		t.Fatalf("failed to combine signatures: %v", err)
+	}
+	if hash := TxHash(signedTx); hash != multisigVectorTxHash {
+		t.Fatalf("expected tx hash %s, got %s", multisigVectorTxHash, hash)
+	}
+
+	tx, err := ParseTxBytes(signedTx)
+	if err != nil {
+		t.Fatalf("failed to parse signed transaction: %v", err)
+	}
+	sigs, err := (*tx).(authsigning.SigVerifiableTx).GetSignaturesV2()
+	if err != nil {
+		t.Fatalf("failed to get signatures: %v", err)
+	}
+	if len(sigs) != 1 || !sigs[0].PubKey.Equals(multisigPubKey) {
+		t.Fatalf("expected a single multisig signer, got %v", sigs)
+	}
+	multiData, ok := sigs[0].Data.(*signingtypes.MultiSignatureData)
+	if !ok {
+		t.Fatalf("expected MultiSignatureData, got %T", sigs[0].Data)
+	}
+	if len(multiData.Signatures) != 2 || !multiData.BitArray.GetIndex(0) || multiData.BitArray.GetIndex(1) || !multiData.BitArray.GetIndex(2) {
+		t.Fatalf("unexpected signer set: %s", multiData.BitArray)
+	}
+}
+
+func TestCombineMultisigSignaturesErrors(t *testing.T) {
+	wallets := multisigVectorWallets(t)
+	params := multisigVectorParams()
+
+	multisigPubKey, err := NewMultisigPubKeyFromWallets(2, wallets...)
+	if err != nil {
+		t.Fatalf("failed to create multisig public key: %v", err)
+	}
+	multisigAddr := sdk.AccAddress(multisigPubKey.Address())
+	amount := sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000))
+	unsignedTx, err := CreateUnsignedSendTx(multisigAddr, wallets[0].Address, amount, params)
+	if err != nil {
+		t.Fatalf("failed to create unsigned transaction: %v", err)
+	}
+
+	sig1, err := SignMultisigPartial(unsignedTx, wallets[0], multisigPubKey, params)
+	if err != nil {
+		t.Fatalf("failed to sign partial: %v", err)
+	}
+
+	// Below threshold
+	_, err = CombineMultisigSignatures(unsignedTx, multisigPubKey, []signingtypes.SignatureV2{sig1}, params)
+	if err == nil || !strings.Contains(err.Error(), "not enough signatures") {
+		t.Fatalf("expected not enough signatures error, got %v", err)
+	}
+
+	// Same signer twice does not count twice
+	_, err = CombineMultisigSignatures(unsignedTx, multisigPubKey, []signingtypes.SignatureV2{sig1, sig1}, params)
+	if err == nil {
+		t.Fatal("expected duplicate signatures to be rejected")
+	}
+
+	// Signature over different params
+	other := *params
+	other.Sequence++
+	sig2, err := SignMultisigPartial(unsignedTx, wallets[1], multisigPubKey, &other)
+	if err != nil {
+		t.Fatalf("failed to sign partial: %v", err)
+	}
+	sig2.Sequence = params.Sequence
+	_, err = CombineMultisigSignatures(unsignedTx, multisigPubKey, []signingtypes.SignatureV2{sig1, sig2}, params)
+	if err == nil || !strings.Contains(err.Error(), "is invalid") {
+		t.Fatalf("expected invalid signature error, got %v", err)
+	}
+
+	// Non-member
+	outsider, _ := GenerateWallet()
+	if _, err := SignMultisigPartial(unsignedTx, outsider, multisigPubKey, params); err == nil {
+		t.Fatal("expected non-member to be rejected")
+	}
+
+	if _, err := NewMultisigPubKeyFromWallets(4, wallets...); err == nil {
+		t.Fatal("expected threshold above member count to be rejected")
+	}
+}
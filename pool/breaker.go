@@ -0,0 +1,169 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/metrics"
+)
+
+// breakerBuckets is how many buckets the failure window of a breaker is split into;
+// outcomes leave the window a bucket at a time
+const breakerBuckets = 10
+
+// failureWindow counts the queries and failures of a client over a sliding window
+type failureWindow struct {
+	buckets [breakerBuckets]windowBucket
+}
+
+type windowBucket struct {
+	start              time.Time
+	requests, failures int
+}
+
+// add records the outcome of a query in the bucket of now
+func (w *failureWindow) add(now time.Time, window time.Duration, failed bool) {
+	width := max(window/breakerBuckets, time.Nanosecond)
+	start := now.Truncate(width)
+	b := &w.buckets[(start.UnixNano()/int64(width))%breakerBuckets]
+	if !b.start.Equal(start) {
+		*b = windowBucket{start: start}
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+}
+
+// counts returns the queries and failures recorded within window of now
+func (w *failureWindow) counts(now time.Time, window time.Duration) (requests, failures int) {
+	for _, b := range w.buckets {
+		if !b.start.IsZero() && now.Sub(b.start) < window {
+			requests += b.requests
+			failures += b.failures
+		}
+	}
+	return requests, failures
+}
+
+// breaker is the circuit breaker of a client. Closed clients are in the active
+// pool; open and half-open ones are cooling.
+type breaker struct {
+	state     config.BreakerState
+	openedAt  time.Time
+	window    failureWindow
+	trials    int // trial queries in flight while half-open
+	successes int // trial queries succeeded in a row while half-open
+}
+
+// breakerTransition is a state change waiting for the OnStateChange callback
+type breakerTransition struct {
+	endpoint string
+	from, to config.BreakerState
+}
+
+// setBreakerState moves the breaker of a client to a new state. Called with the pool
+// locked; the OnStateChange callback runs once notifyBreakerTransitions is called
+// after unlocking.
+func (cpm *ClientPoolManager[T]) setBreakerState(clientInfo *ClientInfo[T], to config.BreakerState, reason string) {
+	b := &clientInfo.breaker
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.trials, b.successes = 0, 0
+	switch to {
+	case config.BreakerOpen:
+		b.openedAt = time.Now()
+	case config.BreakerClosed:
+		b.window = failureWindow{}
+	}
+
+	endpoint := clientInfo.Client.GetEndpointURL()
+	metrics.ObserveBreakerTransition(endpoint, string(from), string(to))
+	cpm.logger.Info().
+		Str("client_url", endpoint).
+		Str("from", string(from)).
+		Str("to", string(to)).
+		Msgf("circuit breaker changed state: %s", reason)
+	if cpm.cfg.Breaker.OnStateChange != nil {
+		cpm.transitions = append(cpm.transitions, breakerTransition{endpoint, from, to})
+	}
+}
+
+// notifyBreakerTransitions calls OnStateChange for the state changes made since the
+// last call. It must be called with the pool unlocked, so that the callback may
+// call back into the pool.
+func (cpm *ClientPoolManager[T]) notifyBreakerTransitions() {
+	cpm.mu.Lock()
+	transitions := cpm.transitions
+	cpm.transitions = nil
+	cpm.mu.Unlock()
+
+	for _, t := range transitions {
+		cpm.cfg.Breaker.OnStateChange(t.endpoint, t.from, t.to)
+	}
+}
+
+// recordOutcome feeds the outcome of a query to the breaker of a client. It trips
+// a closed breaker whose failure ratio over the window reaches FailureRatio, and
+// decides half-open breakers on their trial queries. Called with the pool locked.
+func (cpm *ClientPoolManager[T]) recordOutcome(clientInfo *ClientInfo[T], success bool) {
+	b := &clientInfo.breaker
+	now := time.Now()
+	b.window.add(now, cpm.cfg.Breaker.Window, !success)
+
+	switch b.state {
+	case config.BreakerClosed:
+		if success {
+			return
+		}
+		requests, failures := b.window.counts(now, cpm.cfg.Breaker.Window)
+		if requests >= cpm.cfg.Breaker.MinRequests && float64(failures)/float64(requests) >= cpm.cfg.Breaker.FailureRatio {
+			cpm.coolClient(clientInfo, "failure ratio reached")
+		}
+	case config.BreakerHalfOpen:
+		b.trials = max(b.trials-1, 0)
+		if !success {
+			cpm.setBreakerState(clientInfo, config.BreakerOpen, "trial query failed")
+			return
+		}
+		b.successes++
+		if b.successes >= cpm.cfg.Breaker.HalfOpenSuccesses {
+			clientInfo.successRate = cpm.cfg.ReactivatedSuccessRate
+			cpm.activateClient(clientInfo)
+		}
+	}
+}
+
+// pickTrial returns a half-open client that may take a trial query, first moving
+// open breakers whose OpenTimeout elapsed to half-open. Nodes that are behind the
+// pool stay open. Called with the pool locked.
+func (cpm *ClientPoolManager[T]) pickTrial(skip func(*ClientInfo[T]) bool) (*ClientInfo[T], bool) {
+	now := time.Now()
+	maxHeight := cpm.maxHeight()
+	for i := range cpm.cooling {
+		clientInfo := &cpm.cooling[i]
+		b := &clientInfo.breaker
+		if b.state == config.BreakerOpen && now.Sub(b.openedAt) >= cpm.cfg.Breaker.OpenTimeout && cpm.behind(clientInfo, maxHeight) == "" {
+			cpm.setBreakerState(clientInfo, config.BreakerHalfOpen, "open timeout elapsed")
+		}
+		if b.state == config.BreakerHalfOpen && b.trials < cpm.cfg.Breaker.HalfOpenRequests && !skip(clientInfo) {
+			b.trials++
+			return clientInfo, true
+		}
+	}
+	return nil, false
+}
+
+// releaseTrial frees the trial slot taken by a query whose outcome is not reported,
+// such as the losing attempt of a hedged query
+func (cpm *ClientPoolManager[T]) releaseTrial(client T) {
+	cpm.mu.Lock()
+	defer cpm.mu.Unlock()
+
+	if clientInfo := cpm.find(client.GetEndpointURL()); clientInfo != nil && clientInfo.breaker.state == config.BreakerHalfOpen {
+		clientInfo.breaker.trials = max(clientInfo.breaker.trials-1, 0)
+	}
+}
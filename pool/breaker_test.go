@@ -0,0 +1,193 @@
+package pool
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// transitionRecorder collects the state changes passed to OnStateChange
+type transitionRecorder struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (r *transitionRecorder) record(endpoint string, from, to config.BreakerState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got = append(r.got, endpoint+":"+string(from)+"->"+string(to))
+}
+
+func (r *transitionRecorder) transitions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.got...)
+}
+
+func newBreakerManager(t *testing.T, breaker config.BreakerConfig, clients ...*testParticipant) (*ClientPoolManager[*testParticipant], *transitionRecorder) {
+	t.Helper()
+	recorder := &transitionRecorder{}
+	breaker.OnStateChange = recorder.record
+	return newTestManager(t, config.PoolConfig{Breaker: breaker}, clients...), recorder
+}
+
+func breakerState(cpm *ClientPoolManager[*testParticipant], url string) config.BreakerState {
+	cpm.mu.RLock()
+	defer cpm.mu.RUnlock()
+	return cpm.find(url).breaker.state
+}
+
+func breakerGauge(t *testing.T, endpoint string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "circuit_breaker_state" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "endpoint" && label.GetValue() == endpoint {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	t.Fatalf("no breaker state gauge for %s", endpoint)
+	return 0
+}
+
+func TestBreakerOpensAtFailureRatio(t *testing.T) {
+	good := &testParticipant{url: "breaker-ratio-good"}
+	bad := &testParticipant{url: "breaker-ratio-bad"}
+	cpm, recorder := newBreakerManager(t, config.BreakerConfig{FailureRatio: 0.5, MinRequests: 4}, good, bad)
+
+	cpm.ReportHealth(bad, 1, 10, true)
+	cpm.ReportHealth(bad, 1, 10, false)
+	cpm.ReportHealth(bad, 1, 10, false)
+	if !activeURLs(cpm)[bad.url] {
+		t.Fatal("expected the breaker to wait for MinRequests queries")
+	}
+	if got := breakerGauge(t, bad.url); got != 0 {
+		t.Fatalf("expected the gauge to report closed, got %v", got)
+	}
+
+	cpm.ReportHealth(bad, 1, 10, true)
+	cpm.ReportHealth(bad, 1, 10, false)
+	if activeURLs(cpm)[bad.url] {
+		t.Fatal("expected the breaker to open at the failure ratio")
+	}
+	if state := breakerState(cpm, bad.url); state != config.BreakerOpen {
+		t.Fatalf("expected an open breaker, got %s", state)
+	}
+	if got := breakerGauge(t, bad.url); got != 2 {
+		t.Fatalf("expected the gauge to report open, got %v", got)
+	}
+	if got := recorder.transitions(); len(got) != 1 || got[0] != bad.url+":closed->open" {
+		t.Fatalf("expected one closed->open transition, got %v", got)
+	}
+}
+
+func TestBreakerClosesAfterHalfOpenTrials(t *testing.T) {
+	good := &testParticipant{url: "breaker-trial-good"}
+	bad := &testParticipant{url: "breaker-trial-bad"}
+	cpm, recorder := newBreakerManager(t, config.BreakerConfig{
+		MinRequests:       1,
+		OpenTimeout:       10 * time.Millisecond,
+		HalfOpenRequests:  1,
+		HalfOpenSuccesses: 2,
+	}, good, bad)
+
+	cpm.ReportHealth(bad, 1, 10, false)
+	if client, _ := cpm.GetClient(nil); client != good {
+		t.Fatal("expected an open breaker to keep queries away")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if client, _ := cpm.GetClient(nil); client != bad {
+		t.Fatal("expected a trial query once the open timeout elapsed")
+	}
+	if state := breakerState(cpm, bad.url); state != config.BreakerHalfOpen {
+		t.Fatalf("expected a half-open breaker, got %s", state)
+	}
+	if got := breakerGauge(t, bad.url); got != 1 {
+		t.Fatalf("expected the gauge to report half-open, got %v", got)
+	}
+	if client, _ := cpm.GetClient(nil); client != good {
+		t.Fatal("expected no more trials than HalfOpenRequests at once")
+	}
+
+	// A trial whose outcome is never reported frees its slot
+	cpm.releaseTrial(bad)
+	if client, _ := cpm.GetClient(nil); client != bad {
+		t.Fatal("expected the released trial slot to be taken again")
+	}
+
+	cpm.ReportHealth(bad, 1, 10, true)
+	if activeURLs(cpm)[bad.url] {
+		t.Fatal("expected the breaker to need HalfOpenSuccesses trials")
+	}
+	cpm.GetClient(nil)
+	cpm.ReportHealth(bad, 1, 10, true)
+	if !activeURLs(cpm)[bad.url] {
+		t.Fatal("expected the breaker to close after its trials succeeded")
+	}
+
+	want := []string{bad.url + ":closed->open", bad.url + ":open->half_open", bad.url + ":half_open->closed"}
+	if got := recorder.transitions(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("expected transitions %v, got %v", want, got)
+	}
+}
+
+func TestBreakerReopensWhenTrialFails(t *testing.T) {
+	good := &testParticipant{url: "breaker-reopen-good"}
+	bad := &testParticipant{url: "breaker-reopen-bad"}
+	cpm, _ := newBreakerManager(t, config.BreakerConfig{MinRequests: 1, OpenTimeout: 10 * time.Millisecond}, good, bad)
+
+	cpm.ReportHealth(bad, 1, 10, false)
+	time.Sleep(20 * time.Millisecond)
+	if client, _ := cpm.GetClient(nil); client != bad {
+		t.Fatal("expected a trial query once the open timeout elapsed")
+	}
+	cpm.ReportHealth(bad, 1, 10, false)
+	if state := breakerState(cpm, bad.url); state != config.BreakerOpen {
+		t.Fatalf("expected the failed trial to reopen the breaker, got %s", state)
+	}
+	if client, _ := cpm.GetClient(nil); client != good {
+		t.Fatal("expected the reopened breaker to wait for the open timeout again")
+	}
+}
+
+func TestBreakerStaysOpenWhileNodeLags(t *testing.T) {
+	good := &testParticipant{url: "breaker-lag-good", status: NodeStatus{LatestHeight: 1000}}
+	stuck := &testParticipant{url: "breaker-lag-stuck", status: NodeStatus{LatestHeight: 500}}
+	cpm, _ := newBreakerManager(t, config.BreakerConfig{OpenTimeout: time.Millisecond}, good, stuck)
+
+	cpm.probe()
+	time.Sleep(5 * time.Millisecond)
+	if client, _ := cpm.GetClient(nil); client != good {
+		t.Fatal("expected no trial query on a lagging node")
+	}
+	if state := breakerState(cpm, stuck.url); state != config.BreakerOpen {
+		t.Fatalf("expected the lagging node's breaker to stay open, got %s", state)
+	}
+}
+
+func TestFailureWindowForgetsOldOutcomes(t *testing.T) {
+	var w failureWindow
+	now := time.Now()
+	w.add(now, time.Second, true)
+	w.add(now.Add(500*time.Millisecond), time.Second, false)
+	if requests, failures := w.counts(now.Add(500*time.Millisecond), time.Second); requests != 2 || failures != 1 {
+		t.Fatalf("expected 2 requests and 1 failure, got %d and %d", requests, failures)
+	}
+	if requests, failures := w.counts(now.Add(1200*time.Millisecond), time.Second); requests != 1 || failures != 0 {
+		t.Fatalf("expected the failure to leave the window, got %d requests and %d failures", requests, failures)
+	}
+}
@@ -0,0 +1,87 @@
+package pool
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+const (
+	// latencyWindowSize is how many recent query latencies the p95 hedge delay is
+	// computed over
+	latencyWindowSize = 256
+	// minLatencySamples is how many latencies are needed before the p95 is trusted;
+	// until then queries are not hedged unless a delay is set
+	minLatencySamples = 20
+)
+
+type hedgeKey struct{}
+
+// hedgeSetting overrides the pool's hedging for the queries of a context
+type hedgeSetting struct {
+	enabled bool
+	delay   time.Duration
+}
+
+// WithHedging returns a context under which ExecuteWithRetry hedges queries, whether
+// or not the pool does: a query still running after delay is also sent to a second
+// client, and the first success wins. A zero delay uses the pool's p95 latency.
+func WithHedging(ctx context.Context, delay time.Duration) context.Context {
+	return context.WithValue(ctx, hedgeKey{}, hedgeSetting{enabled: true, delay: delay})
+}
+
+// WithoutHedging returns a context under which ExecuteWithRetry never hedges, for
+// calls that must not run twice, such as broadcasting a transaction
+func WithoutHedging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hedgeKey{}, hedgeSetting{})
+}
+
+func hedging(ctx context.Context) (hedgeSetting, bool) {
+	setting, ok := ctx.Value(hedgeKey{}).(hedgeSetting)
+	return setting, ok
+}
+
+// latencyWindow keeps the latencies of the most recent successful queries
+type latencyWindow struct {
+	samples []float64 // milliseconds
+	next    int
+}
+
+func (w *latencyWindow) add(latencyMS float64) {
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, latencyMS)
+		return
+	}
+	w.samples[w.next] = latencyMS
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+// p95 returns the 95th percentile latency, or false if there are too few samples
+func (w *latencyWindow) p95() (time.Duration, bool) {
+	if len(w.samples) < minLatencySamples {
+		return 0, false
+	}
+	sorted := slices.Clone(w.samples)
+	slices.Sort(sorted)
+	ms := sorted[(len(sorted)*95+99)/100-1]
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// hedgeDelay returns how long a query runs before it is hedged, or false if it is
+// not hedged
+func (cpm *ClientPoolManager[T]) hedgeDelay(ctx context.Context) (time.Duration, bool) {
+	delay, enabled := cpm.cfg.Hedge.Delay, cpm.cfg.Hedge.Enabled
+	if setting, ok := hedging(ctx); ok {
+		delay, enabled = setting.delay, setting.enabled
+	}
+	if !enabled {
+		return 0, false
+	}
+	if delay > 0 {
+		return delay, true
+	}
+
+	cpm.mu.RLock()
+	defer cpm.mu.RUnlock()
+	return cpm.latencies.p95()
+}
@@ -0,0 +1,214 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// hedgeClientWrapper names the metrics of the hedged calls after the "hedge" service
+type hedgeClientWrapper struct {
+	cpm *ClientPoolManager[*testParticipant]
+}
+
+// query runs the operation of the client's URL
+//
+//go:noinline
+func (w *hedgeClientWrapper) query(ctx context.Context, ops map[string]func(ctx context.Context) (string, error)) (string, error) {
+	logger := zerolog.Nop()
+	return ExecuteWithRetry(ctx, w.cpm, &logger, func(ctx context.Context, client *testParticipant) (string, error) {
+		return ops[client.url](ctx)
+	})
+}
+
+// preferStrategy always picks the candidate of the URL if it is there
+type preferStrategy string
+
+func (p preferStrategy) Pick(_ context.Context, cs []Candidate) int {
+	for i, c := range cs {
+		if c.URL == string(p) {
+			return i
+		}
+	}
+	return 0
+}
+
+func hedgeCount(t *testing.T, name string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var total float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "service" && label.GetValue() == "hedge" {
+					total += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+func newHedgeWrapper(t *testing.T, cfg config.PoolConfig, primary string) *hedgeClientWrapper {
+	t.Helper()
+	clients := []*testParticipant{{url: "slow"}, {url: "fast"}}
+	cpm := NewClientPoolManager(clients, cfg, zerolog.Nop(), WithStrategy(preferStrategy(primary)))
+	t.Cleanup(cpm.Close)
+	return &hedgeClientWrapper{cpm: cpm}
+}
+
+func blockUntilCanceled(canceled chan<- struct{}) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		close(canceled)
+		return "", ctx.Err()
+	}
+}
+
+func answer(result string, delay time.Duration) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		time.Sleep(delay)
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return result, nil
+	}
+}
+
+func TestHedgedQueryReturnsFirstSuccessAndCancelsTheOther(t *testing.T) {
+	w := newHedgeWrapper(t, config.PoolConfig{Hedge: config.HedgeConfig{Enabled: true, Delay: 20 * time.Millisecond}}, "slow")
+	issued, won := hedgeCount(t, "rpc_hedges_issued_total"), hedgeCount(t, "rpc_hedges_won_total")
+
+	canceled := make(chan struct{})
+	got, err := w.query(t.Context(), map[string]func(context.Context) (string, error){
+		"slow": blockUntilCanceled(canceled),
+		"fast": answer("fast", 0),
+	})
+	if err != nil || got != "fast" {
+		t.Fatalf("expected the hedge to answer, got %q, %v", got, err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the slow attempt to be canceled")
+	}
+
+	if n := hedgeCount(t, "rpc_hedges_issued_total") - issued; n != 1 {
+		t.Errorf("expected 1 hedge issued, got %v", n)
+	}
+	if n := hedgeCount(t, "rpc_hedges_won_total") - won; n != 1 {
+		t.Errorf("expected 1 hedge won, got %v", n)
+	}
+	if active := activeURLs(w.cpm); !active["slow"] {
+		t.Error("expected the canceled attempt not to count against its node")
+	}
+}
+
+func TestHedgingSkipsFastQueries(t *testing.T) {
+	w := newHedgeWrapper(t, config.PoolConfig{Hedge: config.HedgeConfig{Enabled: true, Delay: time.Second}}, "fast")
+	issued := hedgeCount(t, "rpc_hedges_issued_total")
+
+	got, err := w.query(t.Context(), map[string]func(context.Context) (string, error){
+		"slow": answer("slow", 0),
+		"fast": answer("fast", 0),
+	})
+	if err != nil || got != "fast" {
+		t.Fatalf("expected the first attempt to answer, got %q, %v", got, err)
+	}
+	if n := hedgeCount(t, "rpc_hedges_issued_total") - issued; n != 0 {
+		t.Errorf("expected no hedge, got %v", n)
+	}
+}
+
+func TestHedgedQueryWaitsForOriginalWhenHedgeFails(t *testing.T) {
+	w := newHedgeWrapper(t, config.PoolConfig{Hedge: config.HedgeConfig{Enabled: true, Delay: 10 * time.Millisecond}}, "slow")
+	won := hedgeCount(t, "rpc_hedges_won_total")
+
+	got, err := w.query(t.Context(), map[string]func(context.Context) (string, error){
+		"slow": answer("slow", 80*time.Millisecond),
+		"fast": func(context.Context) (string, error) { return "", errors.New("unavailable") },
+	})
+	if err != nil || got != "slow" {
+		t.Fatalf("expected the original attempt to answer, got %q, %v", got, err)
+	}
+	if n := hedgeCount(t, "rpc_hedges_won_total") - won; n != 0 {
+		t.Errorf("expected the hedge not to win, got %v", n)
+	}
+}
+
+func TestWithHedgingEnablesHedgingPerCall(t *testing.T) {
+	ops := func() map[string]func(context.Context) (string, error) {
+		return map[string]func(context.Context) (string, error){
+			"slow": blockUntilCanceled(make(chan struct{})),
+			"fast": answer("fast", 0),
+		}
+	}
+
+	// Pools do not hedge by default
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := newHedgeWrapper(t, config.PoolConfig{}, "slow").query(ctx, ops()); err == nil {
+		t.Fatal("expected the unhedged query to wait for the slow node")
+	}
+
+	ctx = WithHedging(t.Context(), 10*time.Millisecond)
+	got, err := newHedgeWrapper(t, config.PoolConfig{}, "slow").query(ctx, ops())
+	if err != nil || got != "fast" {
+		t.Fatalf("expected the hedge to answer, got %q, %v", got, err)
+	}
+}
+
+func TestWithoutHedgingDisablesHedgingPerCall(t *testing.T) {
+	w := newHedgeWrapper(t, config.PoolConfig{Hedge: config.HedgeConfig{Enabled: true, Delay: 5 * time.Millisecond}}, "slow")
+	issued := hedgeCount(t, "rpc_hedges_issued_total")
+
+	ctx := WithoutHedging(WithHedging(t.Context(), 5*time.Millisecond))
+	got, err := w.query(ctx, map[string]func(context.Context) (string, error){
+		"slow": answer("slow", 50*time.Millisecond),
+		"fast": answer("fast", 0),
+	})
+	if err != nil || got != "slow" {
+		t.Fatalf("expected the only attempt to answer, got %q, %v", got, err)
+	}
+	if n := hedgeCount(t, "rpc_hedges_issued_total") - issued; n != 0 {
+		t.Errorf("expected no hedge, got %v", n)
+	}
+}
+
+func TestLatencyWindowP95(t *testing.T) {
+	var w latencyWindow
+	for i := 1; i < minLatencySamples; i++ {
+		w.add(float64(i))
+	}
+	if _, ok := w.p95(); ok {
+		t.Fatal("expected no p95 before enough samples")
+	}
+
+	w = latencyWindow{}
+	for i := 1; i <= 100; i++ {
+		w.add(float64(i))
+	}
+	if p95, ok := w.p95(); !ok || p95 != 95*time.Millisecond {
+		t.Fatalf("expected a p95 of 95ms, got %v", p95)
+	}
+
+	// Old samples roll out of the window
+	for i := 0; i < latencyWindowSize; i++ {
+		w.add(1000)
+	}
+	if p95, _ := w.p95(); p95 != time.Second {
+		t.Fatalf("expected a p95 of 1s after the window rolled, got %v", p95)
+	}
+}
@@ -0,0 +1,85 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// NodeStatus is what a health check learns about a node's chain state; zero heights
+// are unknown
+type NodeStatus struct {
+	LatestHeight int64
+	// EarliestHeight is the earliest block the node still serves
+	EarliestHeight int64
+	CatchingUp     bool
+}
+
+// retains reports whether the node still serves queries at height
+func (s NodeStatus) retains(height int64) bool {
+	return s.EarliestHeight == 0 || s.EarliestHeight <= height
+}
+
+// TendermintService is the part of the Cosmos Tendermint query service a node's
+// status is read from
+type TendermintService interface {
+	GetSyncing(ctx context.Context, req *cmtservice.GetSyncingRequest, opts ...config.CallOpt) (*cmtservice.GetSyncingResponse, error)
+	GetLatestBlock(ctx context.Context, req *cmtservice.GetLatestBlockRequest, opts ...config.CallOpt) (*cmtservice.GetLatestBlockResponse, error)
+	GetBlockByHeight(ctx context.Context, req *cmtservice.GetBlockByHeightRequest, opts ...config.CallOpt) (*cmtservice.GetBlockByHeightResponse, error)
+}
+
+// QueryNodeStatus reads whether the node is catching up and the range of blocks it
+// serves. The earliest block is best effort: it is left unknown if the node does not
+// report it.
+func QueryNodeStatus(ctx context.Context, svc TendermintService) (NodeStatus, error) {
+	syncing, err := svc.GetSyncing(ctx, &cmtservice.GetSyncingRequest{})
+	if err != nil {
+		return NodeStatus{}, err
+	}
+	latest, err := LatestHeight(ctx, svc)
+	if err != nil {
+		return NodeStatus{}, err
+	}
+	status := NodeStatus{LatestHeight: latest, CatchingUp: syncing.Syncing}
+	if earliest, err := EarliestHeight(ctx, svc); err == nil {
+		status.EarliestHeight = earliest
+	}
+	return status, nil
+}
+
+// LatestHeight returns the height of the node's latest block
+func LatestHeight(ctx context.Context, svc TendermintService) (int64, error) {
+	resp, err := svc.GetLatestBlock(ctx, &cmtservice.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, err
+	}
+	if resp.SdkBlock != nil {
+		return resp.SdkBlock.Header.Height, nil
+	}
+	if resp.Block != nil {
+		return resp.Block.Header.Height, nil
+	}
+	return 0, fmt.Errorf("latest block response has no block")
+}
+
+// lowestHeightRegexp matches CometBFT's error for blocks below the pruning horizon
+var lowestHeightRegexp = regexp.MustCompile(`lowest height is (\d+)`)
+
+// EarliestHeight returns the earliest block a node still serves: asked for block 1,
+// a pruned node answers "height 1 is not available, lowest height is N"
+func EarliestHeight(ctx context.Context, svc TendermintService) (int64, error) {
+	_, err := svc.GetBlockByHeight(ctx, &cmtservice.GetBlockByHeightRequest{Height: 1})
+	if err == nil {
+		return 1, nil
+	}
+	m := lowestHeightRegexp.FindStringSubmatch(err.Error())
+	if len(m) != 2 {
+		return 0, err
+	}
+	return strconv.ParseInt(m[1], 10, 64)
+}
@@ -0,0 +1,65 @@
+package pool
+
+import (
+	"context"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+type (
+	clientFilterKey struct{}
+	queryHeightKey  struct{}
+)
+
+// WithClientFilter returns a context under which ExecuteWithRetry only picks the
+// clients for which eligible returns true
+func WithClientFilter(ctx context.Context, eligible func(PoolParticipant) bool) context.Context {
+	return context.WithValue(ctx, clientFilterKey{}, eligible)
+}
+
+func clientFilter(ctx context.Context) func(PoolParticipant) bool {
+	eligible, _ := ctx.Value(clientFilterKey{}).(func(PoolParticipant) bool)
+	return eligible
+}
+
+// withQueryHeight records the height a query runs at, so that ExecuteWithRetry only
+// picks the clients that still serve it
+func withQueryHeight(ctx context.Context, height int64) context.Context {
+	return context.WithValue(ctx, queryHeightKey{}, height)
+}
+
+// queryHeight returns the height recorded by withQueryHeight; zero is the latest
+func queryHeight(ctx context.Context) int64 {
+	height, _ := ctx.Value(queryHeightKey{}).(int64)
+	return height
+}
+
+// Pin runs queries at one height, on the clients that can serve it. A nil *Pin pins
+// nothing.
+type Pin struct {
+	Height int64
+	// Eligible, if set, returns which clients can serve queries at Height. It is
+	// called once per query, before a client is picked.
+	Eligible func(ctx context.Context) func(PoolParticipant) bool
+}
+
+// Apply pins the call options to the height, overriding any height the caller set,
+// and restricts the context to the eligible clients. Whether pinned or not, the
+// context records the query's height so that nodes which pruned it are skipped.
+func (p *Pin) Apply(ctx context.Context, opts []config.CallOpt) (context.Context, []config.CallOpt) {
+	if p == nil {
+		callOpts := config.DefaultCallOpts()
+		callOpts.Apply(opts...)
+		if callOpts.Height > 0 {
+			ctx = withQueryHeight(ctx, callOpts.Height)
+		}
+		return ctx, opts
+	}
+	ctx = withQueryHeight(ctx, p.Height)
+	if p.Eligible != nil {
+		if eligible := p.Eligible(ctx); eligible != nil {
+			ctx = WithClientFilter(ctx, eligible)
+		}
+	}
+	return ctx, append(opts[:len(opts):len(opts)], config.Height(p.Height))
+}
@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+func TestPinApply(t *testing.T) {
+	ctx := context.Background()
+	opts := make([]config.CallOpt, 1, 4)
+	opts[0] = config.Height(5)
+
+	// A nil pin leaves the call untouched but records its height
+	var none *Pin
+	gotCtx, gotOpts := none.Apply(ctx, opts)
+	if len(gotOpts) != 1 || clientFilter(gotCtx) != nil {
+		t.Fatal("expected a nil pin to leave the call untouched")
+	}
+	if h := queryHeight(gotCtx); h != 5 {
+		t.Fatalf("expected the query height 5 to be recorded, got %d", h)
+	}
+	if latest, _ := none.Apply(ctx, nil); queryHeight(latest) != 0 {
+		t.Fatal("expected latest-height queries to record no height")
+	}
+
+	pin := &Pin{
+		Height: 42,
+		Eligible: func(context.Context) func(PoolParticipant) bool {
+			return func(client PoolParticipant) bool { return client.GetEndpointURL() == "archive" }
+		},
+	}
+	gotCtx, gotOpts = pin.Apply(ctx, opts)
+
+	callOpts := config.DefaultCallOpts()
+	callOpts.Apply(gotOpts...)
+	if callOpts.Height != 42 || queryHeight(gotCtx) != 42 {
+		t.Errorf("expected the pinned height to override the caller's, got %d", callOpts.Height)
+	}
+
+	// The caller's backing array is not written to, even with spare capacity
+	if opts[:2][1] != nil {
+		t.Error("expected the caller's options to be left alone")
+	}
+
+	eligible := clientFilter(gotCtx)
+	if eligible == nil {
+		t.Fatal("expected the context to carry the client filter")
+	}
+	if !eligible(&testParticipant{url: "archive"}) || eligible(&testParticipant{url: "pruned"}) {
+		t.Error("expected the filter to follow the pin's eligibility")
+	}
+}
+
+// testParticipant reports the node status it is set to
+type testParticipant struct {
+	url string
+
+	mu     sync.Mutex
+	status NodeStatus
+	err    error
+}
+
+func (p *testParticipant) Close() error                 { return nil }
+func (p *testParticipant) GetEndpointURL() string       { return p.url }
+func (p *testParticipant) GetProtocol() config.Protocol { return config.ProtocolGRPC }
+
+func (p *testParticipant) HealthCheck(context.Context) (NodeStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status, p.err
+}
+
+func (p *testParticipant) set(status NodeStatus, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status, p.err = status, err
+}
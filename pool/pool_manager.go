@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand"
 	"net"
+	"net/http"
 	"runtime"
 	"sort"
 	"strings"
@@ -23,29 +24,25 @@ import (
 
 // ClientPoolManager manages a pool of Client instances with health tracking and load balancing
 type ClientPoolManager[T PoolParticipant] struct {
-	mu                       sync.RWMutex
-	active, cooling          []ClientInfo[T]
-	currentIndex             int // round-robin index for load distribution
-	checkRate                time.Duration
-	coolingThreshold         float64
-	minActiveStreak          int
-	startReactivatedSuccRate float64
-	rateLimitDelayIncrease   time.Duration
-	maxRateLimitDelay        time.Duration
-	logger                   zerolog.Logger
-
-	backMu     sync.Mutex
-	backoff    map[string]*backoffState
-	baseDelay  time.Duration
-	maxDelay   time.Duration
-	jitterFrac float64
+	mu          sync.RWMutex
+	active      []ClientInfo[T] // closed breakers
+	cooling     []ClientInfo[T] // open and half-open breakers
+	strategy    Strategy
+	lastPicked  *T // the client of the last query, for health reporting
+	cfg         config.PoolConfig
+	latencies   latencyWindow       // of successful queries, for the p95 hedge delay
+	transitions []breakerTransition // waiting for the OnStateChange callback
+	logger      zerolog.Logger
+
+	backMu  sync.Mutex
+	backoff map[string]*backoffState
 }
 
 type PoolParticipant interface {
 	Close() error
 	GetEndpointURL() string
 	GetProtocol() config.Protocol
-	HealthCheck(ctx context.Context) error
+	HealthCheck(ctx context.Context) (NodeStatus, error)
 }
 
 // ClientInfo wraps an Client with health tracking metadata
@@ -56,60 +53,87 @@ type ClientInfo[T PoolParticipant] struct {
 	latEWMA        float64 // exponential-weighted moving average in milliseconds
 	healthStreak   int
 	rateLimitDelay time.Duration
+	weight         int
+	status         NodeStatus // as of the last passed health check
+	breaker        breaker
 }
 
-const (
-	defaultClientCheckRate                = 10 * time.Second
-	defaultClientCoolingThreshold         = 0.5
-	defaultClientMinActiveStreak          = 3
-	defaultClientStartReactivatedSuccRate = 0.8
-	defaultClientRateLimitDelayIncrease   = 100 * time.Millisecond
-	defaultClientMaxRateLimitDelay        = 2 * time.Second
+// ManagerOpt is a functional option for configuring a ClientPoolManager
+type ManagerOpt func(*ManagerOpts)
 
-	// Backoff constants
-	defaultBaseDelay  = 100 * time.Millisecond
-	defaultMaxDelay   = 30 * time.Second
-	defaultJitterFrac = 0.1
-)
+// ManagerOpts holds configuration options for a ClientPoolManager
+type ManagerOpts struct {
+	Strategy Strategy       // overrides the strategy of the pool config
+	Weights  map[string]int // endpoint weights by URL, for the weighted strategy
+}
+
+// Apply applies the provided options to ManagerOpts
+func (o *ManagerOpts) Apply(opts ...ManagerOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithStrategy sets the strategy that picks the client of each query
+func WithStrategy(strategy Strategy) ManagerOpt {
+	return func(opts *ManagerOpts) {
+		opts.Strategy = strategy
+	}
+}
+
+// WithEndpointWeights sets the endpoint weights by URL; endpoints without a
+// positive weight weigh 1
+func WithEndpointWeights(weights map[string]int) ManagerOpt {
+	return func(opts *ManagerOpts) {
+		opts.Weights = weights
+	}
+}
+
+// EndpointWeights returns the weights of the endpoints by URL
+func EndpointWeights(endpoints []config.EndpointConfig) map[string]int {
+	weights := make(map[string]int, len(endpoints))
+	for _, endpoint := range endpoints {
+		weights[endpoint.URL] = endpoint.Weight
+	}
+	return weights
+}
 
 type backoffState struct {
 	failures int
 	until    time.Time
 }
 
-// NewClientPoolManager creates a new client pool manager with the provided clients
-func NewClientPoolManager[T PoolParticipant](clients []T, logger zerolog.Logger) *ClientPoolManager[T] {
+// NewClientPoolManager creates a new client pool manager with the provided clients.
+// Zero values in cfg are replaced by the defaults of config.DefaultPoolConfig.
+func NewClientPoolManager[T PoolParticipant](clients []T, cfg config.PoolConfig, logger zerolog.Logger, opts ...ManagerOpt) *ClientPoolManager[T] {
+	cfg = withPoolDefaults(cfg)
+
+	managerOpts := &ManagerOpts{}
+	managerOpts.Apply(opts...)
+	if managerOpts.Strategy == nil {
+		managerOpts.Strategy = NewStrategy(cfg.Strategy)
+	}
+
 	clientInfos := make([]ClientInfo[T], len(clients))
 	for i, client := range clients {
 		clientInfos[i] = ClientInfo[T]{
 			Client:      client,
-			MaxRetries:  2,   // Default retry count (matches NodeManager LB=2)
+			MaxRetries:  cfg.MaxRetries,
 			successRate: 1.0, // Start with perfect success rate
+			weight:      max(managerOpts.Weights[client.GetEndpointURL()], 1),
+			breaker:     breaker{state: config.BreakerClosed},
 		}
-	}
-
-	// Use random starting offset to distribute initial load
-	startOffset := 0
-	if len(clients) > 1 {
-		startOffset = int(time.Now().UnixNano()) % len(clients)
+		metrics.SetBreakerState(client.GetEndpointURL(), string(config.BreakerClosed))
 	}
 
 	cpm := &ClientPoolManager[T]{
-		active:                   clientInfos,
-		currentIndex:             startOffset,
-		checkRate:                defaultClientCheckRate,
-		coolingThreshold:         defaultClientCoolingThreshold,
-		minActiveStreak:          defaultClientMinActiveStreak,
-		startReactivatedSuccRate: defaultClientStartReactivatedSuccRate,
-		rateLimitDelayIncrease:   defaultClientRateLimitDelayIncrease,
-		maxRateLimitDelay:        defaultClientMaxRateLimitDelay,
-		logger:                   logger.With().Str("component", "client_pool_manager").Logger(),
+		active:   clientInfos,
+		strategy: managerOpts.Strategy,
+		cfg:      cfg,
+		logger:   logger.With().Str("component", "client_pool_manager").Logger(),
 
 		// Initialize backoff management
-		backoff:    make(map[string]*backoffState),
-		baseDelay:  defaultBaseDelay,
-		maxDelay:   defaultMaxDelay,
-		jitterFrac: defaultJitterFrac,
+		backoff: make(map[string]*backoffState),
 	}
 
 	cpm.sortActive()
@@ -119,6 +143,63 @@ func NewClientPoolManager[T PoolParticipant](clients []T, logger zerolog.Logger)
 	return cpm
 }
 
+// withPoolDefaults replaces the zero values of cfg by the defaults
+func withPoolDefaults(cfg config.PoolConfig) config.PoolConfig {
+	defaults := config.DefaultPoolConfig()
+	if cfg.MaxHeightLag == 0 {
+		cfg.MaxHeightLag = defaults.MaxHeightLag
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = defaults.HealthCheckInterval
+	}
+	if cfg.HealthCheckTimeout <= 0 {
+		cfg.HealthCheckTimeout = defaults.HealthCheckTimeout
+	}
+	if cfg.HealthyStreak <= 0 {
+		cfg.HealthyStreak = defaults.HealthyStreak
+	}
+	if cfg.ReactivatedSuccessRate <= 0 {
+		cfg.ReactivatedSuccessRate = defaults.ReactivatedSuccessRate
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaults.MaxRetries
+	}
+	if cfg.RateLimitDelayIncrease <= 0 {
+		cfg.RateLimitDelayIncrease = defaults.RateLimitDelayIncrease
+	}
+	if cfg.MaxRateLimitDelay <= 0 {
+		cfg.MaxRateLimitDelay = defaults.MaxRateLimitDelay
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = defaults.BackoffBase
+	}
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = defaults.BackoffMax
+	}
+	if cfg.BackoffJitter <= 0 {
+		cfg.BackoffJitter = defaults.BackoffJitter
+	}
+	if cfg.Breaker.FailureRatio <= 0 {
+		cfg.Breaker.FailureRatio = defaults.Breaker.FailureRatio
+	}
+	if cfg.Breaker.Window <= 0 {
+		cfg.Breaker.Window = defaults.Breaker.Window
+	}
+	if cfg.Breaker.MinRequests <= 0 {
+		cfg.Breaker.MinRequests = defaults.Breaker.MinRequests
+	}
+	if cfg.Breaker.OpenTimeout <= 0 {
+		cfg.Breaker.OpenTimeout = defaults.Breaker.OpenTimeout
+	}
+	if cfg.Breaker.HalfOpenRequests <= 0 {
+		cfg.Breaker.HalfOpenRequests = defaults.Breaker.HalfOpenRequests
+	}
+	if cfg.Breaker.HalfOpenSuccesses <= 0 {
+		cfg.Breaker.HalfOpenSuccesses = defaults.Breaker.HalfOpenSuccesses
+	}
+	return cfg
+}
+
 func (cpm *ClientPoolManager[T]) Close() {
 	cpm.mu.Lock()
 	defer cpm.mu.Unlock()
@@ -135,21 +216,36 @@ func (cpm *ClientPoolManager[T]) Close() {
 	}
 }
 
-// GetClient returns the next client using round-robin selection among active clients
+// GetClient returns the active client chosen by the pool's strategy, or a half-open
+// client that may take a trial query
 // The skip function allows filtering clients based on custom criteria (e.g. backoff state)
 func (cpm *ClientPoolManager[T]) GetClient(skip func(T) bool) (T, bool) {
-	var zero T
 	if skip == nil {
 		skip = func(T) bool { return false } // Default: don't skip anything
 	}
+	return cpm.pick(context.Background(), func(info *ClientInfo[T]) bool { return skip(info.Client) })
+}
+
+// pick is GetClient with a skip function that also sees the clients' health
+// tracking. skip is called with the pool locked.
+func (cpm *ClientPoolManager[T]) pick(ctx context.Context, skip func(*ClientInfo[T]) bool) (T, bool) {
+	var zero T
+	defer cpm.notifyBreakerTransitions()
 	cpm.mu.Lock()
 	defer cpm.mu.Unlock()
 
+	// Half-open clients take their trial queries first, so that they get decided
+	if clientInfo, ok := cpm.pickTrial(skip); ok {
+		client := clientInfo.Client
+		cpm.lastPicked = &client
+		return client, true
+	}
+
 	if len(cpm.active) == 0 {
 		// fallback to cooling pool
 		for i := range cpm.cooling {
 			client := cpm.cooling[i].Client
-			if !skip(client) {
+			if !skip(&cpm.cooling[i]) {
 				// Track cooling pool usage
 				cpm.logger.Warn().Str("client_url", client.GetEndpointURL()).Msg("using cooling pool client - no active clients available")
 				return client, true
@@ -158,22 +254,32 @@ func (cpm *ClientPoolManager[T]) GetClient(skip func(T) bool) (T, bool) {
 		return zero, false
 	}
 
-	// Round-robin through active clients
-	startIdx := cpm.currentIndex
-	for i := 0; i < len(cpm.active); i++ {
-		idx := (startIdx + i) % len(cpm.active)
-		client := cpm.active[idx].Client
-		if !skip(client) {
-			cpm.currentIndex = (idx + 1) % len(cpm.active)
-
-			return client, true
+	// Let the strategy choose among the active clients that are not skipped
+	candidates := make([]Candidate, 0, len(cpm.active))
+	indexes := make([]int, 0, len(cpm.active))
+	for i := range cpm.active {
+		clientInfo := &cpm.active[i]
+		if skip(clientInfo) {
+			continue
 		}
+		candidates = append(candidates, Candidate{
+			URL:         clientInfo.Client.GetEndpointURL(),
+			SuccessRate: clientInfo.successRate,
+			LatencyMS:   clientInfo.latEWMA,
+			Weight:      clientInfo.weight,
+		})
+		indexes = append(indexes, i)
+	}
+	if len(candidates) > 0 {
+		client := cpm.active[indexes[cpm.strategy.Pick(ctx, candidates)]].Client
+		cpm.lastPicked = &client
+		return client, true
 	}
 
 	// fallback to cooling pool if all active clients skipped
 	for i := range cpm.cooling {
 		client := cpm.cooling[i].Client
-		if !skip(client) {
+		if !skip(&cpm.cooling[i]) {
 			// Track cooling pool usage
 			cpm.logger.Warn().Str("client_url", client.GetEndpointURL()).Msg("using cooling pool client - all active clients skipped")
 			return client, true
@@ -183,8 +289,24 @@ func (cpm *ClientPoolManager[T]) GetClient(skip func(T) bool) (T, bool) {
 	return zero, false
 }
 
+// Clients returns every client in the pool, active ones first
+func (cpm *ClientPoolManager[T]) Clients() []T {
+	cpm.mu.RLock()
+	defer cpm.mu.RUnlock()
+
+	clients := make([]T, 0, len(cpm.active)+len(cpm.cooling))
+	for i := range cpm.active {
+		clients = append(clients, cpm.active[i].Client)
+	}
+	for i := range cpm.cooling {
+		clients = append(clients, cpm.cooling[i].Client)
+	}
+	return clients
+}
+
 // ReportHealth reports the health status of a client operation
 func (cpm *ClientPoolManager[T]) ReportHealth(client T, tries int, latencyMS float64, success bool) {
+	defer cpm.notifyBreakerTransitions()
 	cpm.mu.Lock()
 	defer cpm.mu.Unlock()
 
@@ -193,30 +315,7 @@ func (cpm *ClientPoolManager[T]) ReportHealth(client T, tries int, latencyMS flo
 	}
 
 	clientURL := client.GetEndpointURL()
-	var clientInfo *ClientInfo[T]
-	var foundInActive bool
-	var activeIndex int
-
-	// Find the client in active pool
-	for i := range cpm.active {
-		if cpm.active[i].Client.GetEndpointURL() == clientURL {
-			clientInfo = &cpm.active[i]
-			foundInActive = true
-			activeIndex = i
-			break
-		}
-	}
-
-	// If not in active, check cooling pool
-	if !foundInActive {
-		for i := range cpm.cooling {
-			if cpm.cooling[i].Client.GetEndpointURL() == clientURL {
-				clientInfo = &cpm.cooling[i]
-				break
-			}
-		}
-	}
-
+	clientInfo := cpm.find(clientURL)
 	if clientInfo == nil {
 		cpm.logger.Error().
 			Str("client_url", clientURL).
@@ -229,6 +328,8 @@ func (cpm *ClientPoolManager[T]) ReportHealth(client T, tries int, latencyMS flo
 	clientInfo.successRate = math.Pow(0.8, float64(tries-1))
 
 	if success {
+		cpm.latencies.add(latencyMS)
+
 		const alpha = 0.3
 		if clientInfo.latEWMA == 0 {
 			clientInfo.latEWMA = latencyMS
@@ -239,16 +340,22 @@ func (cpm *ClientPoolManager[T]) ReportHealth(client T, tries int, latencyMS flo
 		clientInfo.successRate = 0
 	}
 
-	// Handle cooling/activation logic
-	if foundInActive {
-		if clientInfo.successRate < cpm.coolingThreshold {
-			cpm.coolClientByIndex(activeIndex)
-		} else {
-			cpm.sortActive()
+	// The breaker may cool or activate the client, which sorts the pools
+	cpm.recordOutcome(clientInfo, success)
+	cpm.sortActive()
+	cpm.sortCooling()
+}
+
+// find returns the health tracking of the client of the URL, or nil
+func (cpm *ClientPoolManager[T]) find(clientURL string) *ClientInfo[T] {
+	for _, clientInfos := range [][]ClientInfo[T]{cpm.active, cpm.cooling} {
+		for i := range clientInfos {
+			if clientInfos[i].Client.GetEndpointURL() == clientURL {
+				return &clientInfos[i]
+			}
 		}
-	} else {
-		cpm.sortCooling()
 	}
+	return nil
 }
 
 // UpdateRateLimitDelay increases the rate limit delay for a client
@@ -277,26 +384,32 @@ func (cpm *ClientPoolManager[T]) UpdateRateLimitDelay(client T) {
 
 // Helper methods (reused from NodeManager)
 func (cpm *ClientPoolManager[T]) increaseRateLimit(current time.Duration) time.Duration {
-	newDelay := current + cpm.rateLimitDelayIncrease
-	if newDelay > cpm.maxRateLimitDelay {
-		return cpm.maxRateLimitDelay
+	newDelay := current + cpm.cfg.RateLimitDelayIncrease
+	if newDelay > cpm.cfg.MaxRateLimitDelay {
+		return cpm.cfg.MaxRateLimitDelay
 	}
 	return newDelay
 }
 
-func (cpm *ClientPoolManager[T]) coolClientByIndex(index int) {
+// coolClient opens the breaker of an active client and moves it to the cooling pool
+func (cpm *ClientPoolManager[T]) coolClient(clientInfo *ClientInfo[T], reason string) {
+	for i := range cpm.active {
+		if &cpm.active[i] == clientInfo {
+			cpm.coolClientByIndex(i, reason)
+			return
+		}
+	}
+}
+
+func (cpm *ClientPoolManager[T]) coolClientByIndex(index int, reason string) {
 	if index >= len(cpm.active) {
 		return
 	}
+	cpm.setBreakerState(&cpm.active[index], config.BreakerOpen, reason)
 	client := cpm.active[index]
 	cpm.active = append(cpm.active[:index], cpm.active[index+1:]...)
 	cpm.cooling = append(cpm.cooling, client)
 	cpm.sortCooling()
-
-	// Adjust currentIndex if necessary
-	if cpm.currentIndex >= len(cpm.active) && len(cpm.active) > 0 {
-		cpm.currentIndex = 0
-	}
 }
 
 func (cpm *ClientPoolManager[T]) sortActive() {
@@ -326,34 +439,84 @@ func sortClientPool[T PoolParticipant](clients []ClientInfo[T]) {
 	})
 }
 
-// healthLoop continuously probes cooling clients to check if they should be reactivated
+// healthLoop continuously probes the clients to track their heights and reactivate
+// cooling ones
 func (cpm *ClientPoolManager[T]) healthLoop() {
-	tk := time.NewTicker(cpm.checkRate)
+	tk := time.NewTicker(cpm.cfg.HealthCheckInterval)
 	defer tk.Stop()
 
 	for range tk.C {
-		cpm.probeCooling()
+		cpm.probe()
 	}
 }
 
-// probeCooling checks each cooling client and reactivates healthy ones
-func (cpm *ClientPoolManager[T]) probeCooling() {
+// probe health-checks every client and records the node status they report. It
+// opens the breakers of clients that are catching up or lag the highest node by
+// more than MaxHeightLag blocks, and closes those of cooling clients that pass
+// HealthyStreak checks in a row without being behind.
+func (cpm *ClientPoolManager[T]) probe() {
+	clients := cpm.Clients()
+	statuses := make(map[string]NodeStatus, len(clients))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if status, ok := cpm.pingClient(client); ok {
+				mu.Lock()
+				statuses[client.GetEndpointURL()] = status
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	defer cpm.notifyBreakerTransitions()
 	cpm.mu.Lock()
 	defer cpm.mu.Unlock()
 
+	for _, clientInfos := range [][]ClientInfo[T]{cpm.active, cpm.cooling} {
+		for i := range clientInfos {
+			if status, ok := statuses[clientInfos[i].Client.GetEndpointURL()]; ok {
+				clientInfos[i].status = status
+			}
+		}
+	}
+	maxHeight := cpm.maxHeight()
+
+	for i := len(cpm.active) - 1; i >= 0; i-- {
+		clientInfo := &cpm.active[i]
+		if reason := cpm.behind(clientInfo, maxHeight); reason != "" {
+			cpm.logger.Warn().
+				Str("client_url", clientInfo.Client.GetEndpointURL()).
+				Int64("height", clientInfo.status.LatestHeight).
+				Int64("max_height", maxHeight).
+				Msgf("cooling client: %s", reason)
+			cpm.coolClientByIndex(i, reason)
+		}
+	}
+
 	activatingIndexes := make([]int, 0)
 
 	for i := 0; i < len(cpm.cooling); i++ {
 		clientInfo := &cpm.cooling[i]
-		if cpm.pingClient(clientInfo.Client) {
+		_, passed := statuses[clientInfo.Client.GetEndpointURL()]
+		reason := cpm.behind(clientInfo, maxHeight)
+		if passed && reason == "" {
 			clientInfo.healthStreak++
 			cpm.logger.Debug().Str("client_url", clientInfo.Client.GetEndpointURL()).Int("streak", clientInfo.healthStreak).Msg("client health check passed")
-			if clientInfo.healthStreak >= cpm.minActiveStreak {
+			if clientInfo.healthStreak >= cpm.cfg.HealthyStreak {
 				clientInfo.healthStreak = 0
-				clientInfo.successRate = cpm.startReactivatedSuccRate
+				clientInfo.successRate = cpm.cfg.ReactivatedSuccessRate
 				activatingIndexes = append(activatingIndexes, i)
 			}
 		} else {
+			if reason != "" && clientInfo.breaker.state == config.BreakerHalfOpen {
+				cpm.setBreakerState(clientInfo, config.BreakerOpen, reason)
+			}
 			clientInfo.healthStreak = 0
 			cpm.logger.Debug().Str("client_url", clientInfo.Client.GetEndpointURL()).Msg("client health check failed")
 		}
@@ -365,22 +528,59 @@ func (cpm *ClientPoolManager[T]) probeCooling() {
 	}
 }
 
+// maxHeight returns the highest latest height reported by any client
+func (cpm *ClientPoolManager[T]) maxHeight() int64 {
+	var height int64
+	for _, clientInfos := range [][]ClientInfo[T]{cpm.active, cpm.cooling} {
+		for i := range clientInfos {
+			height = max(height, clientInfos[i].status.LatestHeight)
+		}
+	}
+	return height
+}
+
+// behind returns why a client should not serve traffic given the highest height in
+// the pool, or "" if it is in sync. Clients of unknown height are never behind.
+func (cpm *ClientPoolManager[T]) behind(clientInfo *ClientInfo[T], maxHeight int64) string {
+	status := clientInfo.status
+	if status.CatchingUp {
+		return "node is catching up"
+	}
+	if cpm.cfg.MaxHeightLag >= 0 && status.LatestHeight > 0 && maxHeight-status.LatestHeight > cpm.cfg.MaxHeightLag {
+		return fmt.Sprintf("node lags %d blocks behind the pool", maxHeight-status.LatestHeight)
+	}
+	return ""
+}
+
 // pingClient performs a health check on the client
 // For JSON-RPC clients, it calls /status endpoint
-// For gRPC clients, it calls the Status method
-func (cpm *ClientPoolManager[T]) pingClient(client T) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+// For gRPC and REST clients, it queries the Tendermint service
+func (cpm *ClientPoolManager[T]) pingClient(client T) (NodeStatus, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), cpm.cfg.HealthCheckTimeout)
 	defer cancel()
 
-	// Use the client's Status method for health checking
-	err := client.HealthCheck(ctx)
+	status, err := client.HealthCheck(ctx)
 	if err != nil {
 		cpm.logger.Debug().Err(err).Str("client_url", client.GetEndpointURL()).Msg("client health check failed")
-		return false
+		return NodeStatus{}, false
 	}
 
-	cpm.logger.Debug().Str("client_url", client.GetEndpointURL()).Msg("client health check passed")
-	return true
+	cpm.logger.Debug().
+		Str("client_url", client.GetEndpointURL()).
+		Int64("height", status.LatestHeight).
+		Bool("catching_up", status.CatchingUp).
+		Msg("client health check passed")
+	return status, true
+}
+
+// activateClient closes the breaker of a cooling client and moves it to the active pool
+func (cpm *ClientPoolManager[T]) activateClient(clientInfo *ClientInfo[T]) {
+	for i := range cpm.cooling {
+		if &cpm.cooling[i] == clientInfo {
+			cpm.activateClientByIndex(i)
+			return
+		}
+	}
 }
 
 // activateClientByIndex moves a client from cooling to active pool
@@ -388,6 +588,7 @@ func (cpm *ClientPoolManager[T]) activateClientByIndex(index int) {
 	if index >= len(cpm.cooling) {
 		return
 	}
+	cpm.setBreakerState(&cpm.cooling[index], config.BreakerClosed, "client is healthy again")
 	client := cpm.cooling[index]
 	cpm.cooling = append(cpm.cooling[:index], cpm.cooling[index+1:]...)
 	cpm.active = append(cpm.active, client)
@@ -456,9 +657,9 @@ func (cpm *ClientPoolManager[T]) computeAndSetBackoff(clientURL string, base tim
 		fails = 30
 	}
 
-	delay := min(base*time.Duration(1<<fails), cpm.maxDelay)
+	delay := min(base*time.Duration(1<<fails), cpm.cfg.BackoffMax)
 
-	jitter := float64(delay) * cpm.jitterFrac * (2*rand.Float64() - 1)
+	jitter := float64(delay) * cpm.cfg.BackoffJitter * (2*rand.Float64() - 1)
 	delay = time.Duration(float64(delay) + jitter)
 
 	cpm.backoff[clientURL] = &backoffState{
@@ -515,7 +716,7 @@ func (cpm *ClientPoolManager[T]) GetMaxRetries(client T) int {
 		}
 	}
 
-	return 2 // Default fallback
+	return cpm.cfg.MaxRetries
 }
 
 // GetRateLimitDelay returns the current rate limit delay for a client
@@ -544,18 +745,33 @@ func (cpm *ClientPoolManager[T]) GetRateLimitDelay(client T) time.Duration {
 
 // ExpDelay calculates exponential delay for retry attempt i
 func (cpm *ClientPoolManager[T]) ExpDelay(attempt int) time.Duration {
-	d := float64(cpm.baseDelay) * math.Pow(2, float64(attempt))
-	if d > float64(cpm.maxDelay) {
-		d = float64(cpm.maxDelay)
+	d := float64(cpm.cfg.BackoffBase) * math.Pow(2, float64(attempt))
+	if d > float64(cpm.cfg.BackoffMax) {
+		d = float64(cpm.cfg.BackoffMax)
 	}
-	jitter := d * cpm.jitterFrac * (2*rand.Float64() - 1)
+	jitter := d * cpm.cfg.BackoffJitter * (2*rand.Float64() - 1)
 	return time.Duration(d + jitter)
 }
 
 // GetClientWithBackoff returns a client while respecting backoff states
 // This is a convenience method that combines GetClient with backoff checking
 func (cpm *ClientPoolManager[T]) GetClientWithBackoff() (T, bool) {
-	return cpm.GetClient(func(client T) bool {
+	return cpm.getClientWithBackoff(context.Background(), nil, 0)
+}
+
+// getClientWithBackoff is GetClientWithBackoff restricted to the eligible clients
+// that still serve height; a nil eligible allows all of them and a zero height is
+// the latest
+func (cpm *ClientPoolManager[T]) getClientWithBackoff(ctx context.Context, eligible func(PoolParticipant) bool, height int64) (T, bool) {
+	return cpm.pick(ctx, func(clientInfo *ClientInfo[T]) bool {
+		client := clientInfo.Client
+		if eligible != nil && !eligible(client) {
+			return true
+		}
+		// Skip clients known to have pruned the height
+		if height > 0 && !clientInfo.status.retains(height) {
+			return true
+		}
 		// Skip clients that are in backoff
 		return cpm.IsClientInBackoff(client) > 0
 	})
@@ -570,19 +786,23 @@ func (cpm *ClientPoolManager[T]) GetHealthStatus() map[string]any {
 	status["active_clients"] = len(cpm.active)
 	status["cooling_clients"] = len(cpm.cooling)
 
-	if len(cpm.active) > 0 {
-		status["current_client"] = cpm.active[cpm.currentIndex].Client.GetEndpointURL()
-		status["current_client_protocol"] = cpm.active[cpm.currentIndex].Client.GetProtocol()
+	if cpm.lastPicked != nil {
+		status["current_client"] = (*cpm.lastPicked).GetEndpointURL()
+		status["current_client_protocol"] = (*cpm.lastPicked).GetProtocol()
 	}
 
 	activeSummary := make([]map[string]any, len(cpm.active))
 	for i, clientInfo := range cpm.active {
 		activeSummary[i] = map[string]any{
-			"url":          clientInfo.Client.GetEndpointURL(),
-			"protocol":     clientInfo.Client.GetProtocol(),
-			"success_rate": clientInfo.successRate,
-			"latency_ms":   clientInfo.latEWMA,
-			"max_retries":  clientInfo.MaxRetries,
+			"url":           clientInfo.Client.GetEndpointURL(),
+			"protocol":      clientInfo.Client.GetProtocol(),
+			"success_rate":  clientInfo.successRate,
+			"latency_ms":    clientInfo.latEWMA,
+			"max_retries":   clientInfo.MaxRetries,
+			"weight":        clientInfo.weight,
+			"height":        clientInfo.status.LatestHeight,
+			"catching_up":   clientInfo.status.CatchingUp,
+			"breaker_state": clientInfo.breaker.state,
 		}
 	}
 	status["active"] = activeSummary
@@ -596,6 +816,9 @@ func (cpm *ClientPoolManager[T]) GetHealthStatus() map[string]any {
 			"latency_ms":    clientInfo.latEWMA,
 			"health_streak": clientInfo.healthStreak,
 			"max_retries":   clientInfo.MaxRetries,
+			"height":        clientInfo.status.LatestHeight,
+			"catching_up":   clientInfo.status.CatchingUp,
+			"breaker_state": clientInfo.breaker.state,
 		}
 	}
 	status["cooling"] = coolingSummary
@@ -605,6 +828,10 @@ func (cpm *ClientPoolManager[T]) GetHealthStatus() map[string]any {
 
 // ExecuteWithRetry executes a function with automatic retry and load balancing
 // This generic function provides type-safe retry logic with full pool management integration.
+// When hedging is enabled, by the pool config or WithHedging, an attempt still
+// running after the hedge delay is raced against the same operation on a second
+// client; the first success is returned and the other attempt's context canceled.
+// A NotFound answer (see IsNotFound) is returned right away, like a success.
 //
 // Type parameters:
 //   - Result: The expected return type (e.g., *authtypes.QueryAccountResponse)
@@ -613,17 +840,20 @@ func (cpm *ClientPoolManager[T]) GetHealthStatus() map[string]any {
 //   - ctx: Context for the operation
 //   - poolManager: The client pool manager for load balancing and health tracking
 //   - logger: Logger for debugging and monitoring
-//   - operation: Function that receives an Client and returns the result
+//   - operation: Function that receives the attempt's context and an Client and returns the result
 //
 // Returns the result with full type safety, or an error if all clients fail.
 func ExecuteWithRetry[T PoolParticipant, Result any](
 	ctx context.Context,
 	poolManager *ClientPoolManager[T],
 	logger *zerolog.Logger,
-	operation func(client T) (Result, error),
+	operation func(ctx context.Context, client T) (Result, error),
 ) (_ Result, err error) {
 	overallStart := time.Now()
 	service, method := deriveRPCOperation()
+	eligible := clientFilter(ctx)
+	height := queryHeight(ctx)
+	hedgeDelay, hedge := poolManager.hedgeDelay(ctx)
 	maxAttempts := len(poolManager.active) + len(poolManager.cooling)
 	if maxAttempts == 0 {
 		var zero Result
@@ -632,15 +862,32 @@ func ExecuteWithRetry[T PoolParticipant, Result any](
 		return zero, fmt.Errorf("no clients available")
 	}
 
+	// Canceling on return stops the attempt that lost a hedged race
+	attemptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var (
 		attempts     int
 		lastErr      error
 		lastOutcome  = "no_client_available"
 		lastProtocol string
+		hedged       bool
+		results      = make(chan attemptResult[T, Result], maxAttempts+1)
 	)
 
+	launch := func(client T, isHedge bool) {
+		attempts++
+		attempt := attempts
+		lastProtocol = string(client.GetProtocol())
+		go func() {
+			start := time.Now()
+			result, err := operation(attemptCtx, client)
+			results <- attemptResult[T, Result]{client, result, err, attempt, time.Since(start), isHedge}
+		}()
+	}
+
 	for attempt := 0; attempt < maxAttempts; attempt++ {
-		aggregatedClient, available := poolManager.GetClientWithBackoff()
+		aggregatedClient, available := poolManager.getClientWithBackoff(ctx, eligible, height)
 		if !available {
 			if backoffDuration := poolManager.GetShortestBackoff(); backoffDuration > 0 {
 				logger.Debug().Dur("backoff", backoffDuration).Msg("waiting for client backoff")
@@ -669,46 +916,91 @@ func ExecuteWithRetry[T PoolParticipant, Result any](
 			break
 		}
 
-		attempts++
-		lastProtocol = string(aggregatedClient.GetProtocol())
-		protocol := lastProtocol
-		endpoint := aggregatedClient.GetEndpointURL()
-		attemptStart := time.Now()
-
-		result, operationErr := operation(aggregatedClient)
-		attemptDuration := time.Since(attemptStart)
-
-		attemptCount := attempts
-		if operationErr != nil {
-			outcome := classifyAttemptError(operationErr)
-			lastOutcome = outcome
-			metrics.ObserveRPCRequest(protocol, endpoint, service, method, outcome, attemptCount, attemptDuration)
-			logger.Debug().
-				Err(operationErr).
-				Str("endpoint", endpoint).
-				Int("attempt", attemptCount).
-				Dur("duration", attemptDuration).
-				Msg("operation failed, trying next client")
+		launch(aggregatedClient, false)
+		inFlight := 1
 
-			poolManager.ReportHealth(aggregatedClient, attemptCount, float64(attemptDuration.Milliseconds()), false)
-			poolManager.ApplyBackoffPenalty(aggregatedClient, poolManager.ExpDelay(attempt))
-			lastErr = operationErr
-			continue
+		// A call is hedged at most once
+		var hedgeTimer <-chan time.Time
+		if hedge && !hedged {
+			timer := time.NewTimer(hedgeDelay)
+			defer timer.Stop()
+			hedgeTimer = timer.C
 		}
 
-		lastOutcome = "success"
-		metrics.ObserveRPCRequest(protocol, endpoint, service, method, lastOutcome, attemptCount, attemptDuration)
-		metrics.ObserveRPCAttempts(protocol, service, method, lastOutcome, attemptCount)
-		poolManager.ReportHealth(aggregatedClient, attemptCount, float64(attemptDuration.Milliseconds()), true)
-		poolManager.ClearBackoff(aggregatedClient)
+		for inFlight > 0 {
+			var r attemptResult[T, Result]
+			select {
+			case <-hedgeTimer:
+				hedgeTimer = nil
+				busyURL := aggregatedClient.GetEndpointURL()
+				hedgeClient, ok := poolManager.getClientWithBackoff(ctx, func(client PoolParticipant) bool {
+					return client.GetEndpointURL() != busyURL && (eligible == nil || eligible(client))
+				}, height)
+				if !ok {
+					continue
+				}
+				hedged = true
+				metrics.ObserveHedgeIssued(service, method)
+				logger.Debug().
+					Str("endpoint", busyURL).
+					Str("hedge_endpoint", hedgeClient.GetEndpointURL()).
+					Dur("delay", hedgeDelay).
+					Msg("operation is slow, hedging on another client")
+				launch(hedgeClient, true)
+				inFlight++
+				continue
+			case r = <-results:
+				inFlight--
+			}
+
+			protocol := string(r.client.GetProtocol())
+			endpoint := r.client.GetEndpointURL()
+			if IsNotFound(r.err) {
+				// The node answered that the item does not exist: that is no node
+				// failure, and another node would not know better
+				metrics.ObserveRPCRequest(protocol, endpoint, service, method, "not_found", r.attempt, r.duration)
+				metrics.ObserveRPCAttempts(protocol, service, method, "not_found", attempts)
+				poolManager.releaseTrial(r.client)
+				releaseLosers(poolManager, results, inFlight)
+				var zero Result
+				return zero, r.err
+			}
+			if r.err != nil {
+				outcome := classifyAttemptError(r.err)
+				lastOutcome = outcome
+				metrics.ObserveRPCRequest(protocol, endpoint, service, method, outcome, r.attempt, r.duration)
+				logger.Debug().
+					Err(r.err).
+					Str("endpoint", endpoint).
+					Int("attempt", r.attempt).
+					Dur("duration", r.duration).
+					Msg("operation failed, trying next client")
+
+				poolManager.ReportHealth(r.client, r.attempt, float64(r.duration.Milliseconds()), false)
+				poolManager.ApplyBackoffPenalty(r.client, poolManager.ExpDelay(attempt))
+				lastErr = r.err
+				continue
+			}
+
+			lastOutcome = "success"
+			metrics.ObserveRPCRequest(protocol, endpoint, service, method, lastOutcome, r.attempt, r.duration)
+			metrics.ObserveRPCAttempts(protocol, service, method, lastOutcome, attempts)
+			if r.hedge {
+				metrics.ObserveHedgeWon(service, method)
+			}
+			poolManager.ReportHealth(r.client, r.attempt, float64(r.duration.Milliseconds()), true)
+			poolManager.ClearBackoff(r.client)
 
-		logger.Debug().
-			Str("endpoint", endpoint).
-			Int("attempt", attemptCount).
-			Dur("duration", attemptDuration).
-			Msg("operation succeeded")
+			logger.Debug().
+				Str("endpoint", endpoint).
+				Int("attempt", r.attempt).
+				Dur("duration", r.duration).
+				Bool("hedge", r.hedge).
+				Msg("operation succeeded")
 
-		return result, nil
+			releaseLosers(poolManager, results, inFlight)
+			return r.result, nil
+		}
 	}
 
 	var zero Result
@@ -728,6 +1020,29 @@ func ExecuteWithRetry[T PoolParticipant, Result any](
 	return zero, fmt.Errorf("no clients available")
 }
 
+// releaseLosers frees the trial slots held by the attempts still running once a
+// call has its answer, as their outcomes are not reported
+func releaseLosers[T PoolParticipant, Result any](pm *ClientPoolManager[T], results <-chan attemptResult[T, Result], inFlight int) {
+	if inFlight == 0 {
+		return
+	}
+	go func() {
+		for ; inFlight > 0; inFlight-- {
+			pm.releaseTrial((<-results).client)
+		}
+	}()
+}
+
+// attemptResult is the outcome of one attempt of ExecuteWithRetry
+type attemptResult[T PoolParticipant, Result any] struct {
+	client   T
+	result   Result
+	err      error
+	attempt  int
+	duration time.Duration
+	hedge    bool
+}
+
 func deriveRPCOperation() (service, method string) {
 	pcs := make([]uintptr, 3)
 	n := runtime.Callers(3, pcs)
@@ -740,6 +1055,11 @@ func deriveRPCOperation() (service, method string) {
 	}
 	name := frame.Name()
 	parts := strings.Split(name, ".")
+	// Operations run inside closures (e.g. behind the response cache) are named
+	// after the method that created them: drop the "func1", "1", ... suffixes
+	for len(parts) > 1 && isClosureSuffix(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
 	if len(parts) == 0 {
 		return "unknown", "unknown"
 	}
@@ -767,6 +1087,19 @@ func deriveRPCOperation() (service, method string) {
 	return service, method
 }
 
+func isClosureSuffix(part string) bool {
+	part = strings.TrimPrefix(part, "func")
+	if part == "" {
+		return false
+	}
+	for _, r := range part {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func classifyAttemptError(err error) string {
 	switch {
 	case errors.Is(err, context.Canceled):
@@ -797,6 +1130,21 @@ func classifyAttemptError(err error) string {
 	return "error"
 }
 
+// IsNotFound reports whether a query failed because the node does not have the
+// requested item, e.g. a transaction that is not yet included in a block or an
+// account that was never funded. ExecuteWithRetry returns such errors as they are,
+// without retrying or counting them against the node.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	if status.Code(err) == codes.NotFound {
+		return true
+	}
+	// REST clients surface the gateway's 404 as a plain HTTP error
+	return strings.Contains(err.Error(), fmt.Sprintf("HTTP error %d", http.StatusNotFound))
+}
+
 func classifyContextError(err error) string {
 	switch {
 	case errors.Is(err, context.Canceled):
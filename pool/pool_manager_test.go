@@ -0,0 +1,226 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// operation mimics ExecuteWithRetry: it labels the call by its caller
+//
+//go:noinline
+func operation() (service, method string) {
+	return deriveRPCOperation()
+}
+
+type bankClientWrapper struct{}
+
+func (*bankClientWrapper) direct() (string, string) {
+	return operation()
+}
+
+func (*bankClientWrapper) closure() (string, string) {
+	run := func() (string, string) {
+		return operation()
+	}
+	return run()
+}
+
+func (*bankClientWrapper) nestedClosure() (string, string) {
+	outer := func() (string, string) {
+		inner := func() (string, string) {
+			return operation()
+		}
+		return inner()
+	}
+	return outer()
+}
+
+func TestDeriveRPCOperation(t *testing.T) {
+	w := &bankClientWrapper{}
+	for _, tc := range []struct {
+		name                    string
+		call                    func() (string, string)
+		wantService, wantMethod string
+	}{
+		{"direct", w.direct, "bank", "direct"},
+		{"closure", w.closure, "bank", "closure"},
+		{"nested closure", w.nestedClosure, "bank", "nestedClosure"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			service, method := tc.call()
+			if service != tc.wantService || method != tc.wantMethod {
+				t.Fatalf("got %s/%s, want %s/%s", service, method, tc.wantService, tc.wantMethod)
+			}
+		})
+	}
+}
+
+func TestIsClosureSuffix(t *testing.T) {
+	for part, want := range map[string]bool{
+		"func1":    true,
+		"func12":   true,
+		"2":        true,
+		"func":     false,
+		"":         false,
+		"Balance":  false,
+		"funcName": false,
+	} {
+		if got := isClosureSuffix(part); got != want {
+			t.Errorf("isClosureSuffix(%q) = %v, want %v", part, got, want)
+		}
+	}
+}
+
+func newTestManager(t *testing.T, cfg config.PoolConfig, clients ...*testParticipant) *ClientPoolManager[*testParticipant] {
+	t.Helper()
+	cpm := NewClientPoolManager(clients, cfg, zerolog.Nop())
+	t.Cleanup(cpm.Close)
+	return cpm
+}
+
+func activeURLs[T PoolParticipant](cpm *ClientPoolManager[T]) map[string]bool {
+	cpm.mu.RLock()
+	defer cpm.mu.RUnlock()
+	urls := make(map[string]bool, len(cpm.active))
+	for i := range cpm.active {
+		urls[cpm.active[i].Client.GetEndpointURL()] = true
+	}
+	return urls
+}
+
+func TestProbeCoolsLaggingNodes(t *testing.T) {
+	a := &testParticipant{url: "a", status: NodeStatus{LatestHeight: 1000}}
+	b := &testParticipant{url: "b", status: NodeStatus{LatestHeight: 990}}
+	stuck := &testParticipant{url: "stuck", status: NodeStatus{LatestHeight: 500}}
+	cpm := newTestManager(t, config.PoolConfig{MaxHeightLag: 50}, a, b, stuck)
+
+	cpm.probe()
+	if active := activeURLs(cpm); !active["a"] || !active["b"] || active["stuck"] {
+		t.Fatalf("expected only the lagging node to be cooled, active: %v", active)
+	}
+
+	// Still lagging: it stays cooled however many checks it passes
+	for i := 0; i < cpm.cfg.HealthyStreak; i++ {
+		cpm.probe()
+	}
+	if activeURLs(cpm)["stuck"] {
+		t.Fatal("expected the lagging node to stay cooled")
+	}
+
+	// Caught up: it is reactivated after HealthyStreak checks
+	stuck.set(NodeStatus{LatestHeight: 1000}, nil)
+	for i := 0; i < cpm.cfg.HealthyStreak-1; i++ {
+		cpm.probe()
+	}
+	if activeURLs(cpm)["stuck"] {
+		t.Fatal("expected the node to need a streak of passed checks")
+	}
+	cpm.probe()
+	if !activeURLs(cpm)["stuck"] {
+		t.Fatal("expected the node to be reactivated once caught up")
+	}
+}
+
+func TestProbeCoolsCatchingUpNodes(t *testing.T) {
+	a := &testParticipant{url: "a", status: NodeStatus{LatestHeight: 1000}}
+	syncing := &testParticipant{url: "syncing", status: NodeStatus{LatestHeight: 1000, CatchingUp: true}}
+	cpm := newTestManager(t, config.PoolConfig{}, a, syncing)
+
+	cpm.probe()
+	if active := activeURLs(cpm); !active["a"] || active["syncing"] {
+		t.Fatalf("expected the catching-up node to be cooled, active: %v", active)
+	}
+}
+
+func TestProbeKeepsNodesOfUnknownHeight(t *testing.T) {
+	a := &testParticipant{url: "a", status: NodeStatus{LatestHeight: 1000}}
+	unknown := &testParticipant{url: "unknown"}
+	failing := &testParticipant{url: "failing", err: errors.New("unreachable")}
+	cpm := newTestManager(t, config.PoolConfig{MaxHeightLag: 50}, a, unknown, failing)
+
+	cpm.probe()
+	if active := activeURLs(cpm); len(active) != 3 {
+		t.Fatalf("expected nodes of unknown height to stay active, active: %v", active)
+	}
+}
+
+func TestProbeWithLagEvictionDisabled(t *testing.T) {
+	a := &testParticipant{url: "a", status: NodeStatus{LatestHeight: 1000}}
+	stuck := &testParticipant{url: "stuck", status: NodeStatus{LatestHeight: 10}}
+	cpm := newTestManager(t, config.PoolConfig{MaxHeightLag: -1}, a, stuck)
+
+	cpm.probe()
+	if active := activeURLs(cpm); !active["stuck"] {
+		t.Fatalf("expected no lag eviction, active: %v", active)
+	}
+}
+
+func TestExecuteWithRetrySkipsNodesThatPrunedTheHeight(t *testing.T) {
+	archive := &testParticipant{url: "archive", status: NodeStatus{LatestHeight: 1000, EarliestHeight: 1}}
+	pruned := &testParticipant{url: "pruned", status: NodeStatus{LatestHeight: 1000, EarliestHeight: 500}}
+	cpm := newTestManager(t, config.PoolConfig{}, archive, pruned)
+	cpm.probe()
+
+	served := func(ctx context.Context) map[string]int {
+		t.Helper()
+		logger := zerolog.Nop()
+		counts := make(map[string]int)
+		for i := 0; i < 6; i++ {
+			url, err := ExecuteWithRetry(ctx, cpm, &logger, func(ctx context.Context, client *testParticipant) (string, error) {
+				return client.url, nil
+			})
+			if err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			counts[url]++
+		}
+		return counts
+	}
+
+	if counts := served(withQueryHeight(t.Context(), 100)); counts["pruned"] != 0 || counts["archive"] != 6 {
+		t.Errorf("expected height 100 to be served by the archive node only, got %v", counts)
+	}
+	if counts := served(withQueryHeight(t.Context(), 600)); counts["pruned"] == 0 || counts["archive"] == 0 {
+		t.Errorf("expected height 600 to be served by both nodes, got %v", counts)
+	}
+	if counts := served(t.Context()); counts["pruned"] == 0 || counts["archive"] == 0 {
+		t.Errorf("expected latest-height queries to be served by both nodes, got %v", counts)
+	}
+}
+
+func TestExecuteWithRetryReturnsNotFoundWithoutPenalty(t *testing.T) {
+	a := &testParticipant{url: "a"}
+	b := &testParticipant{url: "b"}
+	cpm := newTestManager(t, config.PoolConfig{Breaker: config.BreakerConfig{MinRequests: 1}}, a, b)
+	logger := zerolog.Nop()
+
+	for _, notFound := range []error{
+		status.Error(codes.NotFound, "tx not found"),
+		errors.New("HTTP error 404: 404 Not Found"),
+	} {
+		calls := 0
+		_, err := ExecuteWithRetry(t.Context(), cpm, &logger, func(ctx context.Context, client *testParticipant) (string, error) {
+			calls++
+			return "", notFound
+		})
+		if !IsNotFound(err) {
+			t.Fatalf("expected the NotFound answer, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected NotFound not to be retried, got %d calls", calls)
+		}
+	}
+	if active := activeURLs(cpm); !active["a"] || !active["b"] {
+		t.Errorf("expected NotFound not to cool any node, active: %v", active)
+	}
+	if cpm.GetShortestBackoff() != 0 {
+		t.Error("expected NotFound not to back off any node")
+	}
+}
@@ -0,0 +1,158 @@
+package pool
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// Candidate is a client a Strategy may pick, with its health tracking
+type Candidate struct {
+	URL         string
+	SuccessRate float64
+	LatencyMS   float64 // exponential-weighted moving average; zero is unknown
+	Weight      int     // static weight of the endpoint, at least 1
+}
+
+// Strategy picks the client a query runs on among the active clients that are not
+// in backoff and can serve it
+type Strategy interface {
+	// Pick returns the index of the chosen candidate. candidates is never empty and
+	// is ordered by success rate, then latency. Pick is called with the pool locked
+	// and must not call back into it.
+	Pick(ctx context.Context, candidates []Candidate) int
+}
+
+// NewStrategy returns the built-in strategy of the given kind, round-robin if the
+// kind is empty or unknown
+func NewStrategy(kind config.PoolStrategy) Strategy {
+	switch kind {
+	case config.PoolStrategyLeastLatency:
+		return LeastLatency{}
+	case config.PoolStrategyPowerOfTwo:
+		return PowerOfTwo{}
+	case config.PoolStrategyWeighted:
+		return Weighted{}
+	case config.PoolStrategySticky:
+		return &Sticky{Fallback: NewRoundRobin()}
+	default:
+		return NewRoundRobin()
+	}
+}
+
+// RoundRobin cycles through the candidates
+type RoundRobin struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobin returns a RoundRobin starting at a random offset, to spread the
+// initial load of several pools
+func NewRoundRobin() *RoundRobin {
+	rr := &RoundRobin{}
+	rr.next.Store(rand.Uint64())
+	return rr
+}
+
+func (rr *RoundRobin) Pick(_ context.Context, candidates []Candidate) int {
+	return int((rr.next.Add(1) - 1) % uint64(len(candidates)))
+}
+
+// LeastLatency picks the candidate with the lowest latency EWMA. Candidates of
+// unknown latency are picked first, so that they get measured.
+type LeastLatency struct{}
+
+func (LeastLatency) Pick(_ context.Context, candidates []Candidate) int {
+	best := 0
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].LatencyMS < candidates[best].LatencyMS {
+			best = i
+		}
+	}
+	return best
+}
+
+// PowerOfTwo picks two candidates at random and keeps the one with the lower
+// latency EWMA, which avoids herding every query onto the fastest node
+type PowerOfTwo struct{}
+
+func (PowerOfTwo) Pick(_ context.Context, candidates []Candidate) int {
+	if len(candidates) == 1 {
+		return 0
+	}
+	a := rand.Intn(len(candidates))
+	b := rand.Intn(len(candidates) - 1)
+	if b >= a {
+		b++
+	}
+	if candidates[b].LatencyMS < candidates[a].LatencyMS {
+		return b
+	}
+	return a
+}
+
+// Weighted picks candidates at random, in proportion to their endpoint weights
+type Weighted struct{}
+
+func (Weighted) Pick(_ context.Context, candidates []Candidate) int {
+	total := 0
+	for _, c := range candidates {
+		total += c.Weight
+	}
+	n := rand.Intn(total)
+	for i, c := range candidates {
+		if n < c.Weight {
+			return i
+		}
+		n -= c.Weight
+	}
+	return len(candidates) - 1
+}
+
+type stickyKeyKey struct{}
+
+// WithStickyKey returns a context under which the sticky strategy sends every query
+// with the same key, e.g. an address, to the same node
+func WithStickyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, stickyKeyKey{}, key)
+}
+
+// StickyKey returns the key set by WithStickyKey, or ""
+func StickyKey(ctx context.Context) string {
+	key, _ := ctx.Value(stickyKeyKey{}).(string)
+	return key
+}
+
+// Sticky sends the queries of a key to the same candidate, chosen by rendezvous
+// hashing of the key and the candidates' URLs: a key only moves when its node
+// leaves the candidates. Queries without a key go to Fallback, or to the first
+// candidate if Fallback is nil.
+type Sticky struct {
+	Fallback Strategy
+}
+
+func (s *Sticky) Pick(ctx context.Context, candidates []Candidate) int {
+	key := StickyKey(ctx)
+	if key == "" {
+		if s.Fallback == nil {
+			return 0
+		}
+		return s.Fallback.Pick(ctx, candidates)
+	}
+	var (
+		best      int
+		bestScore uint64
+	)
+	for i, c := range candidates {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(c.URL))
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
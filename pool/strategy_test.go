@@ -0,0 +1,157 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+func candidates(latencies ...float64) []Candidate {
+	cs := make([]Candidate, len(latencies))
+	for i, latency := range latencies {
+		cs[i] = Candidate{URL: fmt.Sprintf("node%d", i), SuccessRate: 1, LatencyMS: latency, Weight: 1}
+	}
+	return cs
+}
+
+func pickCounts(strategy Strategy, ctx context.Context, cs []Candidate, n int) []int {
+	counts := make([]int, len(cs))
+	for i := 0; i < n; i++ {
+		counts[strategy.Pick(ctx, cs)]++
+	}
+	return counts
+}
+
+func TestRoundRobinCyclesThroughCandidates(t *testing.T) {
+	counts := pickCounts(NewRoundRobin(), t.Context(), candidates(10, 20, 30), 30)
+	for i, n := range counts {
+		if n != 10 {
+			t.Errorf("candidate %d picked %d times, want 10", i, n)
+		}
+	}
+}
+
+func TestLeastLatencyPicksFastestCandidate(t *testing.T) {
+	if got := (LeastLatency{}).Pick(t.Context(), candidates(30, 10, 20)); got != 1 {
+		t.Errorf("picked candidate %d, want the fastest one", got)
+	}
+	if got := (LeastLatency{}).Pick(t.Context(), candidates(30, 10, 0)); got != 2 {
+		t.Errorf("picked candidate %d, want the one of unknown latency", got)
+	}
+}
+
+func TestPowerOfTwoAvoidsSlowestCandidate(t *testing.T) {
+	counts := pickCounts(PowerOfTwo{}, t.Context(), candidates(10, 20, 500), 300)
+	if counts[2] != 0 {
+		t.Errorf("expected the slowest candidate never to win a pair, got %v", counts)
+	}
+	if counts[0] == 0 || counts[1] == 0 {
+		t.Errorf("expected both faster candidates to be picked, got %v", counts)
+	}
+	if got := (PowerOfTwo{}).Pick(t.Context(), candidates(10)); got != 0 {
+		t.Errorf("picked candidate %d of a single one", got)
+	}
+}
+
+func TestWeightedFollowsEndpointWeights(t *testing.T) {
+	cs := candidates(10, 10)
+	cs[1].Weight = 3
+	counts := pickCounts(Weighted{}, t.Context(), cs, 4000)
+	if ratio := float64(counts[1]) / float64(counts[0]); ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("expected a 3:1 split, got %v", counts)
+	}
+}
+
+func TestStickyKeepsKeysOnTheSameCandidate(t *testing.T) {
+	sticky := &Sticky{Fallback: NewRoundRobin()}
+	cs := candidates(10, 20, 30, 40)
+
+	picked := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("allo1addr%d", i)
+		ctx := WithStickyKey(t.Context(), key)
+		picked[key] = cs[sticky.Pick(ctx, cs)].URL
+		for j := 0; j < 5; j++ {
+			if url := cs[sticky.Pick(ctx, cs)].URL; url != picked[key] {
+				t.Fatalf("key %s moved from %s to %s", key, picked[key], url)
+			}
+		}
+	}
+
+	// Removing a node only moves the keys it served
+	removed := picked["allo1addr0"]
+	var rest []Candidate
+	for _, c := range cs {
+		if c.URL != removed {
+			rest = append(rest, c)
+		}
+	}
+	for key, url := range picked {
+		got := rest[sticky.Pick(WithStickyKey(t.Context(), key), rest)].URL
+		if url != removed && got != url {
+			t.Errorf("key %s moved from %s to %s when %s left", key, url, got, removed)
+		}
+	}
+
+	// Queries without a key use the fallback
+	counts := pickCounts(sticky, t.Context(), cs, 40)
+	for i, n := range counts {
+		if n != 10 {
+			t.Errorf("candidate %d picked %d times without a key, want 10", i, n)
+		}
+	}
+}
+
+func TestManagerPicksWithConfiguredStrategy(t *testing.T) {
+	a := &testParticipant{url: "a"}
+	b := &testParticipant{url: "b"}
+	c := &testParticipant{url: "c"}
+	cpm := newTestManager(t, config.PoolConfig{Strategy: config.PoolStrategySticky}, a, b, c)
+	logger := zerolog.Nop()
+
+	ctx := WithStickyKey(t.Context(), "allo1sticky")
+	served := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		url, err := ExecuteWithRetry(ctx, cpm, &logger, func(ctx context.Context, client *testParticipant) (string, error) {
+			return client.url, nil
+		})
+		if err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		served[url]++
+	}
+	if len(served) != 1 {
+		t.Errorf("expected every query of the key to hit one node, got %v", served)
+	}
+}
+
+func TestManagerPassesEndpointWeightsToStrategy(t *testing.T) {
+	var seen []Candidate
+	strategy := strategyFunc(func(_ context.Context, cs []Candidate) int {
+		seen = append([]Candidate(nil), cs...)
+		return 0
+	})
+	weights := EndpointWeights([]config.EndpointConfig{{URL: "a", Weight: 5}, {URL: "b"}})
+	cpm := NewClientPoolManager([]*testParticipant{{url: "a"}, {url: "b"}}, config.PoolConfig{}, zerolog.Nop(),
+		WithStrategy(strategy), WithEndpointWeights(weights))
+	t.Cleanup(cpm.Close)
+
+	if _, ok := cpm.GetClient(nil); !ok {
+		t.Fatal("expected a client")
+	}
+	got := make(map[string]int)
+	for _, c := range seen {
+		got[c.URL] = c.Weight
+	}
+	if got["a"] != 5 || got["b"] != 1 {
+		t.Errorf("expected weights a=5 b=1, got %v", got)
+	}
+}
+
+type strategyFunc func(context.Context, []Candidate) int
+
+func (f strategyFunc) Pick(ctx context.Context, cs []Candidate) int { return f(ctx, cs) }
@@ -0,0 +1,186 @@
+// Package remotesigner is a reference implementation of allora.Signer that keeps
+// private keys out of the SDK process.
+//
+// Signing requests are forwarded over gRPC to a signing service, typically a thin
+// front-end to a KMS or HSM. The service exposes two unary methods using the
+// well-known protobuf wrapper types, so it can be implemented in any language
+// without shared .proto files:
+//
+//	service allora.signer.v1.RemoteSigner {
+//	  rpc GetPubKey(google.protobuf.Empty) returns (google.protobuf.BytesValue); // compressed secp256k1 key
+//	  rpc Sign(google.protobuf.BytesValue) returns (google.protobuf.BytesValue); // 64-byte r||s over sha256(msg)
+//	}
+//
+// KeyServer implements the service with an in-process key and serves as a
+// stand-in for a real KMS/HSM in development and tests.
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ServiceName is the fully-qualified gRPC service name of the signing service
+const ServiceName = "allora.signer.v1.RemoteSigner"
+
+const (
+	getPubKeyMethod = "/" + ServiceName + "/GetPubKey"
+	signMethod      = "/" + ServiceName + "/Sign"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Server is the server side of the signing service
+type Server interface {
+	// GetPubKey returns the compressed secp256k1 public key of the signing key
+	GetPubKey(ctx context.Context, req *emptypb.Empty) (*wrapperspb.BytesValue, error)
+
+	// Sign signs the message and returns a 64-byte r||s signature over sha256(msg)
+	Sign(ctx context.Context, req *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error)
+}
+
+// RegisterServer registers a signing service implementation with a gRPC server
+func RegisterServer(s grpc.ServiceRegistrar, srv Server) {
+	s.RegisterService(&ServiceDesc, srv)
+}
+
+// ServiceDesc is the gRPC service descriptor of the signing service
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetPubKey", Handler: getPubKeyHandler},
+		{MethodName: "Sign", Handler: signHandler},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func getPubKeyHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: getPubKeyMethod}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(Server).GetPubKey(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func signHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(wrapperspb.BytesValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: signMethod}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(Server).Sign(ctx, req.(*wrapperspb.BytesValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeyServer is a signing service backed by an in-process private key
+type KeyServer struct {
+	privKey cryptotypes.PrivKey
+}
+
+var _ Server = (*KeyServer)(nil)
+
+// NewKeyServer creates a signing service for the given private key
+func NewKeyServer(privKey cryptotypes.PrivKey) *KeyServer {
+	return &KeyServer{privKey: privKey}
+}
+
+// GetPubKey implements Server
+func (s *KeyServer) GetPubKey(context.Context, *emptypb.Empty) (*wrapperspb.BytesValue, error) {
+	return wrapperspb.Bytes(s.privKey.PubKey().Bytes()), nil
+}
+
+// Sign implements Server
+func (s *KeyServer) Sign(_ context.Context, req *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	sig, err := s.privKey.Sign(req.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.Bytes(sig), nil
+}
+
+// Signer is an allora.Signer that forwards signing requests to a remote signing service
+type Signer struct {
+	conn    grpc.ClientConnInterface
+	pubKey  cryptotypes.PubKey
+	timeout time.Duration
+}
+
+// Option configures a Signer
+type Option func(*Signer)
+
+// WithTimeout sets the timeout of each Sign call (10s by default)
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Signer) {
+		s.timeout = timeout
+	}
+}
+
+// New creates a Signer using the given connection to the signing service. The
+// public key is fetched once and cached.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient("signer.internal:9000", grpc.WithTransportCredentials(creds))
+//	signer, err := remotesigner.New(ctx, conn)
+//
+//	signedTx, err := allora.CreateSignedTx(msgs, signer, params)
+func New(ctx context.Context, conn grpc.ClientConnInterface, opts ...Option) (*Signer, error) {
+	s := &Signer{
+		conn:    conn,
+		timeout: defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	resp := new(wrapperspb.BytesValue)
+	if err := conn.Invoke(ctx, getPubKeyMethod, &emptypb.Empty{}, resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch public key: %w", err)
+	}
+	if len(resp.GetValue()) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("invalid public key length %d, expected %d", len(resp.GetValue()), secp256k1.PubKeySize)
+	}
+	s.pubKey = &secp256k1.PubKey{Key: resp.GetValue()}
+	return s, nil
+}
+
+// GetPubKey implements allora.Signer
+func (s *Signer) GetPubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+// Sign implements allora.Signer. The returned signature is verified against the
+// cached public key, so a misbehaving service cannot produce an invalid transaction.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	resp := new(wrapperspb.BytesValue)
+	if err := s.conn.Invoke(ctx, signMethod, wrapperspb.Bytes(msg), resp); err != nil {
+		return nil, fmt.Errorf("remote signing failed: %w", err)
+	}
+	if !s.pubKey.VerifySignature(msg, resp.GetValue()) {
+		return nil, fmt.Errorf("remote signer returned an invalid signature")
+	}
+	return resp.GetValue(), nil
+}
@@ -0,0 +1,83 @@
+package remotesigner_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	allora "github.com/allora-network/allora-sdk-go"
+	"github.com/allora-network/allora-sdk-go/remotesigner"
+)
+
+func startServer(t *testing.T, srv remotesigner.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	remotesigner.RegisterServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestRemoteSignerSignsTransaction(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	conn := startServer(t, remotesigner.NewKeyServer(privKey))
+
+	signer, err := remotesigner.New(t.Context(), conn)
+	require.NoError(t, err)
+	require.True(t, signer.GetPubKey().Equals(privKey.PubKey()))
+
+	from := allora.SignerAddress(signer)
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	params := &allora.TxParams{
+		ChainID:       "allora-testnet-1",
+		AccountNumber: 1,
+		Sequence:      0,
+		GasLimit:      200000,
+		FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+	}
+
+	remoteTx, err := allora.CreateSignedSendTx(from, to, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1)), signer, params)
+	require.NoError(t, err)
+
+	// Signing with the key in process produces the identical transaction
+	wallet, err := allora.NewWalletFromPrivateKey(privKey.Bytes())
+	require.NoError(t, err)
+	localTx, err := allora.CreateSignedSendTx(from, to, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1)), wallet, params)
+	require.NoError(t, err)
+	require.Equal(t, localTx, remoteTx)
+}
+
+type badSigServer struct {
+	*remotesigner.KeyServer
+}
+
+func (badSigServer) Sign(context.Context, *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	return wrapperspb.Bytes(make([]byte, 64)), nil
+}
+
+func TestRemoteSignerRejectsInvalidSignature(t *testing.T) {
+	conn := startServer(t, badSigServer{remotesigner.NewKeyServer(secp256k1.GenPrivKey())})
+
+	signer, err := remotesigner.New(t.Context(), conn)
+	require.NoError(t, err)
+
+	_, err = signer.Sign([]byte("payload"))
+	require.ErrorContains(t, err, "invalid signature")
+}
@@ -0,0 +1,293 @@
+package allora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// defaultSequenceMismatchRetries is the number of times SignAndBroadcast resyncs
+// and retries after an account sequence mismatch
+const defaultSequenceMismatchRetries = 3
+
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+// IsSequenceMismatch reports whether an error indicates that a transaction was
+// signed with the wrong account sequence
+func IsSequenceMismatch(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, sdkerrors.ErrWrongSequence) {
+		return true
+	}
+	return strings.Contains(err.Error(), "account sequence mismatch")
+}
+
+// expectedSequenceFromError extracts the sequence the chain expected from an
+// "account sequence mismatch, expected X, got Y" error, if present
+func expectedSequenceFromError(err error) (uint64, bool) {
+	if err == nil {
+		return 0, false
+	}
+	m := sequenceMismatchRegexp.FindStringSubmatch(err.Error())
+	if len(m) != 3 {
+		return 0, false
+	}
+	seq, perr := strconv.ParseUint(m[1], 10, 64)
+	if perr != nil {
+		return 0, false
+	}
+	return seq, true
+}
+
+// SequenceManager hands out account numbers and sequences for signers that send
+// several transactions per block from the same key
+//
+// The first request for an address loads its AccountInfo from the chain; later
+// requests are served from the cache with a monotonically increasing sequence,
+// so concurrent senders never reuse a sequence. When the chain reports an
+// account sequence mismatch, the manager resyncs from QueryAccountInfo (or from
+// the expected sequence in the error message).
+//
+// Example:
+//
+//	seqMgr := allora.NewSequenceManager(client)
+//
+//	params, err := allora.NewTxParamsBuilder(ctx, client).
+//	    WithAddress(wallet.Address).
+//	    WithSequenceManager(seqMgr).
+//	    QueryAndBuild()
+//
+//	// or let the manager drive the whole sign/broadcast/resync loop
+//	res, err := seqMgr.SignAndBroadcast(ctx, wallet, msgs, allora.DefaultTxParams())
+type SequenceManager struct {
+	client Client
+
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+type accountSequence struct {
+	mu            sync.Mutex
+	loaded        bool
+	accountNumber uint64
+	next          uint64
+}
+
+// NewSequenceManager creates a new SequenceManager backed by the given client
+func NewSequenceManager(client Client) *SequenceManager {
+	return &SequenceManager{
+		client:   client,
+		accounts: make(map[string]*accountSequence),
+	}
+}
+
+func (m *SequenceManager) account(address sdk.AccAddress) *accountSequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := string(address)
+	acc, ok := m.accounts[key]
+	if !ok {
+		acc = &accountSequence{}
+		m.accounts[key] = acc
+	}
+	return acc
+}
+
+// Next reserves the next sequence for the address and returns it together with
+// the account number. The account is loaded from the chain on first use or
+// after Invalidate.
+func (m *SequenceManager) Next(ctx context.Context, address sdk.AccAddress) (*AccountInfo, error) {
+	if address.Empty() {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if !acc.loaded {
+		if err := m.loadLocked(ctx, address, acc); err != nil {
+			return nil, err
+		}
+	}
+
+	info := &AccountInfo{
+		AccountNumber: acc.accountNumber,
+		Sequence:      acc.next,
+	}
+	acc.next++
+	return info, nil
+}
+
+// Peek returns the cached account number and the sequence the next call to Next
+// would hand out, without reserving it
+func (m *SequenceManager) Peek(address sdk.AccAddress) (*AccountInfo, bool) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if !acc.loaded {
+		return nil, false
+	}
+	return &AccountInfo{AccountNumber: acc.accountNumber, Sequence: acc.next}, true
+}
+
+// current returns the account number and the sequence the next call to Next would
+// hand out, loading them from the chain if they are not cached, without reserving
+// the sequence
+func (m *SequenceManager) current(ctx context.Context, address sdk.AccAddress) (*AccountInfo, error) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if !acc.loaded {
+		if err := m.loadLocked(ctx, address, acc); err != nil {
+			return nil, err
+		}
+	}
+	return &AccountInfo{AccountNumber: acc.accountNumber, Sequence: acc.next}, nil
+}
+
+// release returns a sequence reserved by Next that was never broadcast. It only
+// takes effect if no later sequence has been reserved since; otherwise the gap is
+// left for the chain to report as a sequence mismatch.
+func (m *SequenceManager) release(address sdk.AccAddress, sequence uint64) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if acc.loaded && acc.next == sequence+1 {
+		acc.next = sequence
+	}
+}
+
+// releaseRejected releases the sequence of a transaction that failed to broadcast
+// if CheckTx rejected it, as the sequence was then never consumed
+func (m *SequenceManager) releaseRejected(address sdk.AccAddress, sequence uint64, err error) {
+	var txErr *TxError
+	if errors.As(err, &txErr) && txErr.Height == 0 {
+		m.release(address, sequence)
+	}
+}
+
+// Resync reloads the account number and sequence from the chain
+func (m *SequenceManager) Resync(ctx context.Context, address sdk.AccAddress) (*AccountInfo, error) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if err := m.loadLocked(ctx, address, acc); err != nil {
+		return nil, err
+	}
+	return &AccountInfo{AccountNumber: acc.accountNumber, Sequence: acc.next}, nil
+}
+
+// Invalidate drops the cached state so the next call to Next reloads it from the chain
+func (m *SequenceManager) Invalidate(address sdk.AccAddress) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	acc.loaded = false
+}
+
+// HandleError inspects an error returned while broadcasting a transaction for the
+// address and resyncs the cached sequence if it indicates a sequence mismatch.
+// It reports whether a resync took place, i.e. whether retrying makes sense.
+//
+// Other errors leave the cache untouched: sequences reserved by concurrent senders
+// may still be in flight, and reloading committed state would hand them out again.
+func (m *SequenceManager) HandleError(ctx context.Context, address sdk.AccAddress, err error) bool {
+	if !IsSequenceMismatch(err) {
+		return false
+	}
+
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if expected, ok := expectedSequenceFromError(err); ok && acc.loaded {
+		acc.next = expected
+		return true
+	}
+
+	if lerr := m.loadLocked(ctx, address, acc); lerr != nil {
+		acc.loaded = false
+	}
+	return true
+}
+
+// SignAndBroadcast reserves a sequence, builds and signs a transaction with the
+// given messages and broadcasts it, resyncing and retrying on sequence mismatch
+//
+// The ChainID, gas, fee, memo and timeout height are taken from params; the
+// account number and sequence are filled in by the manager.
+func (m *SequenceManager) SignAndBroadcast(
+	ctx context.Context,
+	signer Signer,
+	msgs []sdk.Msg,
+	params *TxParams,
+	opts ...BroadcastOpt,
+) (*BroadcastResult, error) {
+	pubKey, err := signerPubKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	address := sdk.AccAddress(pubKey.Address())
+	if params == nil {
+		return nil, fmt.Errorf("transaction parameters are required")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= defaultSequenceMismatchRetries; attempt++ {
+		info, err := m.Next(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve sequence: %w", err)
+		}
+
+		txParams := *params
+		txParams.AccountNumber = info.AccountNumber
+		txParams.Sequence = info.Sequence
+
+		signedTx, err := CreateSignedTx(msgs, signer, &txParams)
+		if err != nil {
+			m.release(address, info.Sequence)
+			return nil, err
+		}
+
+		res, err := m.client.BroadcastTx(ctx, signedTx, opts...)
+		if err == nil {
+			return res, nil
+		}
+
+		lastErr = err
+		if !m.HandleError(ctx, address, err) {
+			m.releaseRejected(address, info.Sequence, err)
+			return res, err
+		}
+	}
+
+	return nil, fmt.Errorf("giving up after %d sequence mismatches: %w", defaultSequenceMismatchRetries+1, lastErr)
+}
+
+func (m *SequenceManager) loadLocked(ctx context.Context, address sdk.AccAddress, acc *accountSequence) error {
+	info, err := QueryAccountInfo(ctx, m.client, address)
+	if err != nil {
+		return fmt.Errorf("failed to query account info: %w", err)
+	}
+
+	acc.accountNumber = info.AccountNumber
+	acc.next = info.Sequence
+	acc.loaded = true
+	return nil
+}
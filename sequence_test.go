@@ -0,0 +1,260 @@
+package allora
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog"
+)
+
+func TestIsSequenceMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "typed tx error",
+			err:      &TxError{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrWrongSequence.ABCICode()},
+			expected: true,
+		},
+		{
+			name:     "wrapped message",
+			err:      fmt.Errorf("simulation failed: %w", errors.New("account sequence mismatch, expected 12, got 10: incorrect account sequence")),
+			expected: true,
+		},
+		{
+			name:     "other tx error",
+			err:      &TxError{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInsufficientFee.ABCICode()},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSequenceMismatch(tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExpectedSequenceFromError(t *testing.T) {
+	err := &TxError{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+		Log:       "account sequence mismatch, expected 12, got 10: incorrect account sequence",
+	}
+
+	seq, ok := expectedSequenceFromError(err)
+	if !ok {
+		t.Fatal("expected to parse sequence from error")
+	}
+	if seq != 12 {
+		t.Errorf("expected sequence 12, got %d", seq)
+	}
+
+	if _, ok := expectedSequenceFromError(errors.New("insufficient fees")); ok {
+		t.Error("expected no sequence from unrelated error")
+	}
+}
+
+func TestSequenceManagerHandsOutMonotonicSequences(t *testing.T) {
+	wallet, _ := GenerateWallet()
+	mgr := NewSequenceManager(nil)
+
+	// Seed the cache as if the account had been loaded from the chain
+	acc := mgr.account(wallet.Address)
+	acc.loaded = true
+	acc.accountNumber = 7
+	acc.next = 3
+
+	const n = 50
+	seen := make(chan uint64, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			info, err := mgr.Next(t.Context(), wallet.Address)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				seen <- 0
+				return
+			}
+			seen <- info.Sequence
+		}()
+	}
+
+	unique := make(map[uint64]bool)
+	for i := 0; i < n; i++ {
+		unique[<-seen] = true
+	}
+	if len(unique) != n {
+		t.Errorf("expected %d unique sequences, got %d", n, len(unique))
+	}
+
+	next, ok := mgr.Peek(wallet.Address)
+	if !ok || next.Sequence != 3+n || next.AccountNumber != 7 {
+		t.Errorf("unexpected next account info: %+v", next)
+	}
+
+	// A mismatch error carrying the expected sequence resets the cache without a query
+	resynced := mgr.HandleError(t.Context(), wallet.Address, &TxError{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+		Log:       "account sequence mismatch, expected 10, got 53: incorrect account sequence",
+	})
+	if !resynced {
+		t.Fatal("expected mismatch to trigger a resync")
+	}
+	next, _ = mgr.Peek(wallet.Address)
+	if next.Sequence != 10 {
+		t.Errorf("expected next sequence 10 after resync, got %d", next.Sequence)
+	}
+}
+
+// seededSequenceManager returns a manager that has already loaded the wallet's
+// account with account number 7 and next sequence 3
+func seededSequenceManager(t *testing.T, client Client) (*SequenceManager, *Wallet) {
+	t.Helper()
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	mgr := NewSequenceManager(client)
+	acc := mgr.account(wallet.Address)
+	acc.loaded = true
+	acc.accountNumber = 7
+	acc.next = 3
+	return mgr, wallet
+}
+
+func autoGasBuilder(t *testing.T, client Client, mgr *SequenceManager, wallet *Wallet) *TxParamsBuilder {
+	t.Helper()
+	msg := banktypes.NewMsgSend(wallet.Address, wallet.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1)))
+	return NewTxParamsBuilder(t.Context(), client).
+		WithChainID("allora-testnet-1").
+		WithAddress(wallet.Address).
+		WithSequenceManager(mgr).
+		WithPubKey(wallet.PubKey).
+		WithMsgs(msg).
+		WithAutoGas(1.5).
+		WithGasPrice(sdk.NewDecCoinFromDec("uallo", sdkmath.LegacyMustNewDecFromStr("0.1")))
+}
+
+func TestQueryAndBuildReservesSequenceAfterEstimation(t *testing.T) {
+	c := &client{cosmosPool: &fakeTxPool{gasUsed: 1000}, logger: zerolog.Nop()}
+	mgr, wallet := seededSequenceManager(t, c)
+
+	params, err := autoGasBuilder(t, c, mgr, wallet).QueryAndBuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.AccountNumber != 7 || params.Sequence != 3 || params.GasLimit != 1500 {
+		t.Errorf("unexpected params: %+v", params)
+	}
+	if next, _ := mgr.Peek(wallet.Address); next.Sequence != 4 {
+		t.Errorf("expected sequence 3 to be reserved, next is %d", next.Sequence)
+	}
+}
+
+func TestQueryAndBuildKeepsSequenceWhenEstimationFails(t *testing.T) {
+	c := &client{cosmosPool: &fakeTxPool{simulateErr: errors.New("node unavailable")}, logger: zerolog.Nop()}
+	mgr, wallet := seededSequenceManager(t, c)
+
+	if _, err := autoGasBuilder(t, c, mgr, wallet).QueryAndBuild(); err == nil {
+		t.Fatal("expected gas estimation to fail")
+	}
+	if next, _ := mgr.Peek(wallet.Address); next.Sequence != 3 {
+		t.Errorf("expected sequence 3 to stay available, next is %d", next.Sequence)
+	}
+}
+
+func TestQueryAndBuildDoesNotReserveCallerSequence(t *testing.T) {
+	mgr, wallet := seededSequenceManager(t, nil)
+
+	params, err := NewTxParamsBuilder(t.Context(), nil).
+		WithChainID("allora-testnet-1").
+		WithAddress(wallet.Address).
+		WithSequenceManager(mgr).
+		WithSequence(9).
+		QueryAndBuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.AccountNumber != 7 || params.Sequence != 9 {
+		t.Errorf("unexpected params: %+v", params)
+	}
+	if next, _ := mgr.Peek(wallet.Address); next.Sequence != 3 {
+		t.Errorf("expected no sequence to be reserved, next is %d", next.Sequence)
+	}
+}
+
+func TestSequenceManagerKeepsInFlightSequencesOnRejection(t *testing.T) {
+	mgr, wallet := seededSequenceManager(t, nil)
+	for i := 0; i < 3; i++ {
+		if _, err := mgr.Next(t.Context(), wallet.Address); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Sequences 3 to 5 are in flight; a CheckTx rejection unrelated to the sequence
+	// must not reload the committed sequence and hand them out again
+	resynced := mgr.HandleError(t.Context(), wallet.Address, &TxError{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrInsufficientFee.ABCICode(),
+		Log:       "insufficient fees",
+	})
+	if resynced {
+		t.Error("expected no resync for a non-sequence error")
+	}
+	if next, ok := mgr.Peek(wallet.Address); !ok || next.Sequence != 6 {
+		t.Errorf("expected next sequence 6, got %+v", next)
+	}
+
+	// Only the latest reservation can be handed back
+	mgr.release(wallet.Address, 4)
+	if next, _ := mgr.Peek(wallet.Address); next.Sequence != 6 {
+		t.Errorf("expected releasing an older sequence to be a no-op, next is %d", next.Sequence)
+	}
+	mgr.release(wallet.Address, 5)
+	if next, _ := mgr.Peek(wallet.Address); next.Sequence != 5 {
+		t.Errorf("expected sequence 5 to be released, next is %d", next.Sequence)
+	}
+}
+
+func TestEmissionsTxSendKeepsInFlightSequencesOnRejection(t *testing.T) {
+	txPool := &fakeTxPool{gasUsed: 1000, checkTx: &sdk.TxResponse{
+		Codespace: sdkerrors.RootCodespace,
+		Code:      sdkerrors.ErrInsufficientFee.ABCICode(),
+		RawLog:    "insufficient fees",
+	}}
+	c := newBroadcastTestClient(txPool, nil)
+	mgr, wallet := seededSequenceManager(t, c)
+
+	// Sequence 3 is in flight for a concurrent sender
+	if _, err := mgr.Next(t.Context(), wallet.Address); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e := NewEmissionsTx(c, wallet).
+		WithSequenceManager(mgr).
+		WithGasPrice(sdk.NewDecCoinFromDec("uallo", sdkmath.LegacyMustNewDecFromStr("0.1")))
+	e.chainID = "allora-testnet-1"
+
+	msg := banktypes.NewMsgSend(wallet.Address, wallet.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1)))
+	if _, err := e.Send(t.Context(), []sdk.Msg{msg}); !errors.Is(err, sdkerrors.ErrInsufficientFee) {
+		t.Fatalf("expected the CheckTx rejection, got %v", err)
+	}
+	if next, ok := mgr.Peek(wallet.Address); !ok || next.Sequence != 4 {
+		t.Errorf("expected sequence 4 to be released and sequence 3 kept in flight, got %+v", next)
+	}
+}
@@ -0,0 +1,41 @@
+package allora
+
+import (
+	"fmt"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Signer signs transactions and payloads with a secp256k1 key
+//
+// Wallet implements Signer with an in-memory key. Implementations backed by a
+// KMS, an HSM or a remote signing service keep the private key out of the
+// process; see the remotesigner package for a reference implementation.
+type Signer interface {
+	// GetPubKey returns the public key of the signing key
+	GetPubKey() cryptotypes.PubKey
+
+	// Sign signs msg and returns the signature in the format produced by
+	// cryptotypes.PrivKey.Sign (for secp256k1, 64-byte r||s over sha256(msg))
+	Sign(msg []byte) ([]byte, error)
+}
+
+var _ Signer = (*Wallet)(nil)
+
+// SignerAddress returns the account address of a signer
+func SignerAddress(signer Signer) sdk.AccAddress {
+	return sdk.AccAddress(signer.GetPubKey().Address())
+}
+
+// signerPubKey returns the signer's public key, failing if the signer is missing
+func signerPubKey(signer Signer) (cryptotypes.PubKey, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+	pubKey := signer.GetPubKey()
+	if pubKey == nil {
+		return nil, fmt.Errorf("signer has no public key")
+	}
+	return pubKey, nil
+}
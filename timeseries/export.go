@@ -0,0 +1,43 @@
+package timeseries
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+var csvHeader = []string{"height", "topic_id", "metric", "address", "label", "value"}
+
+// WriteCSV writes the samples as CSV with a header row
+func WriteCSV(w io.Writer, samples []Sample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		record := []string{
+			strconv.FormatInt(s.Height, 10),
+			strconv.FormatUint(s.TopicID, 10),
+			s.Metric,
+			s.Address,
+			s.Label,
+			s.Value,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteParquet writes the samples as a Parquet file with the columns of Sample
+func WriteParquet(w io.Writer, samples []Sample) error {
+	if err := parquet.Write(w, samples); err != nil {
+		return fmt.Errorf("failed to write parquet: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package timeseries
+
+import (
+	"context"
+	"errors"
+
+	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+)
+
+// NetworkInferences samples the topic's latest network inferences as of each height:
+// the combined and naive values and the values of each inferer and forecaster
+// (metrics "combined_value", "naive_value", "inferer_value" and "forecaster_value").
+func NetworkInferences(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error) {
+	bundle, err := networkInferences(ctx, q, topicID, height)
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+
+	var samples []Sample
+	addLabeled := func(metric, address string, values []*emissionstypes.LabeledValue) {
+		for _, v := range values {
+			samples = append(samples, Sample{Metric: metric, Address: address, Label: v.LabelName, Value: v.Value.String()})
+		}
+	}
+	addLabeled("combined_value", "", bundle.CombinedValue)
+	addLabeled("naive_value", "", bundle.NaiveValue)
+	for _, inference := range bundle.InfererValues {
+		addLabeled("inferer_value", inference.Worker, inference.Values)
+	}
+	for _, inference := range bundle.ForecasterValues {
+		addLabeled("forecaster_value", inference.Worker, inference.Values)
+	}
+	return samples, nil
+}
+
+func networkInferences(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) (*emissionstypes.NetworkInferenceBundle, error) {
+	resp, err := q.GetLatestNetworkInferences(ctx, &emissionstypes.GetLatestNetworkInferencesRequest{TopicId: topicID}, config.Height(height))
+	if err != nil {
+		return nil, err
+	}
+	return resp.NetworkInferences, nil
+}
+
+// InfererScoreEmas samples the score EMAs of the given inferers (metric
+// "inferer_score_ema"). Without addresses, the inferers of the network inferences
+// as of each height are sampled.
+func InfererScoreEmas(inferers ...string) Kind {
+	return func(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error) {
+		addresses, err := workersOrDefault(ctx, q, topicID, height, inferers, func(b *emissionstypes.NetworkInferenceBundle) []*emissionstypes.WorkerInference {
+			return b.InfererValues
+		})
+		if err != nil {
+			return nil, err
+		}
+		return scoreEmas(addresses, "inferer_score_ema", func(address string) (*emissionstypes.Score, error) {
+			resp, err := q.GetInfererScoreEma(ctx, &emissionstypes.GetInfererScoreEmaRequest{TopicId: topicID, Inferer: address}, config.Height(height))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Score, nil
+		})
+	}
+}
+
+// ForecasterScoreEmas samples the score EMAs of the given forecasters (metric
+// "forecaster_score_ema"). Without addresses, the forecasters of the network
+// inferences as of each height are sampled.
+func ForecasterScoreEmas(forecasters ...string) Kind {
+	return func(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error) {
+		addresses, err := workersOrDefault(ctx, q, topicID, height, forecasters, func(b *emissionstypes.NetworkInferenceBundle) []*emissionstypes.WorkerInference {
+			return b.ForecasterValues
+		})
+		if err != nil {
+			return nil, err
+		}
+		return scoreEmas(addresses, "forecaster_score_ema", func(address string) (*emissionstypes.Score, error) {
+			resp, err := q.GetForecasterScoreEma(ctx, &emissionstypes.GetForecasterScoreEmaRequest{TopicId: topicID, Forecaster: address}, config.Height(height))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Score, nil
+		})
+	}
+}
+
+// ReputerScoreEmas samples the score EMAs of the given reputers (metric
+// "reputer_score_ema")
+func ReputerScoreEmas(reputers ...string) Kind {
+	return func(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error) {
+		if len(reputers) == 0 {
+			return nil, errors.New("no reputers given")
+		}
+		return scoreEmas(reputers, "reputer_score_ema", func(address string) (*emissionstypes.Score, error) {
+			resp, err := q.GetReputerScoreEma(ctx, &emissionstypes.GetReputerScoreEmaRequest{TopicId: topicID, Reputer: address}, config.Height(height))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Score, nil
+		})
+	}
+}
+
+// TopicStake samples the total stake in the topic (metric "topic_stake")
+func TopicStake(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error) {
+	resp, err := q.GetTopicStake(ctx, &emissionstypes.GetTopicStakeRequest{TopicId: topicID}, config.Height(height))
+	if err != nil {
+		return nil, err
+	}
+	return []Sample{{Metric: "topic_stake", Value: resp.Amount.String()}}, nil
+}
+
+func workersOrDefault(
+	ctx context.Context,
+	q interfaces.EmissionsClient,
+	topicID uint64,
+	height int64,
+	addresses []string,
+	workers func(*emissionstypes.NetworkInferenceBundle) []*emissionstypes.WorkerInference,
+) ([]string, error) {
+	if len(addresses) > 0 {
+		return addresses, nil
+	}
+	bundle, err := networkInferences(ctx, q, topicID, height)
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+	for _, w := range workers(bundle) {
+		addresses = append(addresses, w.Worker)
+	}
+	return addresses, nil
+}
+
+func scoreEmas(addresses []string, metric string, query func(address string) (*emissionstypes.Score, error)) ([]Sample, error) {
+	samples := make([]Sample, 0, len(addresses))
+	for _, address := range addresses {
+		score, err := query(address)
+		if err != nil {
+			return nil, err
+		}
+		if score == nil {
+			continue
+		}
+		samples = append(samples, Sample{Metric: metric, Address: address, Value: score.Score.String()})
+	}
+	return samples, nil
+}
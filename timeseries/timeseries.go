@@ -0,0 +1,139 @@
+// Package timeseries extracts historical emissions data by running height-pinned
+// queries over a range of blocks.
+package timeseries
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+)
+
+// Range selects the heights From, From+Stride, ... up to To (inclusive)
+type Range struct {
+	From   int64
+	To     int64
+	Stride int64 // 1 when zero
+}
+
+// Heights returns the heights selected by the range
+func (r Range) Heights() ([]int64, error) {
+	stride := r.Stride
+	if stride == 0 {
+		stride = 1
+	}
+	if r.From <= 0 || r.To < r.From || stride < 0 {
+		return nil, fmt.Errorf("invalid range %d..%d with stride %d", r.From, r.To, r.Stride)
+	}
+	heights := make([]int64, 0, (r.To-r.From)/stride+1)
+	for h := r.From; h <= r.To; h += stride {
+		heights = append(heights, h)
+	}
+	return heights, nil
+}
+
+// Point is the result of a query at a height
+type Point[T any] struct {
+	Height int64
+	Value  T
+}
+
+// Opt is a functional option for configuring an extraction
+type Opt func(*Opts)
+
+// Opts holds configuration options for an extraction
+type Opts struct {
+	Concurrency int // queries in flight
+}
+
+// Apply applies the provided options to Opts
+func (o *Opts) Apply(opts ...Opt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultOpts returns default extraction options
+func DefaultOpts() *Opts {
+	return &Opts{
+		Concurrency: 8,
+	}
+}
+
+// WithConcurrency sets how many queries are in flight at once
+func WithConcurrency(n int) Opt {
+	return func(opts *Opts) {
+		opts.Concurrency = n
+	}
+}
+
+// Collect runs query at every height of r, with bounded concurrency, and returns the
+// results in height order. It stops at the first failed query.
+func Collect[T any](ctx context.Context, r Range, query func(ctx context.Context, height int64) (T, error), opts ...Opt) ([]Point[T], error) {
+	o := DefaultOpts()
+	o.Apply(opts...)
+
+	heights, err := r.Heights()
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point[T], len(heights))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(o.Concurrency, 1))
+	for i, height := range heights {
+		g.Go(func() error {
+			value, err := query(ctx, height)
+			if err != nil {
+				return fmt.Errorf("query at height %d failed: %w", height, err)
+			}
+			points[i] = Point[T]{Height: height, Value: value}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// Sample is one value of a series. Samples form a long table that can be written to
+// CSV or Parquet, e.g. one row per inferer and height for inferer score EMAs.
+type Sample struct {
+	Height  int64  `parquet:"height"`
+	TopicID uint64 `parquet:"topic_id"`
+	Metric  string `parquet:"metric"`  // e.g. "combined_value" or "inferer_score_ema"
+	Address string `parquet:"address"` // the actor the value belongs to; empty for topic-wide values
+	Label   string `parquet:"label"`   // the label of multi-dimensional inferences
+	Value   string `parquet:"value"`   // the exact decimal value
+}
+
+// Kind queries the samples of a topic at a height. Height and TopicID of the returned
+// samples are filled in by Extract.
+type Kind func(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, height int64) ([]Sample, error)
+
+// Extract runs kind for the topic at every height of r and returns the samples in
+// height order, e.g.
+//
+//	samples, err := timeseries.Extract(ctx, client.Cosmos().Emissions(), 1, timeseries.NetworkInferences,
+//	    timeseries.Range{From: 1_000_000, To: 1_010_000, Stride: 100})
+//	err = timeseries.WriteCSV(file, samples)
+func Extract(ctx context.Context, q interfaces.EmissionsClient, topicID uint64, kind Kind, r Range, opts ...Opt) ([]Sample, error) {
+	points, err := Collect(ctx, r, func(ctx context.Context, height int64) ([]Sample, error) {
+		return kind(ctx, q, topicID, height)
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []Sample
+	for _, p := range points {
+		for _, s := range p.Value {
+			s.Height, s.TopicID = p.Height, topicID
+			samples = append(samples, s)
+		}
+	}
+	return samples, nil
+}
@@ -0,0 +1,141 @@
+package timeseries
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alloramath "github.com/allora-network/allora-chain/math"
+	emissionstypes "github.com/allora-network/allora-chain/x/emissions/types"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
+)
+
+// fakeEmissions answers height-pinned queries with values derived from the height
+type fakeEmissions struct {
+	interfaces.EmissionsClient
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	mu      sync.Mutex
+	heights []int64
+}
+
+func (f *fakeEmissions) height(opts []config.CallOpt) int64 {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		m := f.maxInFlight.Load()
+		if n <= m || f.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	o := config.DefaultCallOpts()
+	o.Apply(opts...)
+	f.mu.Lock()
+	f.heights = append(f.heights, o.Height)
+	f.mu.Unlock()
+	return o.Height
+}
+
+func decOf(height int64) alloramath.Dec {
+	return alloramath.MustNewDecFromString(strconv.FormatInt(height, 10) + ".5")
+}
+
+func (f *fakeEmissions) GetLatestNetworkInferences(_ context.Context, req *emissionstypes.GetLatestNetworkInferencesRequest, opts ...config.CallOpt) (*emissionstypes.GetLatestNetworkInferencesResponse, error) {
+	height := f.height(opts)
+	return &emissionstypes.GetLatestNetworkInferencesResponse{NetworkInferences: &emissionstypes.NetworkInferenceBundle{
+		TopicId:       req.TopicId,
+		CombinedValue: []*emissionstypes.LabeledValue{{Value: decOf(height)}},
+		InfererValues: []*emissionstypes.WorkerInference{
+			{Worker: "allo1a", Values: []*emissionstypes.LabeledValue{{Value: decOf(height)}}},
+			{Worker: "allo1b", Values: []*emissionstypes.LabeledValue{{Value: decOf(height)}}},
+		},
+	}}, nil
+}
+
+func (f *fakeEmissions) GetInfererScoreEma(_ context.Context, req *emissionstypes.GetInfererScoreEmaRequest, opts ...config.CallOpt) (*emissionstypes.GetInfererScoreEmaResponse, error) {
+	height := f.height(opts)
+	return &emissionstypes.GetInfererScoreEmaResponse{Score: &emissionstypes.Score{Address: req.Inferer, Score: decOf(height)}}, nil
+}
+
+func TestRangeHeights(t *testing.T) {
+	heights, err := Range{From: 10, To: 20, Stride: 5}.Heights()
+	if err != nil || len(heights) != 3 || heights[2] != 20 {
+		t.Fatalf("unexpected heights %v (%v)", heights, err)
+	}
+	if _, err := (Range{From: 20, To: 10}).Heights(); err == nil {
+		t.Fatal("expected an inverted range to be rejected")
+	}
+}
+
+func TestExtractNetworkInferences(t *testing.T) {
+	q := &fakeEmissions{}
+	samples, err := Extract(context.Background(), q, 7, NetworkInferences, Range{From: 100, To: 199, Stride: 3}, WithConcurrency(4))
+	if err != nil {
+		t.Fatalf("failed to extract: %v", err)
+	}
+	if n := q.maxInFlight.Load(); n > 4 {
+		t.Fatalf("expected at most 4 queries in flight, got %d", n)
+	}
+
+	// 34 heights with one combined and two inferer values each, in height order
+	if len(samples) != 34*3 {
+		t.Fatalf("expected %d samples, got %d", 34*3, len(samples))
+	}
+	for i, s := range samples {
+		height := 100 + int64(i/3)*3
+		if s.Height != height || s.TopicID != 7 || s.Value != strconv.FormatInt(height, 10)+".5" {
+			t.Fatalf("unexpected sample %d: %+v", i, s)
+		}
+	}
+	if samples[1].Metric != "inferer_value" || samples[1].Address != "allo1a" {
+		t.Fatalf("unexpected inferer sample %+v", samples[1])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, samples[:3]); err != nil {
+		t.Fatalf("failed to write csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "height,topic_id,metric,address,label,value" || lines[1] != "100,7,combined_value,,,100.5" {
+		t.Fatalf("unexpected csv:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteParquet(&buf, samples); err != nil {
+		t.Fatalf("failed to write parquet: %v", err)
+	}
+	read, err := parquet.Read[Sample](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil || len(read) != len(samples) || read[5] != samples[5] {
+		t.Fatalf("failed to round-trip parquet: %v", err)
+	}
+}
+
+func TestExtractInfererScoreEmas(t *testing.T) {
+	q := &fakeEmissions{}
+
+	// Without addresses, the inferers come from the network inferences at each height
+	samples, err := Extract(context.Background(), q, 7, InfererScoreEmas(), Range{From: 10, To: 11})
+	if err != nil {
+		t.Fatalf("failed to extract: %v", err)
+	}
+	if len(samples) != 4 || samples[0].Metric != "inferer_score_ema" || samples[3].Address != "allo1b" || samples[3].Value != "11.5" {
+		t.Fatalf("unexpected samples %+v", samples)
+	}
+
+	samples, err = Extract(context.Background(), q, 7, InfererScoreEmas("allo1c"), Range{From: 10, To: 11})
+	if err != nil || len(samples) != 2 || samples[1].Address != "allo1c" {
+		t.Fatalf("unexpected samples %+v (%v)", samples, err)
+	}
+}
@@ -0,0 +1,279 @@
+package tmrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+)
+
+// BlockEvent is a block delivered by a BlockStream
+type BlockEvent struct {
+	ctypes.EventDataNewBlock
+	Backfilled bool // fetched over RPC rather than received over the websocket
+}
+
+// Height returns the height of the block
+func (b BlockEvent) Height() int64 {
+	return b.Block.Height
+}
+
+// BlockStreamOpt is a functional option for configuring a BlockStream
+type BlockStreamOpt func(*BlockStreamOpts)
+
+// BlockStreamOpts holds configuration options for a BlockStream
+type BlockStreamOpts struct {
+	StartHeight   int64         // first height to deliver; 0 starts at the first live block
+	BufferSize    int           // capacity of the returned channel
+	StallTimeout  time.Duration // how long without a live block before polling Status for new heights
+	RetryInterval time.Duration // initial delay between attempts to fetch a missing block
+}
+
+// Apply applies the provided options to BlockStreamOpts
+func (o *BlockStreamOpts) Apply(opts ...BlockStreamOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultBlockStreamOpts returns default block stream options
+func DefaultBlockStreamOpts() *BlockStreamOpts {
+	return &BlockStreamOpts{
+		BufferSize:    100,
+		StallTimeout:  30 * time.Second,
+		RetryInterval: time.Second,
+	}
+}
+
+// WithStartHeight makes the stream backfill from height before following live blocks
+func WithStartHeight(height int64) BlockStreamOpt {
+	return func(opts *BlockStreamOpts) {
+		opts.StartHeight = height
+	}
+}
+
+// WithBlockBufferSize sets the capacity of the returned channel
+func WithBlockBufferSize(size int) BlockStreamOpt {
+	return func(opts *BlockStreamOpts) {
+		opts.BufferSize = size
+	}
+}
+
+// WithBlockStallTimeout sets how long the stream waits for a live block before
+// checking the latest height over RPC
+func WithBlockStallTimeout(timeout time.Duration) BlockStreamOpt {
+	return func(opts *BlockStreamOpts) {
+		opts.StallTimeout = timeout
+	}
+}
+
+// WithBlockRetryInterval sets the initial delay between attempts to fetch a missing block
+func WithBlockRetryInterval(interval time.Duration) BlockStreamOpt {
+	return func(opts *BlockStreamOpts) {
+		opts.RetryInterval = interval
+	}
+}
+
+// BlockStream delivers a gapless, ordered sequence of blocks. Live NewBlock events
+// come from the websocket pool; heights missed while a websocket was reconnecting
+// (or before the first live block, with WithStartHeight) are fetched with Block and
+// BlockResults before the stream resumes with live blocks.
+type BlockStream struct {
+	websockets WebsocketPool
+	rpc        ClientPool
+	opts       *BlockStreamOpts
+	logger     zerolog.Logger
+
+	mu         sync.Mutex
+	lastHeight int64
+}
+
+func NewBlockStream(websockets WebsocketPool, rpc ClientPool, logger zerolog.Logger, opts ...BlockStreamOpt) *BlockStream {
+	o := DefaultBlockStreamOpts()
+	o.Apply(opts...)
+
+	s := &BlockStream{
+		websockets: websockets,
+		rpc:        rpc,
+		opts:       o,
+		logger:     logger.With().Str("component", "block_stream").Logger(),
+	}
+	if o.StartHeight > 0 {
+		s.lastHeight = o.StartHeight - 1
+	}
+	return s
+}
+
+// LastHeight returns the height of the last delivered block, or 0 if none was
+// delivered and no start height was set
+func (s *BlockStream) LastHeight() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastHeight
+}
+
+// last returns the last delivered height and whether the stream knows which height
+// comes next
+func (s *BlockStream) last() (int64, bool) {
+	last := s.LastHeight()
+	return last, last > 0 || s.opts.StartHeight > 0
+}
+
+func (s *BlockStream) setLastHeight(height int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastHeight = height
+}
+
+// Start subscribes to new blocks and returns the stream's channel. The channel is
+// closed and the subscription cancelled when ctx is done.
+func (s *BlockStream) Start(ctx context.Context) (<-chan BlockEvent, error) {
+	mb := NewMailbox(poolMailboxCapacity)
+	sub, err := s.websockets.Subscribe(mb, ctypes.EventQueryNewBlock.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+
+	ch := make(chan BlockEvent, s.opts.BufferSize)
+	go func() {
+		defer close(ch)
+		defer func() {
+			if err := sub.Unsubscribe(); err != nil {
+				s.logger.Warn().Err(err).Msg("failed to unsubscribe")
+			}
+		}()
+
+		stall := time.NewTimer(s.opts.StallTimeout)
+		defer stall.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				s.logger.Error().Err(err).Msg("block subscription failed")
+			case <-mb.Notify():
+				for _, data := range mb.RetrieveAll() {
+					block, ok := data.(ctypes.EventDataNewBlock)
+					if !ok || block.Block == nil {
+						continue
+					}
+					if !s.deliverLive(ctx, ch, block) {
+						return
+					}
+				}
+				stall.Reset(s.opts.StallTimeout)
+			case <-stall.C:
+				if !s.catchUp(ctx, ch) {
+					return
+				}
+				stall.Reset(s.opts.StallTimeout)
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// deliverLive backfills the heights between the last delivered block and block, then
+// delivers block. Blocks at or below the last delivered height are dropped. It returns
+// false once ctx is done.
+func (s *BlockStream) deliverLive(ctx context.Context, ch chan<- BlockEvent, block ctypes.EventDataNewBlock) bool {
+	height := block.Block.Height
+	last, known := s.last()
+	if known && height <= last {
+		return true
+	}
+	if known && height > last+1 {
+		s.logger.Info().Int64("from", last+1).Int64("to", height-1).Msg("backfilling missed blocks")
+		if !s.backfill(ctx, ch, last+1, height-1) {
+			return false
+		}
+	}
+	return s.send(ctx, ch, BlockEvent{EventDataNewBlock: block})
+}
+
+// catchUp backfills up to the latest height when no live block arrived for a while,
+// e.g. because every websocket is down
+func (s *BlockStream) catchUp(ctx context.Context, ch chan<- BlockEvent) bool {
+	status, err := s.rpc.Status(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("no live blocks and failed to get the latest height")
+		return ctx.Err() == nil
+	}
+	last, known := s.last()
+	latest := status.SyncInfo.LatestBlockHeight
+	if !known || latest <= last {
+		return true
+	}
+	s.logger.Info().Int64("from", last+1).Int64("to", latest).Msg("no live blocks, backfilling")
+	return s.backfill(ctx, ch, last+1, latest)
+}
+
+func (s *BlockStream) backfill(ctx context.Context, ch chan<- BlockEvent, from, to int64) bool {
+	for height := from; height <= to; height++ {
+		block, ok := s.fetchBlock(ctx, height)
+		if !ok {
+			return false
+		}
+		if !s.send(ctx, ch, BlockEvent{EventDataNewBlock: block, Backfilled: true}) {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchBlock retries until the block is fetched or ctx is done, so that the stream
+// never skips a height
+func (s *BlockStream) fetchBlock(ctx context.Context, height int64) (ctypes.EventDataNewBlock, bool) {
+	delay := s.opts.RetryInterval
+	for {
+		block, err := s.getBlock(ctx, height)
+		if err == nil {
+			return block, true
+		}
+		s.logger.Error().Err(err).Int64("height", height).Dur("retry_in", delay).Msg("failed to fetch missed block")
+
+		select {
+		case <-ctx.Done():
+			return ctypes.EventDataNewBlock{}, false
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, time.Minute)
+	}
+}
+
+func (s *BlockStream) getBlock(ctx context.Context, height int64) (ctypes.EventDataNewBlock, error) {
+	block, err := s.rpc.Block(ctx, &height)
+	if err != nil {
+		return ctypes.EventDataNewBlock{}, fmt.Errorf("failed to get block: %w", err)
+	}
+	results, err := s.rpc.BlockResults(ctx, &height)
+	if err != nil {
+		return ctypes.EventDataNewBlock{}, fmt.Errorf("failed to get block results: %w", err)
+	}
+	return ctypes.EventDataNewBlock{
+		Block:   block.Block,
+		BlockID: block.BlockID,
+		ResultFinalizeBlock: abcitypes.ResponseFinalizeBlock{
+			Events:                results.FinalizeBlockEvents,
+			TxResults:             results.TxsResults,
+			ValidatorUpdates:      results.ValidatorUpdates,
+			ConsensusParamUpdates: results.ConsensusParamUpdates,
+			AppHash:               results.AppHash,
+		},
+	}, nil
+}
+
+func (s *BlockStream) send(ctx context.Context, ch chan<- BlockEvent, block BlockEvent) bool {
+	select {
+	case ch <- block:
+		s.setLastHeight(block.Height())
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
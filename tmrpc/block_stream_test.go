@@ -0,0 +1,125 @@
+package tmrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// fakeClientPool serves every height up to latest; the first request for each height
+// fails once if flaky is set
+type fakeClientPool struct {
+	ClientPool
+
+	mu     sync.Mutex
+	latest int64
+	flaky  bool
+	failed map[int64]bool
+}
+
+func (p *fakeClientPool) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.flaky && !p.failed[*height] {
+		p.failed[*height] = true
+		return nil, errors.New("connection reset")
+	}
+	return &coretypes.ResultBlock{Block: &ctypes.Block{Header: ctypes.Header{Height: *height}}}, nil
+}
+
+func (p *fakeClientPool) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{
+		Height:              *height,
+		FinalizeBlockEvents: []abcitypes.Event{{Type: "backfilled"}},
+	}, nil
+}
+
+func (p *fakeClientPool) Status(context.Context) (*coretypes.ResultStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: p.latest}}, nil
+}
+
+func newBlockEvent(height int64) ctypes.EventDataNewBlock {
+	return ctypes.EventDataNewBlock{Block: &ctypes.Block{Header: ctypes.Header{Height: height}}}
+}
+
+func expectBlocks(t *testing.T, ch <-chan BlockEvent, heights []int64, backfilled []bool) {
+	t.Helper()
+	for i, height := range heights {
+		select {
+		case block := <-ch:
+			if block.Height() != height || block.Backfilled != backfilled[i] {
+				t.Fatalf("expected height %d (backfilled %v), got %d (backfilled %v)", height, backfilled[i], block.Height(), block.Backfilled)
+			}
+			if block.Backfilled && len(block.ResultFinalizeBlock.Events) != 1 {
+				t.Fatalf("expected block results of height %d, got %+v", height, block.ResultFinalizeBlock)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for height %d", height)
+		}
+	}
+}
+
+func TestBlockStreamBackfillsGaps(t *testing.T) {
+	query := ctypes.EventQueryNewBlock.String()
+	ws := newFakeWebsocket()
+	pool := NewWebsocketPool([]Websocket{ws}, config.WebsocketPoolConfig{}, zerolog.Nop())
+	defer pool.Close()
+	rpc := &fakeClientPool{flaky: true, failed: make(map[int64]bool)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := NewBlockStream(pool, rpc, zerolog.Nop(), WithStartHeight(8), WithBlockRetryInterval(time.Millisecond))
+	blocks, err := stream.Start(ctx)
+	if err != nil {
+		t.Fatalf("failed to start block stream: %v", err)
+	}
+
+	// The heights before the first live block are fetched first
+	ws.deliver(query, newBlockEvent(10))
+	expectBlocks(t, blocks, []int64{8, 9, 10}, []bool{true, true, false})
+
+	// Blocks missed during a reconnect are backfilled before live blocks resume
+	ws.deliver(query, newBlockEvent(13))
+	ws.deliver(query, newBlockEvent(13))
+	ws.deliver(query, newBlockEvent(14))
+	expectBlocks(t, blocks, []int64{11, 12, 13, 14}, []bool{true, true, false, false})
+
+	if stream.LastHeight() != 14 {
+		t.Fatalf("expected last height 14, got %d", stream.LastHeight())
+	}
+
+	cancel()
+	for range blocks {
+	}
+	if q := ws.queries(); len(q) != 0 {
+		t.Fatalf("expected the subscription to be cancelled, got %v", q)
+	}
+}
+
+func TestBlockStreamCatchesUpWithoutLiveBlocks(t *testing.T) {
+	ws := newFakeWebsocket()
+	pool := NewWebsocketPool([]Websocket{ws}, config.WebsocketPoolConfig{}, zerolog.Nop())
+	defer pool.Close()
+	rpc := &fakeClientPool{latest: 3}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, err := NewBlockStream(pool, rpc, zerolog.Nop(), WithStartHeight(1), WithBlockStallTimeout(50*time.Millisecond)).Start(ctx)
+	if err != nil {
+		t.Fatalf("failed to start block stream: %v", err)
+	}
+	expectBlocks(t, blocks, []int64{1, 2, 3}, []bool{true, true, true})
+}
@@ -56,9 +56,16 @@ type httpClient struct {
 	logger zerolog.Logger
 }
 
-func (c *httpClient) HealthCheck(ctx context.Context) error {
-	_, err := c.Status(ctx)
-	return err
+func (c *httpClient) HealthCheck(ctx context.Context) (pool.NodeStatus, error) {
+	status, err := c.Status(ctx)
+	if err != nil {
+		return pool.NodeStatus{}, err
+	}
+	return pool.NodeStatus{
+		LatestHeight:   status.SyncInfo.LatestBlockHeight,
+		EarliestHeight: status.SyncInfo.EarliestBlockHeight,
+		CatchingUp:     status.SyncInfo.CatchingUp,
+	}, nil
 }
 
 func (c *httpClient) GetEndpointURL() string {
@@ -116,11 +123,11 @@ type clientPool struct {
 
 // NewPool constructs a pool from the provided clients. The pool will panic if
 // no clients are supplied.
-func NewClientPool(clients []Client, logger zerolog.Logger) *clientPool {
+func NewClientPool(clients []Client, cfg config.PoolConfig, logger zerolog.Logger, opts ...pool.ManagerOpt) *clientPool {
 	poolLogger := logger.With().Str("component", "tmrpc_pool").Logger()
 	return &clientPool{
 		logger:      poolLogger,
-		poolManager: pool.NewClientPoolManager(clients, poolLogger),
+		poolManager: pool.NewClientPoolManager(clients, cfg, poolLogger, opts...),
 	}
 }
 
@@ -133,38 +140,39 @@ func (p *clientPool) GetHealthStatus() map[string]any {
 }
 
 func (p *clientPool) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
-	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (*coretypes.ResultBlockResults, error) {
+	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (*coretypes.ResultBlockResults, error) {
 		return c.BlockResults(ctx, height)
 	})
 }
 
 func (p *clientPool) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
-	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (*coretypes.ResultBlock, error) {
+	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (*coretypes.ResultBlock, error) {
 		return c.Block(ctx, height)
 	})
 }
 
 func (p *clientPool) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
-	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (*coretypes.ResultCommit, error) {
+	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (*coretypes.ResultCommit, error) {
 		return c.Commit(ctx, height)
 	})
 }
 
 func (p *clientPool) ABCIQuery(ctx context.Context, path string, data []byte) (*coretypes.ResultABCIQuery, error) {
-	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (*coretypes.ResultABCIQuery, error) {
+	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (*coretypes.ResultABCIQuery, error) {
 		return c.ABCIQuery(ctx, path, data)
 	})
 }
 
 func (p *clientPool) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
-	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (*coretypes.ResultStatus, error) {
+	return pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (*coretypes.ResultStatus, error) {
 		return c.Status(ctx)
 	})
 }
 
 func (p *clientPool) HealthCheck(ctx context.Context) error {
-	_, err := pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(c Client) (struct{}, error) {
-		return struct{}{}, c.HealthCheck(ctx)
+	_, err := pool.ExecuteWithRetry(ctx, p.poolManager, &p.logger, func(ctx context.Context, c Client) (struct{}, error) {
+		_, err := c.HealthCheck(ctx)
+		return struct{}{}, err
 	})
 	return err
 }
@@ -1,33 +1,69 @@
 package tmrpc
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	butils "github.com/brynbellomy/go-utils"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 	rpctypes "github.com/cometbft/cometbft/rpc/core/types"
 	jsonrpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	ctypes "github.com/cometbft/cometbft/types"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/metrics"
 )
 
+// ErrNoWebsockets is returned when subscribing on a pool without websocket endpoints
+var ErrNoWebsockets = errors.New("no websocket endpoints configured")
+
 type Websocket interface {
-	Subscribe(mb *Mailbox, query string)
+	Subscribe(mb *Mailbox, query string) (Subscription, error)
 	Close()
 }
 
+// Subscription is a handle to an event subscription
+type Subscription interface {
+	// Query returns the CometBFT query of the subscription
+	Query() string
+
+	// Err receives errors reported by the node for the subscription, e.g. when it is
+	// rejected because the node's subscription limit was reached. A rejected
+	// subscription is dropped and not renewed on reconnect.
+	Err() <-chan error
+
+	// Unsubscribe stops delivery to the mailbox. The CometBFT unsubscribe call is sent
+	// once no other subscription on the connection uses the same query.
+	Unsubscribe() error
+}
+
 type tmWebsocket struct {
 	url    string
+	opts   *WebsocketOpts
 	logger zerolog.Logger
 
-	conn   *websocket.Conn
-	muConn *sync.Mutex
+	conn      *websocket.Conn
+	connected atomic.Bool
+	lastBlock time.Time   // when the reader last received a block event
+	muConn    *sync.Mutex // held while reading and while (re)connecting
+	muWrite   *sync.Mutex // serialises writes and guards assignments of conn
 
-	subIDNonce int
-	subs       *butils.SyncMap[int, sub]
+	// CometBFT allows one subscription per query and connection, so subscriptions
+	// with the same query share a single remote subscription
+	muSubs     *sync.Mutex
+	rpcIDNonce int
+	queries    map[string]*querySub
+	byRPCID    map[int]*querySub
 
 	chResetConn chan struct{}
 	chStop      chan struct{}
@@ -42,15 +78,130 @@ func NewMailbox(capacity uint64) *Mailbox {
 	return butils.NewMailbox[ctypes.TMEventData](capacity)
 }
 
-type sub struct {
-	id    int
-	event string
+// querySub is a remote subscription and the local subscriptions sharing it
+type querySub struct {
+	rpcID int
+	query string
+	subs  map[*wsSubscription]struct{}
+}
+
+type wsSubscription struct {
+	ws    *tmWebsocket
+	query string
 	mb    *Mailbox
+	errCh chan error
+
+	unsubOnce sync.Once
+	unsubErr  error
+}
+
+var _ Subscription = (*wsSubscription)(nil)
+
+func (s *wsSubscription) Query() string {
+	return s.query
+}
+
+func (s *wsSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *wsSubscription) Unsubscribe() error {
+	s.unsubOnce.Do(func() {
+		s.unsubErr = s.ws.unsubscribe(s)
+	})
+	return s.unsubErr
+}
+
+func (s *wsSubscription) reportErr(err error) {
+	select {
+	case s.errCh <- err:
+	default: // an unread error is already pending
+	}
+}
+
+// WebsocketOpt is a functional option for configuring a websocket
+type WebsocketOpt func(*WebsocketOpts)
+
+// WebsocketOpts holds configuration options for a websocket
+type WebsocketOpts struct {
+	PingInterval      time.Duration // how often to ping the node
+	PongWait          time.Duration // how long the connection may stay silent, pongs included
+	StallTimeout      time.Duration // how long without a new block, while subscribed to blocks; 0 disables
+	MinReconnectDelay time.Duration // first dial retry delay, doubled on every failure
+	MaxReconnectDelay time.Duration
+	OnStateChange     func(url string, state config.ConnectionState, err error)
+}
+
+// Apply applies the provided options to WebsocketOpts
+func (o *WebsocketOpts) Apply(opts ...WebsocketOpt) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// DefaultWebsocketOpts returns default websocket options
+func DefaultWebsocketOpts() *WebsocketOpts {
+	return &WebsocketOpts{
+		PingInterval:      15 * time.Second,
+		PongWait:          30 * time.Second,
+		StallTimeout:      30 * time.Second,
+		MinReconnectDelay: 500 * time.Millisecond,
+		MaxReconnectDelay: 30 * time.Second,
+	}
+}
+
+// WithPingInterval sets how often the node is pinged. The connection is considered
+// dead when nothing, pongs included, is received for two intervals.
+func WithPingInterval(interval time.Duration) WebsocketOpt {
+	return func(opts *WebsocketOpts) {
+		if interval > 0 {
+			opts.PingInterval = interval
+			opts.PongWait = 2 * interval
+		}
+	}
+}
+
+// WithStallTimeout sets how long a websocket subscribed to new blocks may go without
+// one before it reconnects. 0 disables stall detection.
+func WithStallTimeout(timeout time.Duration) WebsocketOpt {
+	return func(opts *WebsocketOpts) {
+		opts.StallTimeout = timeout
+	}
 }
 
-func NewTendermintWebsocket(rpcURL string, logger zerolog.Logger) *tmWebsocket {
+// WithReconnectDelay sets the bounds of the exponential backoff between dial attempts
+func WithReconnectDelay(min, max time.Duration) WebsocketOpt {
+	return func(opts *WebsocketOpts) {
+		opts.MinReconnectDelay = min
+		opts.MaxReconnectDelay = max
+	}
+}
+
+// WithStateCallback sets a function called whenever the connection state changes
+func WithStateCallback(fn func(url string, state config.ConnectionState, err error)) WebsocketOpt {
+	return func(opts *WebsocketOpts) {
+		opts.OnStateChange = fn
+	}
+}
+
+// blockQueries are the queries whose events show that the node is producing blocks
+var blockQueries = map[string]struct{}{
+	ctypes.EventQueryNewBlock.String():       {},
+	ctypes.EventQueryNewBlockHeader.String(): {},
+	ctypes.EventQueryNewBlockEvents.String(): {},
+}
+
+// errStalled is reported when a connection subscribed to blocks receives none
+var errStalled = errors.New("no new block within the stall timeout")
+
+// NewTendermintWebsocket connects to the node's websocket endpoint, blocking until the
+// first connection is established
+func NewTendermintWebsocket(rpcURL string, logger zerolog.Logger, opts ...WebsocketOpt) *tmWebsocket {
 	cometLogger := logger.With().Str("component", "websocket").Logger()
 
+	o := DefaultWebsocketOpts()
+	o.Apply(opts...)
+
 	u, err := url.Parse(rpcURL)
 	if err != nil {
 		cometLogger.Fatal().Err(err).Msg("bad url")
@@ -65,10 +216,13 @@ func NewTendermintWebsocket(rpcURL string, logger zerolog.Logger) *tmWebsocket {
 
 	ws := &tmWebsocket{
 		url:         url,
+		opts:        o,
 		logger:      cometLogger,
 		muConn:      &sync.Mutex{},
-		subIDNonce:  0,
-		subs:        butils.NewSyncMap[int, sub](),
+		muWrite:     &sync.Mutex{},
+		muSubs:      &sync.Mutex{},
+		queries:     make(map[string]*querySub),
+		byRPCID:     make(map[int]*querySub),
 		chResetConn: make(chan struct{}, 1),
 		chStop:      make(chan struct{}),
 		wgDone:      &sync.WaitGroup{},
@@ -84,6 +238,14 @@ func NewTendermintWebsocket(rpcURL string, logger zerolog.Logger) *tmWebsocket {
 
 func (ws *tmWebsocket) Close() {
 	close(ws.chStop)
+
+	// Unblock a pending read; the connection manager then sees chStop and exits
+	ws.muWrite.Lock()
+	if ws.conn != nil {
+		_ = ws.conn.Close()
+	}
+	ws.muWrite.Unlock()
+
 	ws.wgDone.Wait()
 }
 
@@ -92,7 +254,7 @@ func (ws *tmWebsocket) connectionManager() {
 	defer ws.terminateConnection()
 
 	for {
-		ws.readConnection()
+		err := ws.readConnection()
 
 		select {
 		case <-ws.chStop:
@@ -100,31 +262,77 @@ func (ws *tmWebsocket) connectionManager() {
 		default:
 		}
 
+		ws.logger.Warn().Err(err).Msg("websocket connection lost, reconnecting...")
+		metrics.ObserveWebsocketDisconnect(ws.url, disconnectReason(err))
+		ws.setState(config.ConnectionStateDisconnected, err)
+
 		ws.resetConnection()
 	}
 }
 
-func (ws *tmWebsocket) readConnection() {
+func disconnectReason(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errStalled):
+		return "stalled"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "keepalive_timeout"
+	case websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway):
+		return "closed_by_peer"
+	default:
+		return "read_error"
+	}
+}
+
+// Connected reports whether the websocket is currently connected
+func (ws *tmWebsocket) Connected() bool {
+	return ws.connected.Load()
+}
+
+func (ws *tmWebsocket) setState(state config.ConnectionState, err error) {
+	ws.connected.Store(state == config.ConnectionStateConnected)
+	metrics.SetWebsocketConnected(ws.url, state == config.ConnectionStateConnected)
+	if ws.opts.OnStateChange != nil {
+		ws.opts.OnStateChange(ws.url, state, err)
+	}
+}
+
+// readConnection reads events until the connection fails and returns the cause
+func (ws *tmWebsocket) readConnection() (err error) {
 	defer func() {
 		if perr := recover(); perr != nil {
 			ws.logger.Error().Msgf("recovered from panic: %v", perr)
+			err = fmt.Errorf("panic while reading: %v", perr)
 		}
 	}()
 
+	chDone := make(chan struct{})
+	defer close(chDone)
+	go ws.keepalive(ws.conn, chDone)
+
 	for {
 		select {
 		case <-ws.chStop:
-			return
+			return nil
 		default:
 		}
 
+		ws.refreshReadDeadline()
+
 		var resp jsonrpctypes.RPCResponse
-		err := ws.read(&resp)
-		if err != nil {
-			ws.logger.Error().Err(err).Msg("could not read websocket msg")
-			continue
+		if err := ws.read(&resp); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				ws.logger.Error().Err(err).Msg("could not decode websocket msg")
+				continue
+			}
+			if ws.stalled() {
+				return errStalled
+			}
+			return err
 		} else if resp.Error != nil {
-			ws.logger.Error().Err(*resp.Error).Msg("rpc received error")
+			ws.handleRPCError(resp)
 			continue
 		}
 
@@ -133,20 +341,117 @@ func (ws *tmWebsocket) readConnection() {
 		}
 
 		var event rpctypes.ResultEvent
-		err = cmtjson.Unmarshal(resp.Result, &event)
+		err := cmtjson.Unmarshal(resp.Result, &event)
 		if err != nil {
 			ws.logger.Error().Err(err).Msg("could not unmarshal websocket msg")
 			continue
 		}
 
-		subID := int(resp.ID.(jsonrpctypes.JSONRPCIntID))
-		sub, ok := ws.subs.Get(subID)
-		if !ok {
-			ws.logger.Error().Msgf("received event for unknown subscription ID %d", subID)
+		switch event.Data.(type) {
+		case ctypes.EventDataNewBlock, ctypes.EventDataNewBlockHeader, ctypes.EventDataNewBlockEvents:
+			ws.lastBlock = time.Now()
+		}
+
+		subID, _ := resp.ID.(jsonrpctypes.JSONRPCIntID)
+		mailboxes := ws.mailboxes(int(subID))
+		if len(mailboxes) == 0 {
+			ws.logger.Debug().Msgf("received event for unknown subscription ID %v", resp.ID)
 			continue
 		}
+		for _, mb := range mailboxes {
+			mb.Deliver(event.Data)
+		}
+	}
+}
+
+// keepalive pings the node until the connection is done. Pongs extend the read
+// deadline, so a half-open connection fails the pending read instead of hanging.
+func (ws *tmWebsocket) keepalive(conn *websocket.Conn, chDone <-chan struct{}) {
+	ticker := time.NewTicker(ws.opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-chDone:
+			return
+		case <-ws.chStop:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(ws.opts.PingInterval)); err != nil {
+				ws.logger.Debug().Err(err).Msg("failed to send ping")
+			}
+		}
+	}
+}
+
+// refreshReadDeadline lets the connection stay silent for PongWait, or until the
+// stall timeout if it is subscribed to blocks. It is only called by the reader.
+func (ws *tmWebsocket) refreshReadDeadline() {
+	deadline := time.Now().Add(ws.opts.PongWait)
+	if ws.opts.StallTimeout > 0 {
+		if !ws.hasBlockSubscription() {
+			// Only time spent subscribed to blocks counts towards a stall
+			ws.lastBlock = time.Now()
+		} else if stall := ws.lastBlock.Add(ws.opts.StallTimeout); stall.Before(deadline) {
+			deadline = stall
+		}
+	}
+	_ = ws.conn.SetReadDeadline(deadline)
+}
+
+func (ws *tmWebsocket) stalled() bool {
+	return ws.opts.StallTimeout > 0 && ws.hasBlockSubscription() && time.Since(ws.lastBlock) >= ws.opts.StallTimeout
+}
+
+func (ws *tmWebsocket) hasBlockSubscription() bool {
+	ws.muSubs.Lock()
+	defer ws.muSubs.Unlock()
+	for query := range ws.queries {
+		if _, ok := blockQueries[query]; ok {
+			return true
+		}
+	}
+	return false
+}
 
-		sub.mb.Deliver(event.Data)
+// mailboxes returns the mailboxes of the subscriptions sharing the remote subscription rpcID
+func (ws *tmWebsocket) mailboxes(rpcID int) []*Mailbox {
+	ws.muSubs.Lock()
+	defer ws.muSubs.Unlock()
+
+	qs, ok := ws.byRPCID[rpcID]
+	if !ok {
+		return nil
+	}
+	mailboxes := make([]*Mailbox, 0, len(qs.subs))
+	for s := range qs.subs {
+		mailboxes = append(mailboxes, s.mb)
+	}
+	return mailboxes
+}
+
+// handleRPCError reports an error response to the subscriptions of the request it
+// answers. A subscription the node rejected is dropped.
+func (ws *tmWebsocket) handleRPCError(resp jsonrpctypes.RPCResponse) {
+	rpcID, _ := resp.ID.(jsonrpctypes.JSONRPCIntID)
+
+	ws.muSubs.Lock()
+	qs, ok := ws.byRPCID[int(rpcID)]
+	if ok {
+		delete(ws.byRPCID, qs.rpcID)
+		delete(ws.queries, qs.query)
+	}
+	ws.muSubs.Unlock()
+
+	if !ok {
+		ws.logger.Error().Err(*resp.Error).Msgf("rpc received error for request ID %v", resp.ID)
+		return
+	}
+
+	ws.logger.Error().Err(*resp.Error).Str("query", qs.query).Msg("subscription rejected")
+	err := fmt.Errorf("subscription %q rejected: %w", qs.query, resp.Error)
+	for s := range qs.subs {
+		s.reportErr(err)
 	}
 }
 
@@ -154,7 +459,7 @@ func (ws *tmWebsocket) read(resp any) error {
 	ws.muConn.Lock()
 	defer ws.muConn.Unlock()
 
-	return ws.conn.ReadJSON(&resp)
+	return ws.conn.ReadJSON(resp)
 }
 
 func (ws *tmWebsocket) resetConnection() {
@@ -163,42 +468,66 @@ func (ws *tmWebsocket) resetConnection() {
 
 	// close connection if active
 	if ws.conn != nil {
-		ws.logger.Info().Msg("websocket connection closed, reconnecting...")
-		err := ws.conn.Close()
-		if err != nil {
-			ws.logger.Error().Err(err).Msg("error closing websocket connection")
-		} else {
-			ws.logger.Info().Msg("websocket connection closed, reconnecting...")
+		if err := ws.conn.Close(); err != nil {
+			ws.logger.Debug().Err(err).Msg("error closing websocket connection")
 		}
-		ws.conn = nil
+		ws.setConn(nil)
 	}
 
-	// wait for a new connection
+	ws.setState(config.ConnectionStateConnecting, nil)
+
+	// wait for a new connection, backing off exponentially with jitter so that
+	// clients don't reconnect in lockstep after a node restarts
+	delay := ws.opts.MinReconnectDelay
 	for {
 		conn, _, err := websocket.DefaultDialer.Dial(ws.url, nil)
 		if err != nil {
-			ws.logger.Error().Err(err).Msg("websocket dial failed")
+			retryIn := delay/2 + rand.N(delay/2+1)
+			ws.logger.Error().Err(err).Dur("retry_in", retryIn).Msg("websocket dial failed")
+			metrics.ObserveWebsocketDialFailure(ws.url, retryIn)
 			select {
 			case <-ws.chStop:
 				return
-			case <-time.After(5 * time.Second):
+			case <-time.After(retryIn):
 			}
+			delay = min(2*delay, ws.opts.MaxReconnectDelay)
 			continue
 		}
 
-		ws.conn = conn
+		conn.SetPongHandler(func(string) error {
+			ws.refreshReadDeadline()
+			return nil
+		})
+		ws.setConn(conn)
 		break
 	}
+	ws.lastBlock = time.Now()
 
 	ws.logger.Info().Str("url", ws.conn.RemoteAddr().String()).Msg("connected to comet rpc websocket")
+	ws.setState(config.ConnectionStateConnected, nil)
 
 	// resubscribe to everything
-	for _, sub := range ws.subs.Iter() {
-		ws.logger.Debug().Msgf("subscribing to subscription ID %d with event %v", sub.id, sub.event)
-		ws.sendSubscribeMsg(sub)
+	ws.muSubs.Lock()
+	queries := make([]*querySub, 0, len(ws.queries))
+	for _, qs := range ws.queries {
+		queries = append(queries, qs)
+	}
+	ws.muSubs.Unlock()
+
+	for _, qs := range queries {
+		ws.logger.Debug().Msgf("subscribing to subscription ID %d with event %v", qs.rpcID, qs.query)
+		if err := ws.sendSubscribeMsg(qs.rpcID, qs.query); err != nil {
+			ws.logger.Error().Err(err).Msg("could not write subscription message")
+		}
 	}
 }
 
+func (ws *tmWebsocket) setConn(conn *websocket.Conn) {
+	ws.muWrite.Lock()
+	defer ws.muWrite.Unlock()
+	ws.conn = conn
+}
+
 // terminateConnection closes the websocket connection and cleans up resources permanently.
 func (ws *tmWebsocket) terminateConnection() {
 	ws.muConn.Lock()
@@ -207,70 +536,115 @@ func (ws *tmWebsocket) terminateConnection() {
 	if ws.conn != nil {
 		err := ws.conn.Close()
 		if err != nil {
-			ws.logger.Error().Err(err).Msg("error closing websocket connection, giving up")
+			ws.logger.Debug().Err(err).Msg("error closing websocket connection")
 		} else {
 			ws.logger.Info().Msg("websocket connection closed")
 		}
-		ws.conn = nil
+		ws.setConn(nil)
 	}
+	ws.setState(config.ConnectionStateClosed, nil)
 }
 
-func (ws *tmWebsocket) Subscribe(mb *Mailbox, query string) {
-	ws.subIDNonce++
+// Subscribe delivers the events matching the CometBFT query to the mailbox until the
+// returned subscription is unsubscribed. Subscriptions are renewed on reconnect.
+func (ws *tmWebsocket) Subscribe(mb *Mailbox, query string) (Subscription, error) {
+	if _, err := cmtquery.New(query); err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", query, err)
+	}
 
-	sub := sub{
-		id:    ws.subIDNonce,
-		event: query,
+	s := &wsSubscription{
+		ws:    ws,
+		query: query,
 		mb:    mb,
+		errCh: make(chan error, 1),
 	}
 
-	ws.subs.Set(sub.id, sub)
-	ws.sendSubscribeMsg(sub)
-}
-
-func (ws *tmWebsocket) sendSubscribeMsg(sub sub) {
-	subMsg := map[string]any{
-		"jsonrpc": "2.0",
-		"method":  "subscribe",
-		"id":      sub.id,
-		"params": map[string]any{
-			"query": sub.event,
-		},
+	ws.muSubs.Lock()
+	qs, exists := ws.queries[query]
+	if !exists {
+		qs = &querySub{
+			rpcID: ws.nextRPCIDLocked(),
+			query: query,
+			subs:  make(map[*wsSubscription]struct{}),
+		}
+		ws.queries[query] = qs
+		ws.byRPCID[qs.rpcID] = qs
 	}
+	qs.subs[s] = struct{}{}
+	ws.muSubs.Unlock()
 
-	ws.logger.Info().Msg("subscribing to " + sub.event)
-
-	err := ws.conn.WriteJSON(subMsg)
-	if err != nil {
-		ws.logger.Error().Err(err).Msg("could not write subscription message")
+	if !exists {
+		if err := ws.sendSubscribeMsg(qs.rpcID, query); err != nil {
+			// The subscription is renewed once the connection is re-established
+			ws.logger.Warn().Err(err).Str("query", query).Msg("could not write subscription message")
+		}
 	}
+	return s, nil
 }
 
-type WebsocketPool interface {
-	Subscribe(mb *Mailbox, query string)
-	Close()
+func (ws *tmWebsocket) unsubscribe(s *wsSubscription) error {
+	ws.muSubs.Lock()
+	qs, ok := ws.queries[s.query]
+	if !ok {
+		ws.muSubs.Unlock()
+		return nil // rejected by the node, nothing to cancel
+	}
+	if _, ok := qs.subs[s]; !ok {
+		ws.muSubs.Unlock()
+		return nil
+	}
+	delete(qs.subs, s)
+	if len(qs.subs) > 0 {
+		ws.muSubs.Unlock()
+		return nil
+	}
+	delete(ws.queries, qs.query)
+	delete(ws.byRPCID, qs.rpcID)
+	rpcID := ws.nextRPCIDLocked()
+	ws.muSubs.Unlock()
+
+	if err := ws.sendUnsubscribeMsg(rpcID, s.query); err != nil {
+		// Not renewed on reconnect, so the node drops it with the connection
+		return fmt.Errorf("failed to unsubscribe from %q: %w", s.query, err)
+	}
+	return nil
 }
 
-type websocketPool struct {
-	websockets []Websocket
+func (ws *tmWebsocket) nextRPCIDLocked() int {
+	ws.rpcIDNonce++
+	return ws.rpcIDNonce
 }
 
-var _ WebsocketPool = (*websocketPool)(nil)
-
-func NewWebsocketPool(websockets []Websocket) *websocketPool {
-	return &websocketPool{
-		websockets: websockets,
-	}
+func (ws *tmWebsocket) sendSubscribeMsg(rpcID int, query string) error {
+	ws.logger.Info().Msg("subscribing to " + query)
+	return ws.write(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "subscribe",
+		"id":      rpcID,
+		"params": map[string]any{
+			"query": query,
+		},
+	})
 }
 
-func (p *websocketPool) Close() {
-	for _, ws := range p.websockets {
-		ws.Close()
-	}
+func (ws *tmWebsocket) sendUnsubscribeMsg(rpcID int, query string) error {
+	ws.logger.Info().Msg("unsubscribing from " + query)
+	return ws.write(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "unsubscribe",
+		"id":      rpcID,
+		"params": map[string]any{
+			"query": query,
+		},
+	})
 }
 
-func (p *websocketPool) Subscribe(mb *Mailbox, query string) {
-	for _, ws := range p.websockets {
-		ws.Subscribe(mb, query)
+func (ws *tmWebsocket) write(msg any) error {
+	ws.muWrite.Lock()
+	defer ws.muWrite.Unlock()
+
+	if ws.conn == nil {
+		return fmt.Errorf("websocket is not connected")
 	}
+	return ws.conn.WriteJSON(msg)
 }
@@ -0,0 +1,468 @@
+package tmrpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+type WebsocketPool interface {
+	Subscribe(mb *Mailbox, query string) (Subscription, error)
+	Close()
+}
+
+// poolMailboxCapacity is the capacity of the mailboxes events pass through before
+// being deduplicated into the subscriber's mailbox
+const poolMailboxCapacity = 1000
+
+type websocketPool struct {
+	websockets []Websocket
+	cfg        config.WebsocketPoolConfig
+	logger     zerolog.Logger
+
+	// primary-standby mode: only websockets[primary] is subscribed, and a
+	// NewBlockHeader subscription on it tells whether it is still live
+	mu          sync.Mutex
+	primary     int
+	standbys    map[*standbySubscription]struct{}
+	heartbeat   Subscription
+	hbMailbox   *Mailbox
+	monitorOnce sync.Once
+
+	chStop    chan struct{}
+	closeOnce sync.Once
+	wgDone    sync.WaitGroup
+}
+
+var _ WebsocketPool = (*websocketPool)(nil)
+
+// NewWebsocketPool creates a pool over the given websockets. Zero values in cfg are
+// replaced by the defaults of config.DefaultWebsocketPoolConfig.
+func NewWebsocketPool(websockets []Websocket, cfg config.WebsocketPoolConfig, logger zerolog.Logger) *websocketPool {
+	defaults := config.DefaultWebsocketPoolConfig()
+	switch cfg.Mode {
+	case config.WebsocketPoolDedup, config.WebsocketPoolFanout, config.WebsocketPoolPrimaryStandby:
+	case "":
+		cfg.Mode = defaults.Mode
+	default:
+		logger.Warn().Str("mode", string(cfg.Mode)).Msgf("unknown websocket pool mode, using %s", defaults.Mode)
+		cfg.Mode = defaults.Mode
+	}
+	if cfg.StallTimeout <= 0 {
+		cfg.StallTimeout = defaults.StallTimeout
+	}
+	if cfg.DedupWindow <= 0 {
+		cfg.DedupWindow = defaults.DedupWindow
+	}
+
+	return &websocketPool{
+		websockets: websockets,
+		cfg:        cfg,
+		logger:     logger.With().Str("component", "websocket_pool").Logger(),
+		standbys:   make(map[*standbySubscription]struct{}),
+		hbMailbox:  NewMailbox(10),
+		chStop:     make(chan struct{}),
+	}
+}
+
+func (p *websocketPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.chStop)
+		p.wgDone.Wait()
+		for _, ws := range p.websockets {
+			ws.Close()
+		}
+	})
+}
+
+// Subscribe subscribes the mailbox according to the pool's mode:
+//
+//   - dedup: on every websocket, delivering each event once
+//   - fanout: on every websocket, delivering every copy of an event
+//   - primary-standby: on the primary websocket only, moving to a standby when the
+//     primary stalls
+//
+// The returned subscription reports the errors of the underlying subscriptions and
+// cancels all of them.
+func (p *websocketPool) Subscribe(mb *Mailbox, query string) (Subscription, error) {
+	if len(p.websockets) == 0 {
+		return nil, ErrNoWebsockets
+	}
+
+	switch p.cfg.Mode {
+	case config.WebsocketPoolFanout:
+		return p.subscribeAll(mb, query)
+	case config.WebsocketPoolPrimaryStandby:
+		return p.subscribePrimary(mb, query)
+	default:
+		in := NewMailbox(poolMailboxCapacity)
+		m, err := p.subscribeAll(in, query)
+		if err != nil {
+			return nil, err
+		}
+		go forwardUnique(in, mb, newEventDeduper(p.cfg.DedupWindow), m.chStop, p.chStop)
+		return m, nil
+	}
+}
+
+func (p *websocketPool) subscribeAll(mb *Mailbox, query string) (*multiSubscription, error) {
+	subs := make([]Subscription, 0, len(p.websockets))
+	for _, ws := range p.websockets {
+		sub, err := ws.Subscribe(mb, query)
+		if err != nil {
+			for _, s := range subs {
+				_ = s.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return newMultiSubscription(query, subs, p.chStop), nil
+}
+
+func (p *websocketPool) subscribePrimary(mb *Mailbox, query string) (Subscription, error) {
+	if len(p.websockets) > 1 {
+		p.monitorOnce.Do(p.startMonitor)
+	}
+
+	s := &standbySubscription{
+		pool:   p,
+		query:  query,
+		in:     NewMailbox(poolMailboxCapacity),
+		errCh:  make(chan error, 1),
+		chStop: make(chan struct{}),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := s.switchToLocked(p.websockets[p.primary]); err != nil {
+		return nil, err
+	}
+	p.standbys[s] = struct{}{}
+
+	// Events delivered by the old and the new primary around a promotion overlap
+	go forwardUnique(s.in, mb, newEventDeduper(p.cfg.DedupWindow), s.chStop, p.chStop)
+	return s, nil
+}
+
+var heartbeatQuery = ctypes.EventQueryNewBlockHeader.String()
+
+func (p *websocketPool) startMonitor() {
+	p.mu.Lock()
+	hb, err := p.websockets[p.primary].Subscribe(p.hbMailbox, heartbeatQuery)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to subscribe to block headers of the primary websocket")
+	}
+	p.heartbeat = hb
+	p.mu.Unlock()
+
+	p.wgDone.Add(1)
+	go p.monitorPrimary()
+}
+
+// monitorPrimary promotes the next websocket whenever the primary goes StallTimeout
+// without delivering a block header
+func (p *websocketPool) monitorPrimary() {
+	defer p.wgDone.Done()
+
+	ticker := time.NewTicker(p.cfg.StallTimeout / 4)
+	defer ticker.Stop()
+
+	lastBlock := time.Now()
+	for {
+		select {
+		case <-p.chStop:
+			return
+		case <-p.hbMailbox.Notify():
+			p.hbMailbox.RetrieveAll()
+			lastBlock = time.Now()
+		case <-ticker.C:
+			if time.Since(lastBlock) > p.cfg.StallTimeout {
+				p.promoteStandby()
+				lastBlock = time.Now()
+			}
+		}
+	}
+}
+
+// connectionReporter is implemented by websockets that know whether they are
+// connected; the others are assumed to be
+type connectionReporter interface {
+	Connected() bool
+}
+
+func isConnected(ws Websocket) bool {
+	r, ok := ws.(connectionReporter)
+	return !ok || r.Connected()
+}
+
+// promoteStandby makes the next connected websocket the primary. The primary is
+// kept if no standby is connected.
+func (p *websocketPool) promoteStandby() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prev := p.primary
+	next := -1
+	for i := 1; i < len(p.websockets); i++ {
+		if candidate := (prev + i) % len(p.websockets); isConnected(p.websockets[candidate]) {
+			next = candidate
+			break
+		}
+	}
+	if next < 0 {
+		p.logger.Warn().
+			Int("primary", prev).
+			Dur("stall_timeout", p.cfg.StallTimeout).
+			Msg("primary websocket stalled, but no standby is connected")
+		return
+	}
+	p.primary = next
+	ws := p.websockets[p.primary]
+
+	p.logger.Warn().
+		Int("from", prev).
+		Int("to", p.primary).
+		Dur("stall_timeout", p.cfg.StallTimeout).
+		Msg("primary websocket stalled, promoting standby")
+
+	hb, err := ws.Subscribe(p.hbMailbox, heartbeatQuery)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to subscribe to block headers of the promoted websocket")
+	} else {
+		if p.heartbeat != nil {
+			if err := p.heartbeat.Unsubscribe(); err != nil {
+				p.logger.Debug().Err(err).Msg("failed to unsubscribe from the stalled websocket")
+			}
+		}
+		p.heartbeat = hb
+	}
+
+	for s := range p.standbys {
+		if err := s.switchToLocked(ws); err != nil {
+			s.reportErr(fmt.Errorf("failed to move subscription %q to the promoted websocket: %w", s.query, err))
+		}
+	}
+}
+
+// multiSubscription combines the subscriptions of the same query on several websockets
+type multiSubscription struct {
+	query string
+	subs  []Subscription
+	errCh chan error
+
+	chStop    chan struct{}
+	unsubOnce sync.Once
+	unsubErr  error
+}
+
+// newMultiSubscription combines subs; their errors are forwarded until it is
+// cancelled or chPoolStop is closed
+func newMultiSubscription(query string, subs []Subscription, chPoolStop <-chan struct{}) *multiSubscription {
+	m := &multiSubscription{
+		query:  query,
+		subs:   subs,
+		errCh:  make(chan error, len(subs)),
+		chStop: make(chan struct{}),
+	}
+	for _, sub := range subs {
+		go forwardErrors(sub, m.errCh, m.chStop, chPoolStop)
+	}
+	return m
+}
+
+func (m *multiSubscription) Query() string {
+	return m.query
+}
+
+func (m *multiSubscription) Err() <-chan error {
+	return m.errCh
+}
+
+func (m *multiSubscription) Unsubscribe() error {
+	m.unsubOnce.Do(func() {
+		close(m.chStop)
+		var errs []error
+		for _, sub := range m.subs {
+			errs = append(errs, sub.Unsubscribe())
+		}
+		m.unsubErr = errors.Join(errs...)
+	})
+	return m.unsubErr
+}
+
+// standbySubscription is a primary-standby subscription. It is subscribed on the
+// current primary only and moved to the promoted websocket by the pool.
+type standbySubscription struct {
+	pool  *websocketPool
+	query string
+	in    *Mailbox
+	errCh chan error
+
+	// guarded by pool.mu
+	current   Subscription
+	chCurrent chan struct{} // stops forwarding errors of current
+
+	chStop    chan struct{}
+	unsubOnce sync.Once
+	unsubErr  error
+}
+
+var _ Subscription = (*standbySubscription)(nil)
+
+func (s *standbySubscription) Query() string {
+	return s.query
+}
+
+func (s *standbySubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *standbySubscription) Unsubscribe() error {
+	s.unsubOnce.Do(func() {
+		s.pool.mu.Lock()
+		defer s.pool.mu.Unlock()
+
+		delete(s.pool.standbys, s)
+		close(s.chStop)
+		close(s.chCurrent)
+		s.unsubErr = s.current.Unsubscribe()
+	})
+	return s.unsubErr
+}
+
+// switchToLocked subscribes on ws before cancelling the current subscription so that
+// no event is missed in between
+func (s *standbySubscription) switchToLocked(ws Websocket) error {
+	sub, err := ws.Subscribe(s.in, s.query)
+	if err != nil {
+		return err
+	}
+	chCurrent := make(chan struct{})
+	go forwardErrors(sub, s.errCh, chCurrent, s.pool.chStop)
+
+	prev, chPrev := s.current, s.chCurrent
+	s.current, s.chCurrent = sub, chCurrent
+	if prev != nil {
+		close(chPrev)
+		if err := prev.Unsubscribe(); err != nil {
+			s.pool.logger.Debug().Err(err).Str("query", s.query).Msg("failed to unsubscribe from the stalled websocket")
+		}
+	}
+	return nil
+}
+
+func (s *standbySubscription) reportErr(err error) {
+	select {
+	case s.errCh <- err:
+	default: // an unread error is already pending
+	}
+}
+
+// forwardErrors moves the errors of sub to errCh until chStop or chPoolStop is closed
+func forwardErrors(sub Subscription, errCh chan<- error, chStop, chPoolStop <-chan struct{}) {
+	for {
+		select {
+		case <-chStop:
+			return
+		case <-chPoolStop:
+			return
+		case err := <-sub.Err():
+			select {
+			case errCh <- err:
+			default: // the caller is not reading errors; drop rather than block
+			}
+		}
+	}
+}
+
+// forwardUnique moves events from in to out, dropping the ones already delivered,
+// until chStop or chPoolStop is closed
+func forwardUnique(in, out *Mailbox, dedup *eventDeduper, chStop, chPoolStop <-chan struct{}) {
+	for {
+		select {
+		case <-chStop:
+			return
+		case <-chPoolStop:
+			return
+		case <-in.Notify():
+			for _, data := range in.RetrieveAll() {
+				if dedup.firstSeen(data) {
+					out.Deliver(data)
+				}
+			}
+		}
+	}
+}
+
+type eventKey struct {
+	height    int64
+	eventType string
+	txHash    string
+}
+
+// eventDeduper remembers the events delivered within the last window heights. It is
+// not safe for concurrent use.
+type eventDeduper struct {
+	window    int64
+	maxHeight int64
+	seen      map[eventKey]struct{}
+}
+
+func newEventDeduper(window int64) *eventDeduper {
+	return &eventDeduper{
+		window: window,
+		seen:   make(map[eventKey]struct{}),
+	}
+}
+
+// firstSeen reports whether data has not been seen before. Events older than the
+// window are treated as already seen; events without a height are always new.
+func (d *eventDeduper) firstSeen(data ctypes.TMEventData) bool {
+	key, ok := eventKeyOf(data)
+	if !ok {
+		return true
+	}
+	if key.height <= d.maxHeight-d.window {
+		return false
+	}
+	if _, seen := d.seen[key]; seen {
+		return false
+	}
+	d.seen[key] = struct{}{}
+
+	if key.height > d.maxHeight {
+		d.maxHeight = key.height
+		for k := range d.seen {
+			if k.height <= d.maxHeight-d.window {
+				delete(d.seen, k)
+			}
+		}
+	}
+	return true
+}
+
+func eventKeyOf(data ctypes.TMEventData) (eventKey, bool) {
+	switch d := data.(type) {
+	case ctypes.EventDataTx:
+		return eventKey{height: d.Height, eventType: ctypes.EventTx, txHash: string(ctypes.Tx(d.Tx).Hash())}, true
+	case ctypes.EventDataNewBlock:
+		if d.Block == nil {
+			return eventKey{}, false
+		}
+		return eventKey{height: d.Block.Height, eventType: ctypes.EventNewBlock}, true
+	case ctypes.EventDataNewBlockHeader:
+		return eventKey{height: d.Header.Height, eventType: ctypes.EventNewBlockHeader}, true
+	case ctypes.EventDataNewBlockEvents:
+		return eventKey{height: d.Height, eventType: ctypes.EventNewBlockEvents}, true
+	default:
+		return eventKey{}, false
+	}
+}
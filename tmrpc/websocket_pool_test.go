@@ -0,0 +1,260 @@
+package tmrpc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+// fakeWebsocket delivers events to its subscribers on demand
+type fakeWebsocket struct {
+	mu           sync.Mutex
+	subs         map[*fakeWsSubscription]struct{}
+	disconnected atomic.Bool
+}
+
+func newFakeWebsocket() *fakeWebsocket {
+	return &fakeWebsocket{subs: make(map[*fakeWsSubscription]struct{})}
+}
+
+type fakeWsSubscription struct {
+	ws    *fakeWebsocket
+	query string
+	mb    *Mailbox
+}
+
+func (s *fakeWsSubscription) Query() string     { return s.query }
+func (s *fakeWsSubscription) Err() <-chan error { return nil }
+
+func (s *fakeWsSubscription) Unsubscribe() error {
+	s.ws.mu.Lock()
+	defer s.ws.mu.Unlock()
+	delete(s.ws.subs, s)
+	return nil
+}
+
+func (ws *fakeWebsocket) Subscribe(mb *Mailbox, query string) (Subscription, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s := &fakeWsSubscription{ws: ws, query: query, mb: mb}
+	ws.subs[s] = struct{}{}
+	return s, nil
+}
+
+func (ws *fakeWebsocket) Close() {}
+
+func (ws *fakeWebsocket) Connected() bool { return !ws.disconnected.Load() }
+
+func (ws *fakeWebsocket) deliver(query string, data ctypes.TMEventData) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for s := range ws.subs {
+		if s.query == query {
+			s.mb.Deliver(data)
+		}
+	}
+}
+
+func (ws *fakeWebsocket) queries() map[string]int {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	queries := make(map[string]int)
+	for s := range ws.subs {
+		queries[s.query]++
+	}
+	return queries
+}
+
+func receiveAll(mb *Mailbox, wait time.Duration) []ctypes.TMEventData {
+	var events []ctypes.TMEventData
+	timeout := time.After(wait)
+	for {
+		select {
+		case <-mb.Notify():
+			events = append(events, mb.RetrieveAll()...)
+		case <-timeout:
+			return events
+		}
+	}
+}
+
+func txEvent(height int64, tx string) ctypes.EventDataTx {
+	return ctypes.EventDataTx{TxResult: abcitypes.TxResult{Height: height, Tx: []byte(tx)}}
+}
+
+func TestWebsocketPoolWithoutWebsockets(t *testing.T) {
+	if _, err := NewWebsocketPool(nil, config.WebsocketPoolConfig{}, zerolog.Nop()).Subscribe(NewMailbox(1), "tm.event = 'Tx'"); err != ErrNoWebsockets {
+		t.Fatalf("expected ErrNoWebsockets, got %v", err)
+	}
+}
+
+func TestWebsocketPoolDedup(t *testing.T) {
+	txQuery := ctypes.EventQueryTx.String()
+	websockets := []*fakeWebsocket{newFakeWebsocket(), newFakeWebsocket(), newFakeWebsocket()}
+	pool := NewWebsocketPool([]Websocket{websockets[0], websockets[1], websockets[2]}, config.WebsocketPoolConfig{DedupWindow: 10}, zerolog.Nop())
+	defer pool.Close()
+
+	mb := NewMailbox(100)
+	sub, err := pool.Subscribe(mb, txQuery)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	for _, ws := range websockets {
+		ws.deliver(txQuery, txEvent(5, "a"))
+		ws.deliver(txQuery, txEvent(5, "b"))
+	}
+	websockets[2].deliver(txQuery, txEvent(6, "a"))
+	websockets[0].deliver(txQuery, txEvent(20, "c"))
+	// Below the window of the latest height: treated as already delivered
+	websockets[1].deliver(txQuery, txEvent(6, "a"))
+
+	events := receiveAll(mb, 200*time.Millisecond)
+	if len(events) != 4 {
+		t.Fatalf("expected 4 unique events, got %d: %+v", len(events), events)
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("failed to unsubscribe: %v", err)
+	}
+	for i, ws := range websockets {
+		if queries := ws.queries(); len(queries) != 0 {
+			t.Fatalf("websocket %d still subscribed to %v", i, queries)
+		}
+	}
+}
+
+func TestWebsocketPoolFanout(t *testing.T) {
+	txQuery := ctypes.EventQueryTx.String()
+	websockets := []*fakeWebsocket{newFakeWebsocket(), newFakeWebsocket()}
+	pool := NewWebsocketPool([]Websocket{websockets[0], websockets[1]}, config.WebsocketPoolConfig{Mode: config.WebsocketPoolFanout}, zerolog.Nop())
+	defer pool.Close()
+
+	mb := NewMailbox(100)
+	if _, err := pool.Subscribe(mb, txQuery); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	for _, ws := range websockets {
+		ws.deliver(txQuery, txEvent(5, "a"))
+	}
+	if events := receiveAll(mb, 100*time.Millisecond); len(events) != 2 {
+		t.Fatalf("expected every copy to be delivered, got %d", len(events))
+	}
+}
+
+func TestWebsocketPoolPrimaryStandby(t *testing.T) {
+	txQuery := ctypes.EventQueryTx.String()
+	primary, standby := newFakeWebsocket(), newFakeWebsocket()
+	pool := NewWebsocketPool([]Websocket{primary, standby}, config.WebsocketPoolConfig{
+		Mode:         config.WebsocketPoolPrimaryStandby,
+		StallTimeout: 200 * time.Millisecond,
+	}, zerolog.Nop())
+	defer pool.Close()
+
+	mb := NewMailbox(100)
+	sub, err := pool.Subscribe(mb, txQuery)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	if q := primary.queries(); q[txQuery] != 1 || q[heartbeatQuery] != 1 {
+		t.Fatalf("expected primary to carry the subscription and the heartbeat, got %v", q)
+	}
+	if q := standby.queries(); len(q) != 0 {
+		t.Fatalf("expected standby to be idle, got %v", q)
+	}
+
+	// Block headers keep the primary alive
+	for range 4 {
+		primary.deliver(heartbeatQuery, ctypes.EventDataNewBlockHeader{})
+		time.Sleep(100 * time.Millisecond)
+	}
+	if q := standby.queries(); len(q) != 0 {
+		t.Fatalf("expected live primary to be kept, got standby subscriptions %v", q)
+	}
+	primary.deliver(txQuery, txEvent(5, "a"))
+
+	// Once the primary stalls, the subscriptions move to the standby
+	deadline := time.Now().Add(5 * time.Second)
+	for standby.queries()[txQuery] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the standby to be promoted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if q := primary.queries(); len(q) != 0 {
+		t.Fatalf("expected stalled primary to be unsubscribed, got %v", q)
+	}
+
+	standby.deliver(txQuery, txEvent(5, "a"))
+	standby.deliver(txQuery, txEvent(6, "b"))
+	if events := receiveAll(mb, 100*time.Millisecond); len(events) != 2 {
+		t.Fatalf("expected 2 unique events across the promotion, got %d", len(events))
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("failed to unsubscribe: %v", err)
+	}
+	if q := standby.queries(); q[txQuery] != 0 {
+		t.Fatalf("expected subscription to be cancelled, got %v", q)
+	}
+}
+
+func TestWebsocketPoolCloseStopsForwarding(t *testing.T) {
+	txQuery := ctypes.EventQueryTx.String()
+	ws := newFakeWebsocket()
+	pool := NewWebsocketPool([]Websocket{ws}, config.WebsocketPoolConfig{}, zerolog.Nop())
+
+	mb := NewMailbox(100)
+	if _, err := pool.Subscribe(mb, txQuery); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	ws.deliver(txQuery, txEvent(5, "a"))
+	if events := receiveAll(mb, 50*time.Millisecond); len(events) != 1 {
+		t.Fatalf("expected 1 event before closing, got %d", len(events))
+	}
+
+	pool.Close()
+	// Give the forwarding goroutine time to see the pool close
+	time.Sleep(20 * time.Millisecond)
+	ws.deliver(txQuery, txEvent(6, "b"))
+	if events := receiveAll(mb, 50*time.Millisecond); len(events) != 0 {
+		t.Fatalf("expected no events after closing, got %d", len(events))
+	}
+}
+
+func TestWebsocketPoolPromotesConnectedStandby(t *testing.T) {
+	txQuery := ctypes.EventQueryTx.String()
+	primary, down, up := newFakeWebsocket(), newFakeWebsocket(), newFakeWebsocket()
+	down.disconnected.Store(true)
+	pool := NewWebsocketPool([]Websocket{primary, down, up}, config.WebsocketPoolConfig{
+		Mode:         config.WebsocketPoolPrimaryStandby,
+		StallTimeout: time.Hour,
+	}, zerolog.Nop())
+	defer pool.Close()
+
+	if _, err := pool.Subscribe(NewMailbox(100), txQuery); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	pool.promoteStandby()
+	if q := down.queries(); len(q) != 0 {
+		t.Fatalf("expected the disconnected standby to be skipped, got %v", q)
+	}
+	if q := up.queries(); q[txQuery] != 1 {
+		t.Fatalf("expected the connected standby to be promoted, got %v", q)
+	}
+
+	// Without a connected standby the primary is kept
+	primary.disconnected.Store(true)
+	pool.promoteStandby()
+	if q := up.queries(); q[txQuery] != 1 {
+		t.Fatalf("expected the primary to keep the subscription, got %v", q)
+	}
+}
@@ -0,0 +1,267 @@
+package tmrpc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	ctypes "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/allora-network/allora-sdk-go/config"
+)
+
+type rpcRequest struct {
+	ID     int    `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		Query string `json:"query"`
+	} `json:"params"`
+}
+
+// fakeCometServer speaks enough of the CometBFT websocket protocol to accept or
+// reject subscriptions and push events
+type fakeCometServer struct {
+	*httptest.Server
+
+	reject string
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	requests []rpcRequest
+	subIDs   map[string]int
+}
+
+func newFakeCometServer(t *testing.T, reject string) *fakeCometServer {
+	t.Helper()
+
+	s := &fakeCometServer{reject: reject, subIDs: make(map[string]int)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+
+		for {
+			var req rpcRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			var resp jsonrpctypes.RPCResponse
+			switch {
+			case req.Method == "subscribe" && req.Params.Query == s.reject:
+				resp = jsonrpctypes.RPCInternalError(jsonrpctypes.JSONRPCIntID(req.ID), errMaxSubscriptions)
+			case req.Method == "subscribe":
+				s.subIDs[req.Params.Query] = req.ID
+				resp = jsonrpctypes.NewRPCSuccessResponse(jsonrpctypes.JSONRPCIntID(req.ID), &rpctypes.ResultSubscribe{})
+			default:
+				delete(s.subIDs, req.Params.Query)
+				resp = jsonrpctypes.NewRPCSuccessResponse(jsonrpctypes.JSONRPCIntID(req.ID), &rpctypes.ResultUnsubscribe{})
+			}
+			_ = conn.WriteJSON(resp)
+			s.mu.Unlock()
+		}
+	}))
+	return s
+}
+
+var errMaxSubscriptions = errors.New("max_subscriptions_per_client 5 reached")
+
+func (s *fakeCometServer) publish(t *testing.T, query string, data ctypes.TMEventData) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.subIDs[query]
+	if !ok {
+		t.Fatalf("no subscription for %q", query)
+	}
+	resp := jsonrpctypes.NewRPCSuccessResponse(jsonrpctypes.JSONRPCIntID(id), &rpctypes.ResultEvent{Query: query, Data: data})
+	if err := s.conn.WriteJSON(resp); err != nil {
+		t.Fatalf("failed to publish event: %v", err)
+	}
+}
+
+func (s *fakeCometServer) methods() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var methods []string
+	for _, req := range s.requests {
+		methods = append(methods, req.Method+" "+req.Params.Query)
+	}
+	return methods
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWebsocketSubscriptionLifecycle(t *testing.T) {
+	const blocks = "tm.event = 'NewBlockEvents'"
+	const rejected = "tm.event = 'Tx'"
+
+	srv := newFakeCometServer(t, rejected)
+	defer srv.Close()
+
+	ws := NewTendermintWebsocket(srv.URL, zerolog.Nop())
+	defer ws.Close()
+
+	if _, err := ws.Subscribe(NewMailbox(10), "tm.event = "); err == nil {
+		t.Fatal("expected invalid query to be rejected")
+	}
+
+	// Two subscriptions with the same query share one remote subscription
+	mb1, mb2 := NewMailbox(10), NewMailbox(10)
+	sub1, err := ws.Subscribe(mb1, blocks)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	sub2, err := ws.Subscribe(mb2, blocks)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	waitFor(t, "subscribe", func() bool { return len(srv.methods()) == 1 })
+
+	srv.publish(t, blocks, ctypes.EventDataNewBlockEvents{Height: 42})
+	for _, mb := range []*Mailbox{mb1, mb2} {
+		select {
+		case <-mb.Notify():
+			evt, _ := mb.Retrieve()
+			if evt.(ctypes.EventDataNewBlockEvents).Height != 42 {
+				t.Fatalf("unexpected event %+v", evt)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+
+	// The remote subscription is cancelled with the last local one
+	if err := sub1.Unsubscribe(); err != nil {
+		t.Fatalf("failed to unsubscribe: %v", err)
+	}
+	if err := sub2.Unsubscribe(); err != nil {
+		t.Fatalf("failed to unsubscribe: %v", err)
+	}
+	waitFor(t, "unsubscribe", func() bool { return len(srv.methods()) == 2 })
+	if got := srv.methods(); got[0] != "subscribe "+blocks || got[1] != "unsubscribe "+blocks {
+		t.Fatalf("unexpected requests %v", got)
+	}
+
+	// Rejections are reported on the subscription's error channel
+	sub3, err := ws.Subscribe(NewMailbox(10), rejected)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	select {
+	case err := <-sub3.Err():
+		if !strings.Contains(err.Error(), "max_subscriptions_per_client") {
+			t.Fatalf("unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for subscription error")
+	}
+	if err := sub3.Unsubscribe(); err != nil {
+		t.Fatalf("unsubscribing a rejected subscription must not fail: %v", err)
+	}
+	if got := srv.methods(); len(got) != 3 {
+		t.Fatalf("rejected subscription must not be unsubscribed remotely: %v", got)
+	}
+}
+
+type stateChange struct {
+	state config.ConnectionState
+	err   error
+}
+
+func recordStates() (func(string, config.ConnectionState, error), func() []stateChange) {
+	var (
+		mu     sync.Mutex
+		states []stateChange
+	)
+	record := func(_ string, state config.ConnectionState, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, stateChange{state, err})
+	}
+	get := func() []stateChange {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(states)
+	}
+	return record, get
+}
+
+func TestWebsocketReconnectsWhenStalled(t *testing.T) {
+	blocks := ctypes.EventQueryNewBlock.String()
+
+	srv := newFakeCometServer(t, "")
+	defer srv.Close()
+
+	record, states := recordStates()
+	ws := NewTendermintWebsocket(srv.URL, zerolog.Nop(), WithStallTimeout(200*time.Millisecond), WithStateCallback(record))
+	defer ws.Close()
+
+	if _, err := ws.Subscribe(NewMailbox(10), blocks); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	// The node answers pings but produces no blocks; the subscription is renewed on
+	// the new connection
+	waitFor(t, "resubscribe", func() bool { return len(srv.methods()) == 2 })
+	got := states()
+	if len(got) < 4 || got[0].state != config.ConnectionStateConnecting || got[1].state != config.ConnectionStateConnected {
+		t.Fatalf("unexpected states %+v", got)
+	}
+	if got[2].state != config.ConnectionStateDisconnected || !errors.Is(got[2].err, errStalled) {
+		t.Fatalf("expected a stall disconnect, got %+v", got[2])
+	}
+}
+
+func TestWebsocketKeepaliveDetectsHalfOpenConnection(t *testing.T) {
+	// The server never reads, so pings are never answered
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	record, states := recordStates()
+	ws := NewTendermintWebsocket(srv.URL, zerolog.Nop(), WithPingInterval(50*time.Millisecond), WithStateCallback(record))
+	defer ws.Close()
+
+	waitFor(t, "reconnect", func() bool { return connections.Load() >= 2 })
+	var netErr net.Error
+	if got := states(); got[2].state != config.ConnectionStateDisconnected || !errors.As(got[2].err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("expected a keepalive timeout, got %+v", got)
+	}
+}
@@ -3,7 +3,9 @@ package allora
 import (
 	"fmt"
 
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
 )
 
 // TxParams contains all parameters needed to build and sign a transaction
@@ -22,6 +24,16 @@ type TxParams struct {
 	// Optional fields
 	Memo          string
 	TimeoutHeight uint64
+
+	// SignMode selects how the transaction is signed: SIGN_MODE_DIRECT,
+	// SIGN_MODE_LEGACY_AMINO_JSON or SIGN_MODE_TEXTUAL. Unspecified means
+	// SIGN_MODE_DIRECT.
+	SignMode signingtypes.SignMode
+
+	// CoinMetadataQuery resolves the denom metadata used to render amounts in
+	// SIGN_MODE_TEXTUAL. QueryAndBuild sets it to query the chain; when it is nil,
+	// uallo is assumed to be displayed as allo with exponent 18.
+	CoinMetadataQuery CoinMetadataQueryFn
 }
 
 // DefaultTxParams returns TxParams with sensible defaults for Allora Network
@@ -44,6 +56,14 @@ func (p *TxParams) Validate() error {
 	if p.FeeAmount.Empty() {
 		return fmt.Errorf("fee amount is required")
 	}
+	switch p.SignMode {
+	case signingtypes.SignMode_SIGN_MODE_UNSPECIFIED,
+		signingtypes.SignMode_SIGN_MODE_DIRECT,
+		signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		signingtypes.SignMode_SIGN_MODE_TEXTUAL:
+	default:
+		return fmt.Errorf("unsupported sign mode %s", p.SignMode)
+	}
 	return nil
 }
 
@@ -64,8 +84,8 @@ func (p *TxParams) Validate() error {
 //
 // Example:
 //
-//	fromAddr, _ := sdk.AccAddressFromBech32("allo1...")
-//	toAddr, _ := sdk.AccAddressFromBech32("allo1...")
+//	fromAddr, _ := allora.ParseAccAddress("allo1...")
+//	toAddr, _ := allora.ParseAccAddress("allo1...")
 //	amount := sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000))
 //
 //	params := &allora.TxParams{
@@ -108,12 +128,12 @@ func CreateUnsignedSendTx(
 // SignTransaction signs a previously created unsigned transaction
 //
 // This function takes an unsigned transaction (created with CreateUnsignedSendTx)
-// and signs it with the provided wallet. This enables a two-phase workflow where
+// and signs it with the provided signer. This enables a two-phase workflow where
 // transactions can be created, stored, and signed at a later time.
 //
 // Parameters:
 //   - unsignedTx: The unsigned transaction bytes from CreateUnsignedSendTx
-//   - wallet: The wallet to sign the transaction with
+//   - signer: The Signer to sign the transaction with (a Wallet or a KMS/HSM-backed signer)
 //   - params: The same TxParams used to create the unsigned transaction
 //
 // Returns:
@@ -129,7 +149,7 @@ func CreateUnsignedSendTx(
 //	signedTx, err := allora.SignTransaction(unsignedTx, wallet, params)
 func SignTransaction(
 	unsignedTx []byte,
-	wallet *Wallet,
+	signer Signer,
 	params *TxParams,
 ) ([]byte, error) {
 	if err := params.Validate(); err != nil {
@@ -140,12 +160,82 @@ func SignTransaction(
 		return nil, fmt.Errorf("unsigned transaction is empty")
 	}
 
-	if wallet == nil {
-		return nil, fmt.Errorf("wallet is required")
+	builder := newTxBuilder()
+	return builder.signTx(unsignedTx, signer, params)
+}
+
+// GetSignBytes returns the exact bytes that must be signed for a transaction
+//
+// This enables external signers (hardware wallets, custody providers, KMS) that
+// only accept raw sign bytes: the bytes are computed for the signer's public key
+// and params.SignMode, signed externally, and the resulting signature is attached
+// with InjectSignature using the same public key and params.
+//
+// Parameters:
+//   - unsignedTx: The unsigned transaction bytes
+//   - pubKey: The signer's public key
+//   - params: The same TxParams used to create the unsigned transaction
+//
+// Returns:
+//   - []byte: The bytes to sign (protobuf SignDoc, amino JSON or textual CBOR,
+//     depending on the sign mode)
+//   - error: Any error that occurred
+//
+// Example:
+//
+//	params.SignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+//
+//	signBytes, err := allora.GetSignBytes(unsignedTx, pubKey, params)
+//	signature, err := hsm.Sign(signBytes) // secp256k1 over sha256(signBytes), 64-byte r||s
+//
+//	signedTx, err := allora.InjectSignature(unsignedTx, pubKey, signature, params)
+func GetSignBytes(unsignedTx []byte, pubKey cryptotypes.PubKey, params *TxParams) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction parameters: %w", err)
+	}
+	if len(unsignedTx) == 0 {
+		return nil, fmt.Errorf("unsigned transaction is empty")
+	}
+	if pubKey == nil {
+		return nil, fmt.Errorf("public key is required")
 	}
 
 	builder := newTxBuilder()
-	return builder.signTx(unsignedTx, wallet.PrivKey, params)
+	_, _, signBytes, err := builder.prepareSignature(unsignedTx, pubKey, params)
+	if err != nil {
+		return nil, err
+	}
+	return signBytes, nil
+}
+
+// InjectSignature attaches a signature produced externally over the bytes
+// returned by GetSignBytes and returns the signed transaction
+//
+// The signature is verified against the public key before it is attached.
+func InjectSignature(unsignedTx []byte, pubKey cryptotypes.PubKey, signature []byte, params *TxParams) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction parameters: %w", err)
+	}
+	if len(unsignedTx) == 0 {
+		return nil, fmt.Errorf("unsigned transaction is empty")
+	}
+	if pubKey == nil {
+		return nil, fmt.Errorf("public key is required")
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("signature is empty")
+	}
+
+	builder := newTxBuilder()
+	txBuilder, signMode, signBytes, err := builder.prepareSignature(unsignedTx, pubKey, params)
+	if err != nil {
+		return nil, err
+	}
+	if !pubKey.VerifySignature(signBytes, signature) {
+		return nil, fmt.Errorf("signature does not match the sign bytes for %s", signMode)
+	}
+
+	return builder.setSignature(txBuilder, signMode, pubKey, signature, params)
 }
 
 // CreateSignedSendTx is a convenience function that creates and signs a send transaction in one step
@@ -157,7 +247,7 @@ func SignTransaction(
 //   - fromAddr: The sender's Allora address (allo...)
 //   - toAddr: The recipient's Allora address (allo...)
 //   - amount: The amount to send
-//   - wallet: The wallet to sign with (must match fromAddr)
+//   - signer: The Signer to sign with (must match fromAddr)
 //   - params: Transaction parameters
 //
 // Returns:
@@ -168,7 +258,7 @@ func SignTransaction(
 //
 //	wallet, _ := allora.GenerateWallet()
 //	fromAddr := wallet.Address
-//	toAddr, _ := sdk.AccAddressFromBech32("allo1...")
+//	toAddr, _ := allora.ParseAccAddress("allo1...")
 //	amount := sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000))
 //
 //	params := &allora.TxParams{
@@ -184,12 +274,16 @@ func CreateSignedSendTx(
 	fromAddr sdk.AccAddress,
 	toAddr sdk.AccAddress,
 	amount sdk.Coins,
-	wallet *Wallet,
+	signer Signer,
 	params *TxParams,
 ) ([]byte, error) {
-	// Verify wallet address matches from address
-	if !wallet.Address.Equals(fromAddr) {
-		return nil, fmt.Errorf("wallet address does not match from address")
+	// Verify signer address matches from address
+	pubKey, err := signerPubKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	if !sdk.AccAddress(pubKey.Address()).Equals(fromAddr) {
+		return nil, fmt.Errorf("signer address does not match from address")
 	}
 
 	// Create unsigned transaction
@@ -199,7 +293,7 @@ func CreateSignedSendTx(
 	}
 
 	// Sign the transaction
-	signedTx, err := SignTransaction(unsignedTx, wallet, params)
+	signedTx, err := SignTransaction(unsignedTx, signer, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
@@ -207,6 +301,123 @@ func CreateSignedSendTx(
 	return signedTx, nil
 }
 
+// CreateUnsignedTx creates an unsigned transaction from an arbitrary list of messages
+//
+// This is the generic counterpart of CreateUnsignedSendTx: it accepts any sdk.Msg
+// (emissions, staking, gov, authz, feegrant, ...) and packs all of them into a
+// single transaction using the same encoding and TxParams handling. Message
+// signers must be valid bech32 addresses; the rest of the stateless validation is
+// left to the chain.
+//
+// Parameters:
+//   - msgs: The messages to include, in execution order
+//   - params: Transaction parameters including chain ID, account info, gas, and fees
+//
+// Returns:
+//   - []byte: The unsigned transaction bytes (can be stored and signed later)
+//   - error: Any error that occurred during transaction creation
+//
+// Example:
+//
+//	delegate := stakingtypes.NewMsgDelegate(delegator, validator, sdk.NewInt64Coin("uallo", 1000000))
+//	vote := govv1.NewMsgVote(voter, 42, govv1.OptionYes, "")
+//
+//	unsignedTx, err := allora.CreateUnsignedTx([]sdk.Msg{delegate, vote}, params)
+func CreateUnsignedTx(msgs []sdk.Msg, params *TxParams) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction parameters: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("at least one message is required")
+	}
+	for i, msg := range msgs {
+		if msg == nil {
+			return nil, fmt.Errorf("message %d is nil", i)
+		}
+		if err := validateMsgSigners(msg); err != nil {
+			return nil, fmt.Errorf("invalid message %d (%s): %w", i, sdk.MsgTypeURL(msg), err)
+		}
+	}
+
+	builder := newTxBuilder()
+	return builder.buildUnsignedTx(msgs, params)
+}
+
+// CreateSignedTx is a convenience function that creates and signs a transaction
+// containing arbitrary messages in one step
+//
+// Example:
+//
+//	signedTx, err := allora.CreateSignedTx([]sdk.Msg{msg1, msg2}, wallet, params)
+func CreateSignedTx(msgs []sdk.Msg, signer Signer, params *TxParams) ([]byte, error) {
+	unsignedTx, err := CreateUnsignedTx(msgs, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unsigned transaction: %w", err)
+	}
+
+	signedTx, err := SignTransaction(unsignedTx, signer, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// TxBuilder accumulates messages for a single multi-message transaction
+//
+// TxBuilder is a fluent wrapper around CreateUnsignedTx/CreateSignedTx for callers
+// that assemble a transaction incrementally (e.g. batching several emissions
+// submissions or staking operations from the same key).
+//
+// Example:
+//
+//	unsignedTx, err := allora.NewTxBuilder(params).
+//	    AddMsgs(registerMsg).
+//	    AddMsgs(addStakeMsg).
+//	    BuildUnsigned()
+type TxBuilder struct {
+	params *TxParams
+	msgs   []sdk.Msg
+}
+
+// NewTxBuilder creates a new TxBuilder using the given transaction parameters
+func NewTxBuilder(params *TxParams) *TxBuilder {
+	return &TxBuilder{params: params}
+}
+
+// AddMsgs appends messages to the transaction
+func (b *TxBuilder) AddMsgs(msgs ...sdk.Msg) *TxBuilder {
+	b.msgs = append(b.msgs, msgs...)
+	return b
+}
+
+// Msgs returns the messages added so far
+func (b *TxBuilder) Msgs() []sdk.Msg {
+	return b.msgs
+}
+
+// Params returns the transaction parameters used by the builder
+func (b *TxBuilder) Params() *TxParams {
+	return b.params
+}
+
+// BuildUnsigned encodes the accumulated messages into an unsigned transaction
+func (b *TxBuilder) BuildUnsigned() ([]byte, error) {
+	if b.params == nil {
+		return nil, fmt.Errorf("transaction parameters are required")
+	}
+	return CreateUnsignedTx(b.msgs, b.params)
+}
+
+// BuildSigned encodes the accumulated messages and signs the transaction with the given signer
+func (b *TxBuilder) BuildSigned(signer Signer) ([]byte, error) {
+	if b.params == nil {
+		return nil, fmt.Errorf("transaction parameters are required")
+	}
+	return CreateSignedTx(b.msgs, signer, b.params)
+}
+
 // ParseTxBytes parses transaction bytes and returns the decoded transaction
 // This is useful for inspecting transaction contents before broadcasting
 func ParseTxBytes(txBytes []byte) (*sdk.Tx, error) {
@@ -3,11 +3,17 @@ package allora
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
+	bankv1beta1 "cosmossdk.io/api/cosmos/bank/v1beta1"
+	signingv1beta1 "cosmossdk.io/api/cosmos/tx/signing/v1beta1"
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
@@ -24,15 +30,175 @@ type txBuilder struct {
 
 // newTxBuilder creates a new transaction builder with the Allora codec
 func newTxBuilder() *txBuilder {
-	// Use the Allora codec which has all interfaces registered
-	txConfig := authtx.NewTxConfig(alloracodec.CosmosCodec(), authtx.DefaultSignModes)
-
 	return &txBuilder{
-		txConfig: txConfig,
+		txConfig: defaultTxConfig(),
 		codec:    alloracodec.CosmosCodec(),
 	}
 }
 
+var (
+	txConfigOnce sync.Once
+	txConfig     sdkclient.TxConfig
+)
+
+// defaultTxConfig returns the shared TxConfig: the Allora codec (which has all
+// interfaces registered) with SIGN_MODE_TEXTUAL enabled on top of the default
+// sign modes. SIGN_MODE_DIRECT stays the default.
+func defaultTxConfig() sdkclient.TxConfig {
+	txConfigOnce.Do(func() {
+		cfg, err := authtx.NewTxConfigWithOptions(alloracodec.CosmosCodec(), authtx.ConfigOptions{
+			EnabledSignModes:           append(slices.Clone(authtx.DefaultSignModes), signingtypes.SignMode_SIGN_MODE_TEXTUAL),
+			TextualCoinMetadataQueryFn: textualCoinMetadata,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("failed to create tx config: %v", err))
+		}
+		txConfig = cfg
+	})
+	return txConfig
+}
+
+var (
+	signingCodecOnce sync.Once
+	signingCodec     *codec.ProtoCodec
+	signingCodecErr  error
+)
+
+// validateMsgSigners checks that the signers of a message are well-formed bech32
+// addresses. Any prefix is accepted, so that transactions for other Cosmos chains
+// can be built too.
+//
+// Messages' ValidateBasic is deliberately not called: the allora emissions and IBC
+// messages decode bech32 addresses with the global sdk.Config, which the SDK leaves
+// at the cosmos prefixes, and would reject valid allo1... addresses. The chain runs
+// the full stateless validation in CheckTx.
+func validateMsgSigners(msg sdk.Msg) error {
+	signingCodecOnce.Do(func() {
+		signingCodec, signingCodecErr = alloracodec.NewSigningCodec(anyPrefixCodec{}, anyPrefixCodec{})
+	})
+	if signingCodecErr != nil {
+		return fmt.Errorf("failed to create signing codec: %w", signingCodecErr)
+	}
+
+	signers, _, err := signingCodec.GetMsgV1Signers(msg)
+	if err != nil {
+		return fmt.Errorf("invalid signer: %w", err)
+	}
+	if len(signers) == 0 {
+		return fmt.Errorf("message has no signers")
+	}
+	return nil
+}
+
+// anyPrefixCodec decodes bech32 addresses regardless of their prefix and encodes
+// them as Allora addresses
+type anyPrefixCodec struct{}
+
+func (anyPrefixCodec) StringToBytes(text string) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty address string is not allowed")
+	}
+	_, bz, err := bech32.DecodeAndConvert(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+func (anyPrefixCodec) BytesToString(bz []byte) (string, error) {
+	return AlloraAddressPrefixes.AccountCodec().BytesToString(bz)
+}
+
+// CoinMetadataQueryFn returns the bank metadata of a denom, which SIGN_MODE_TEXTUAL
+// uses to render amounts. A nil metadata renders the amount in its base denom.
+type CoinMetadataQueryFn func(ctx context.Context, denom string) (*bankv1beta1.Metadata, error)
+
+// QueryCoinMetadataFn returns a CoinMetadataQueryFn that resolves denom metadata
+// with the client's bank DenomMetadata query, as the chain does when it renders
+// the textual sign bytes. Results are cached for the lifetime of the function.
+func QueryCoinMetadataFn(client Client) CoinMetadataQueryFn {
+	var (
+		mu       sync.Mutex
+		metadata = make(map[string]*bankv1beta1.Metadata)
+	)
+	return func(ctx context.Context, denom string) (*bankv1beta1.Metadata, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if md, ok := metadata[denom]; ok {
+			return md, nil
+		}
+
+		resp, err := client.Cosmos().Bank().DenomMetadata(ctx, &banktypes.QueryDenomMetadataRequest{Denom: denom})
+		var md *bankv1beta1.Metadata
+		switch {
+		case isNotFound(err):
+			// The chain renders denoms without metadata in their base denom too
+		case err != nil:
+			return nil, fmt.Errorf("failed to query metadata of denom %s: %w", denom, err)
+		default:
+			md = apiCoinMetadata(&resp.Metadata)
+		}
+		metadata[denom] = md
+		return md, nil
+	}
+}
+
+// apiCoinMetadata converts bank metadata to the API type used by the textual renderer
+func apiCoinMetadata(md *banktypes.Metadata) *bankv1beta1.Metadata {
+	units := make([]*bankv1beta1.DenomUnit, len(md.DenomUnits))
+	for i, unit := range md.DenomUnits {
+		units[i] = &bankv1beta1.DenomUnit{
+			Denom:    unit.Denom,
+			Exponent: unit.Exponent,
+			Aliases:  unit.Aliases,
+		}
+	}
+	return &bankv1beta1.Metadata{
+		Description: md.Description,
+		DenomUnits:  units,
+		Base:        md.Base,
+		Display:     md.Display,
+		Name:        md.Name,
+		Symbol:      md.Symbol,
+		Uri:         md.URI,
+		UriHash:     md.URIHash,
+	}
+}
+
+type coinMetadataQueryKey struct{}
+
+// textualCoinMetadata resolves denom metadata for SIGN_MODE_TEXTUAL with the
+// CoinMetadataQueryFn carried by the signing context, if any
+func textualCoinMetadata(ctx context.Context, denom string) (*bankv1beta1.Metadata, error) {
+	if query, ok := ctx.Value(coinMetadataQueryKey{}).(CoinMetadataQueryFn); ok && query != nil {
+		return query(ctx, denom)
+	}
+	return fallbackCoinMetadata(ctx, denom)
+}
+
+// fallbackCoinMetadata is a fallback for signing offline, when TxParams carries no
+// CoinMetadataQueryFn: it assumes the chain's uallo metadata is uallo/allo with
+// exponent 18. If the on-chain metadata differs the node renders different
+// textual sign bytes and rejects the signature. Other denoms are rendered with
+// their base denom.
+func fallbackCoinMetadata(_ context.Context, denom string) (*bankv1beta1.Metadata, error) {
+	if denom != DefaultFeeDenom {
+		return nil, nil
+	}
+	return &bankv1beta1.Metadata{
+		Base:    DefaultFeeDenom,
+		Display: "allo",
+		DenomUnits: []*bankv1beta1.DenomUnit{
+			{Denom: DefaultFeeDenom, Exponent: 0},
+			{Denom: "allo", Exponent: 18},
+		},
+	}, nil
+}
+
 // buildUnsignedSendTx creates an unsigned send transaction
 func (b *txBuilder) buildUnsignedSendTx(
 	fromAddr sdk.AccAddress,
@@ -40,14 +206,24 @@ func (b *txBuilder) buildUnsignedSendTx(
 	amount sdk.Coins,
 	params *TxParams,
 ) ([]byte, error) {
-	// Create the MsgSend
-	msg := banktypes.NewMsgSend(fromAddr, toAddr, amount)
+	// Create the MsgSend. banktypes.NewMsgSend is not used because it encodes the
+	// addresses with the global sdk.Config prefix.
+	msg := &banktypes.MsgSend{
+		FromAddress: FormatAccAddress(fromAddr),
+		ToAddress:   FormatAccAddress(toAddr),
+		Amount:      amount,
+	}
 
+	return b.buildUnsignedTx([]sdk.Msg{msg}, params)
+}
+
+// buildUnsignedTx creates an unsigned transaction containing the given messages
+func (b *txBuilder) buildUnsignedTx(msgs []sdk.Msg, params *TxParams) ([]byte, error) {
 	// Create transaction builder
 	txBuilder := b.txConfig.NewTxBuilder()
 
-	// Set the message
-	if err := txBuilder.SetMsgs(msg); err != nil {
+	// Set the messages
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
 		return nil, fmt.Errorf("failed to set messages: %w", err)
 	}
 
@@ -69,64 +245,158 @@ func (b *txBuilder) buildUnsignedSendTx(
 	return txBytes, nil
 }
 
-// signTx signs a transaction with the provided private key
+// signTx signs a transaction with the provided signer
 func (b *txBuilder) signTx(
 	txBytes []byte,
-	privKey cryptotypes.PrivKey,
+	signer Signer,
 	params *TxParams,
 ) ([]byte, error) {
-	// Decode the unsigned transaction
-	txDecoder := b.txConfig.TxDecoder()
-	decodedTx, err := txDecoder(txBytes)
+	// Get public key
+	pubKey, err := signerPubKey(signer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+		return nil, err
 	}
 
-	// Convert to TxBuilder for signing
-	txBuilder, err := b.txConfig.WrapTxBuilder(decodedTx)
+	txBuilder, signMode, bytesToSign, err := b.prepareSignature(txBytes, pubKey, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wrap transaction: %w", err)
+		return nil, err
 	}
 
-	// Get public key
-	pubKey := privKey.PubKey()
+	// Sign the bytes
+	signature, err := signer.Sign(bytesToSign)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return b.setSignature(txBuilder, signMode, pubKey, signature, params)
+}
 
-	// Convert API sign mode to internal
-	apiSignMode := b.txConfig.SignModeHandler().DefaultMode()
-	signMode, err := authsigning.APISignModeToInternal(apiSignMode)
+// prepareSignature decodes an unsigned transaction, sets an empty signature for
+// the signer in the sign mode selected by params and returns the bytes to sign
+func (b *txBuilder) prepareSignature(
+	txBytes []byte,
+	pubKey cryptotypes.PubKey,
+	params *TxParams,
+) (sdkclient.TxBuilder, signingtypes.SignMode, []byte, error) {
+	txBuilder, err := b.decodeTxBuilder(txBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert sign mode: %w", err)
+		return nil, 0, nil, err
 	}
 
-	// Create signature data with empty signature (first pass)
-	sigData := &signingtypes.SingleSignatureData{
-		SignMode:  signMode,
-		Signature: nil,
+	signMode, err := b.resolveSignMode(params)
+	if err != nil {
+		return nil, 0, nil, err
 	}
 
+	// Create signature data with empty signature (first pass)
 	sig := signingtypes.SignatureV2{
-		PubKey:   pubKey,
-		Data:     sigData,
+		PubKey: pubKey,
+		Data: &signingtypes.SingleSignatureData{
+			SignMode:  signMode,
+			Signature: nil,
+		},
 		Sequence: params.Sequence,
 	}
 
 	// Set the signature (with nil signature data) to get proper sign bytes
 	if err := txBuilder.SetSignatures(sig); err != nil {
-		return nil, fmt.Errorf("failed to set signatures: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to set signatures: %w", err)
+	}
+
+	// Get the bytes to sign
+	bytesToSign, err := b.signBytes(txBuilder, signMode, pubKey, params)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return txBuilder, signMode, bytesToSign, nil
+}
+
+// setSignature sets the signer's final signature and encodes the signed transaction
+func (b *txBuilder) setSignature(
+	txBuilder sdkclient.TxBuilder,
+	signMode signingtypes.SignMode,
+	pubKey cryptotypes.PubKey,
+	signature []byte,
+	params *TxParams,
+) ([]byte, error) {
+	sig := signingtypes.SignatureV2{
+		PubKey: pubKey,
+		Data: &signingtypes.SingleSignatureData{
+			SignMode:  signMode,
+			Signature: signature,
+		},
+		Sequence: params.Sequence,
+	}
+
+	// Set the actual signature
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, fmt.Errorf("failed to set final signatures: %w", err)
+	}
+
+	// Encode the signed transaction
+	signedTxBytes, err := b.txConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed transaction: %w", err)
+	}
+
+	return signedTxBytes, nil
+}
+
+// resolveSignMode returns the sign mode selected by params, or the default sign
+// mode of the TxConfig if none is selected
+func (b *txBuilder) resolveSignMode(params *TxParams) (signingtypes.SignMode, error) {
+	if params.SignMode == signingtypes.SignMode_SIGN_MODE_UNSPECIFIED {
+		signMode, err := authsigning.APISignModeToInternal(b.txConfig.SignModeHandler().DefaultMode())
+		if err != nil {
+			return 0, fmt.Errorf("failed to convert sign mode: %w", err)
+		}
+		return signMode, nil
 	}
 
-	// Create signer data
+	// The API and internal sign mode enums share their values
+	if !slices.Contains(b.txConfig.SignModeHandler().SupportedModes(), signingv1beta1.SignMode(params.SignMode)) {
+		return 0, fmt.Errorf("unsupported sign mode %s", params.SignMode)
+	}
+	return params.SignMode, nil
+}
+
+// decodeTxBuilder decodes transaction bytes into a TxBuilder that can be signed
+func (b *txBuilder) decodeTxBuilder(txBytes []byte) (sdkclient.TxBuilder, error) {
+	decodedTx, err := b.txConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	txBuilder, err := b.txConfig.WrapTxBuilder(decodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap transaction: %w", err)
+	}
+	return txBuilder, nil
+}
+
+// signBytes returns the bytes the signer with the given public key signs in the
+// given sign mode. For multisig accounts pubKey is the multisig public key.
+func (b *txBuilder) signBytes(
+	txBuilder sdkclient.TxBuilder,
+	signMode signingtypes.SignMode,
+	pubKey cryptotypes.PubKey,
+	params *TxParams,
+) ([]byte, error) {
 	signerData := authsigning.SignerData{
 		ChainID:       params.ChainID,
 		AccountNumber: params.AccountNumber,
 		Sequence:      params.Sequence,
-		Address:       sdk.AccAddress(pubKey.Address()).String(),
+		Address:       FormatAccAddress(sdk.AccAddress(pubKey.Address())),
 		PubKey:        pubKey,
 	}
 
-	// Get the bytes to sign
+	ctx := context.Background()
+	if params.CoinMetadataQuery != nil {
+		ctx = context.WithValue(ctx, coinMetadataQueryKey{}, params.CoinMetadataQuery)
+	}
+
 	bytesToSign, err := authsigning.GetSignBytesAdapter(
-		context.Background(),
+		ctx,
 		b.txConfig.SignModeHandler(),
 		signMode,
 		signerData,
@@ -135,35 +405,49 @@ func (b *txBuilder) signTx(
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sign bytes: %w", err)
 	}
+	return bytesToSign, nil
+}
 
-	// Sign the bytes
-	signature, err := privKey.Sign(bytesToSign)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+// buildSimulationTx creates a transaction suitable for the Simulate endpoint: the
+// messages and parameters of the real transaction plus a signer entry with an
+// empty signature. pubKey may be nil for accounts that have not yet published
+// their public key on chain.
+func (b *txBuilder) buildSimulationTx(msgs []sdk.Msg, pubKey cryptotypes.PubKey, params *TxParams) ([]byte, error) {
+	txBuilder := b.txConfig.NewTxBuilder()
+
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("failed to set messages: %w", err)
 	}
 
-	// Update signature data with actual signature
-	sigData = &signingtypes.SingleSignatureData{
-		SignMode:  signMode,
-		Signature: signature,
+	txBuilder.SetGasLimit(params.GasLimit)
+	txBuilder.SetFeeAmount(params.FeeAmount)
+	txBuilder.SetMemo(params.Memo)
+
+	if params.TimeoutHeight > 0 {
+		txBuilder.SetTimeoutHeight(params.TimeoutHeight)
 	}
 
-	sig = signingtypes.SignatureV2{
-		PubKey:   pubKey,
-		Data:     sigData,
-		Sequence: params.Sequence,
+	signMode, err := b.resolveSignMode(params)
+	if err != nil {
+		return nil, err
 	}
 
-	// Set the actual signature
+	sig := signingtypes.SignatureV2{
+		PubKey: pubKey,
+		Data: &signingtypes.SingleSignatureData{
+			SignMode:  signMode,
+			Signature: nil,
+		},
+		Sequence: params.Sequence,
+	}
 	if err := txBuilder.SetSignatures(sig); err != nil {
-		return nil, fmt.Errorf("failed to set final signatures: %w", err)
+		return nil, fmt.Errorf("failed to set signatures: %w", err)
 	}
 
-	// Encode the signed transaction
-	signedTxBytes, err := b.txConfig.TxEncoder()(txBuilder.GetTx())
+	txBytes, err := b.txConfig.TxEncoder()(txBuilder.GetTx())
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode signed transaction: %w", err)
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
 	}
 
-	return signedTxBytes, nil
+	return txBytes, nil
 }
@@ -1,9 +1,23 @@
 package allora
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
+	bankv1beta1 "cosmossdk.io/api/cosmos/bank/v1beta1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/allora-network/allora-sdk-go/config"
+	"github.com/allora-network/allora-sdk-go/cosmosrpc"
+	"github.com/allora-network/allora-sdk-go/gen/interfaces"
 )
 
 func TestCreateUnsignedSendTx(t *testing.T) {
@@ -379,3 +393,259 @@ func TestTxRoundTrip(t *testing.T) {
 
 	t.Logf("Parsed transaction with %d message(s)", len(msgs))
 }
+
+func TestCreateUnsignedTxMultipleMessages(t *testing.T) {
+	wallet, _ := GenerateWallet()
+	recipientWallet, _ := GenerateWallet()
+
+	params := &TxParams{
+		ChainID:       "allora-testnet-1",
+		AccountNumber: 123,
+		Sequence:      5,
+		GasLimit:      300000,
+		FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+		Memo:          "multi-msg",
+	}
+
+	msgs := []sdk.Msg{
+		banktypes.NewMsgSend(wallet.Address, recipientWallet.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000))),
+		stakingtypes.NewMsgDelegate(wallet.Address.String(), sdk.ValAddress(recipientWallet.Address).String(), sdk.NewInt64Coin("uallo", 2000)),
+	}
+
+	signedTx, err := NewTxBuilder(params).AddMsgs(msgs...).BuildSigned(wallet)
+	if err != nil {
+		t.Fatalf("failed to build signed transaction: %v", err)
+	}
+
+	parsedTx, err := ParseTxBytes(signedTx)
+	if err != nil {
+		t.Fatalf("failed to parse transaction: %v", err)
+	}
+
+	parsedMsgs := (*parsedTx).GetMsgs()
+	if len(parsedMsgs) != len(msgs) {
+		t.Fatalf("expected %d messages, got %d", len(msgs), len(parsedMsgs))
+	}
+	if _, ok := parsedMsgs[0].(*banktypes.MsgSend); !ok {
+		t.Errorf("expected first message to be MsgSend, got %T", parsedMsgs[0])
+	}
+	if _, ok := parsedMsgs[1].(*stakingtypes.MsgDelegate); !ok {
+		t.Errorf("expected second message to be MsgDelegate, got %T", parsedMsgs[1])
+	}
+}
+
+func TestCreateUnsignedTxInvalidMessages(t *testing.T) {
+	params := &TxParams{
+		ChainID:       "allora-testnet-1",
+		AccountNumber: 123,
+		Sequence:      5,
+		GasLimit:      200000,
+		FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+	}
+
+	if _, err := CreateUnsignedTx(nil, params); err == nil {
+		t.Error("expected error with no messages")
+	}
+
+	if _, err := CreateUnsignedTx([]sdk.Msg{nil}, params); err == nil {
+		t.Error("expected error with nil message")
+	}
+}
+
+func TestSignModes(t *testing.T) {
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	recipient, _ := GenerateWallet()
+	amount := sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000))
+
+	for _, mode := range []signingtypes.SignMode{
+		signingtypes.SignMode_SIGN_MODE_DIRECT,
+		signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		signingtypes.SignMode_SIGN_MODE_TEXTUAL,
+	} {
+		t.Run(mode.String(), func(t *testing.T) {
+			params := &TxParams{
+				ChainID:       "allora-testnet-1",
+				AccountNumber: 123,
+				Sequence:      5,
+				GasLimit:      200000,
+				FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+				SignMode:      mode,
+			}
+
+			unsignedTx, err := CreateUnsignedSendTx(wallet.Address, recipient.Address, amount, params)
+			if err != nil {
+				t.Fatalf("failed to create unsigned transaction: %v", err)
+			}
+
+			signBytes, err := GetSignBytes(unsignedTx, wallet.PubKey, params)
+			if err != nil {
+				t.Fatalf("failed to get sign bytes: %v", err)
+			}
+			if mode == signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON && !bytes.Contains(signBytes, []byte(`"chain_id":"allora-testnet-1"`)) {
+				t.Fatalf("expected amino JSON sign bytes, got %s", signBytes)
+			}
+
+			signature, err := wallet.Sign(signBytes)
+			if err != nil {
+				t.Fatalf("failed to sign: %v", err)
+			}
+			injected, err := InjectSignature(unsignedTx, wallet.PubKey, signature, params)
+			if err != nil {
+				t.Fatalf("failed to inject signature: %v", err)
+			}
+
+			// Signing in-process yields the same transaction
+			signed, err := SignTransaction(unsignedTx, wallet, params)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			if !bytes.Equal(injected, signed) {
+				t.Fatal("injected and in-process signed transactions differ")
+			}
+
+			tx, err := ParseTxBytes(injected)
+			if err != nil {
+				t.Fatalf("failed to parse transaction: %v", err)
+			}
+			sigs, err := (*tx).(authsigning.SigVerifiableTx).GetSignaturesV2()
+			if err != nil {
+				t.Fatalf("failed to get signatures: %v", err)
+			}
+			if got := sigs[0].Data.(*signingtypes.SingleSignatureData).SignMode; got != mode {
+				t.Fatalf("expected sign mode %s, got %s", mode, got)
+			}
+
+			// A signature over other bytes is rejected
+			if _, err := InjectSignature(unsignedTx, wallet.PubKey, signature, &TxParams{
+				ChainID: "other-chain", AccountNumber: 123, Sequence: 5, GasLimit: 200000,
+				FeeAmount: params.FeeAmount, SignMode: mode,
+			}); err == nil {
+				t.Fatal("expected signature over different sign bytes to be rejected")
+			}
+		})
+	}
+
+	params := &TxParams{
+		ChainID:   "allora-testnet-1",
+		GasLimit:  200000,
+		FeeAmount: sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+		SignMode:  signingtypes.SignMode_SIGN_MODE_DIRECT_AUX,
+	}
+	if err := params.Validate(); err == nil {
+		t.Fatal("expected SIGN_MODE_DIRECT_AUX to be rejected")
+	}
+}
+
+// fakeBankPool serves DenomMetadata from a fixed set of denoms
+type fakeBankPool struct {
+	cosmosrpc.ClientPool
+	bank *fakeBankClient
+}
+
+func (p *fakeBankPool) Bank() interfaces.BankClient { return p.bank }
+
+type fakeBankClient struct {
+	interfaces.BankClient
+
+	metadata map[string]banktypes.Metadata
+	queries  []string
+}
+
+func (p *fakeBankClient) DenomMetadata(ctx context.Context, req *banktypes.QueryDenomMetadataRequest, opts ...config.CallOpt) (*banktypes.QueryDenomMetadataResponse, error) {
+	p.queries = append(p.queries, req.Denom)
+	md, ok := p.metadata[req.Denom]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "client metadata for denom %s", req.Denom)
+	}
+	return &banktypes.QueryDenomMetadataResponse{Metadata: md}, nil
+}
+
+func TestQueryCoinMetadataFn(t *testing.T) {
+	bank := &fakeBankClient{metadata: map[string]banktypes.Metadata{
+		"uallo": {
+			Base:    "uallo",
+			Display: "allo",
+			DenomUnits: []*banktypes.DenomUnit{
+				{Denom: "uallo", Exponent: 0},
+				{Denom: "allo", Exponent: 6, Aliases: []string{"ALLO"}},
+			},
+		},
+	}}
+	query := QueryCoinMetadataFn(&client{cosmosPool: &fakeBankPool{bank: bank}, logger: zerolog.Nop()})
+
+	md, err := query(t.Context(), "uallo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.Display != "allo" || len(md.DenomUnits) != 2 || md.DenomUnits[1].Exponent != 6 || md.DenomUnits[1].Aliases[0] != "ALLO" {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+
+	// Denoms without metadata are rendered in their base denom, as on chain
+	md, err = query(t.Context(), "ibc/ABCD")
+	if err != nil || md != nil {
+		t.Errorf("expected no metadata for unknown denom, got %+v (err %v)", md, err)
+	}
+
+	if _, err := query(t.Context(), "uallo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bank.queries) != 2 {
+		t.Errorf("expected metadata to be cached, got queries %v", bank.queries)
+	}
+}
+
+func TestTextualSignBytesUseChainMetadata(t *testing.T) {
+	wallet, err := GenerateWallet()
+	if err != nil {
+		t.Fatalf("failed to generate wallet: %v", err)
+	}
+	params := &TxParams{
+		ChainID:       "allora-testnet-1",
+		AccountNumber: 123,
+		Sequence:      5,
+		GasLimit:      200000,
+		FeeAmount:     sdk.NewCoins(sdk.NewInt64Coin("uallo", 5000)),
+		SignMode:      signingtypes.SignMode_SIGN_MODE_TEXTUAL,
+	}
+	unsignedTx, err := CreateUnsignedSendTx(wallet.Address, wallet.Address, sdk.NewCoins(sdk.NewInt64Coin("uallo", 1000000)), params)
+	if err != nil {
+		t.Fatalf("failed to create unsigned transaction: %v", err)
+	}
+
+	fallback, err := GetSignBytes(unsignedTx, wallet.PubKey, params)
+	if err != nil {
+		t.Fatalf("failed to get sign bytes: %v", err)
+	}
+
+	var queried []string
+	params.CoinMetadataQuery = func(_ context.Context, denom string) (*bankv1beta1.Metadata, error) {
+		queried = append(queried, denom)
+		return &bankv1beta1.Metadata{
+			Base:    "uallo",
+			Display: "allo",
+			DenomUnits: []*bankv1beta1.DenomUnit{
+				{Denom: "uallo", Exponent: 0},
+				{Denom: "allo", Exponent: 6},
+			},
+		}, nil
+	}
+	onChain, err := GetSignBytes(unsignedTx, wallet.PubKey, params)
+	if err != nil {
+		t.Fatalf("failed to get sign bytes: %v", err)
+	}
+
+	if len(queried) == 0 || queried[0] != "uallo" {
+		t.Fatalf("expected uallo metadata to be queried, got %v", queried)
+	}
+	if bytes.Equal(fallback, onChain) {
+		t.Error("expected sign bytes to follow the queried metadata")
+	}
+	// 1000000uallo renders as 1 allo with the queried exponent
+	if !bytes.Contains(onChain, []byte("1 allo")) {
+		t.Errorf("expected amount rendered with the queried exponent, got %q", onChain)
+	}
+}
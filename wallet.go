@@ -1,7 +1,9 @@
 package allora
 
 import (
+	"bytes"
 	"crypto/rand"
+	"errors"
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
@@ -22,12 +24,17 @@ const (
 	DefaultHDPath = "m/44'/118'/0'/0/0"
 )
 
+// ErrWalletClosed is returned when signing with a wallet whose key material was wiped by Close
+var ErrWalletClosed = errors.New("wallet is closed")
+
 // Wallet represents an Allora wallet with a private key and address
 type Wallet struct {
 	PrivKey cryptotypes.PrivKey
 	PubKey  cryptotypes.PubKey
 	Address sdk.AccAddress
 	Mnemonic string
+
+	bech32Prefix string
 }
 
 // NewWalletFromMnemonic creates a wallet from a BIP39 mnemonic phrase
@@ -76,7 +83,8 @@ func NewWalletFromPrivateKey(privKeyBytes []byte) (*Wallet, error) {
 		return nil, fmt.Errorf("private key must be 32 bytes, got %d", len(privKeyBytes))
 	}
 
-	privKey := &secp256k1.PrivKey{Key: privKeyBytes}
+	// Copy the key so that Close does not wipe the caller's buffer
+	privKey := &secp256k1.PrivKey{Key: bytes.Clone(privKeyBytes)}
 	return newWalletFromPrivKey(privKey, "")
 }
 
@@ -105,13 +113,37 @@ func newWalletFromPrivKey(privKey cryptotypes.PrivKey, mnemonic string) (*Wallet
 	}, nil
 }
 
-// GetAddress returns the bech32-encoded Allora address
+// GetAddress returns the bech32-encoded address, with the Allora prefix unless
+// another one was set with SetBech32Prefix
 func (w *Wallet) GetAddress() string {
-	return w.Address.String()
+	return NewAddressPrefixes(w.GetBech32Prefix()).FormatAccAddress(w.Address)
+}
+
+// SetBech32Prefix sets the account address prefix used by GetAddress, e.g. to use
+// the same key on another Cosmos chain. An empty prefix restores the Allora one.
+func (w *Wallet) SetBech32Prefix(prefix string) error {
+	if prefix != "" {
+		if err := ValidateBech32Prefix(prefix); err != nil {
+			return err
+		}
+	}
+	w.bech32Prefix = prefix
+	return nil
+}
+
+// GetBech32Prefix returns the account address prefix used by GetAddress
+func (w *Wallet) GetBech32Prefix() string {
+	if w.bech32Prefix == "" {
+		return AlloraBech32Prefix
+	}
+	return w.bech32Prefix
 }
 
-// GetPrivateKeyBytes returns the raw private key bytes
+// GetPrivateKeyBytes returns the raw private key bytes (nil once the wallet is closed)
 func (w *Wallet) GetPrivateKeyBytes() []byte {
+	if w.PrivKey == nil {
+		return nil
+	}
 	return w.PrivKey.Bytes()
 }
 
@@ -127,6 +159,9 @@ func (w *Wallet) GetMnemonic() string {
 
 // Sign signs a message with the wallet's private key
 func (w *Wallet) Sign(message []byte) ([]byte, error) {
+	if w.PrivKey == nil {
+		return nil, ErrWalletClosed
+	}
 	signature, err := w.PrivKey.Sign(message)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign message: %w", err)
@@ -134,16 +169,32 @@ func (w *Wallet) Sign(message []byte) ([]byte, error) {
 	return signature, nil
 }
 
+// GetPubKey returns the wallet's public key
+func (w *Wallet) GetPubKey() cryptotypes.PubKey {
+	if w == nil {
+		return nil
+	}
+	return w.PubKey
+}
+
 // VerifySignature verifies a signature against a message using the wallet's public key
 func (w *Wallet) VerifySignature(message, signature []byte) bool {
 	return w.PubKey.VerifySignature(message, signature)
 }
 
-// init configures the SDK to use the Allora bech32 prefix
-func init() {
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount(AlloraBech32Prefix, AlloraBech32Prefix+"pub")
-	config.SetBech32PrefixForValidator(AlloraBech32Prefix+"valoper", AlloraBech32Prefix+"valoperpub")
-	config.SetBech32PrefixForConsensusNode(AlloraBech32Prefix+"valcons", AlloraBech32Prefix+"valconspub")
-	config.Seal()
+// Close zeroes the private key bytes held by the wallet and drops the mnemonic.
+// The wallet cannot sign afterwards; its address and public key remain available.
+//
+// Note that Go strings are immutable, so the mnemonic can only be dropped, not
+// overwritten: prefer loading wallets from a Keystore over keeping mnemonics around.
+func (w *Wallet) Close() error {
+	if w == nil {
+		return nil
+	}
+	if pk, ok := w.PrivKey.(*secp256k1.PrivKey); ok && pk != nil {
+		clear(pk.Key)
+	}
+	w.PrivKey = nil
+	w.Mnemonic = ""
+	return nil
 }